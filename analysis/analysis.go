@@ -0,0 +1,170 @@
+// Package analysis measures space and distance on a rules.BoardState, the way many snakes do when
+// deciding on a move: how far away each cell is, which snake can reach each cell first, how much
+// room a snake has, which cells split the board in two, and the shortest way to food.
+//
+// Distances are measured in cost. Every move costs 1, and moving onto a hazard costs
+// Options.HazardCost more for each hazard stacked there. Walls can never be entered. Snake bodies
+// can be entered once they've moved out of the way: the tail of a snake is free after one move,
+// the segment before it after two moves, and so on. Snakes that are eliminated are ignored.
+//
+// Results are indexed by cell, as y*width+x, with y = 0 at the bottom of the board to match the
+// coordinate system used by the rules engine.
+package analysis
+
+import (
+	"github.com/BattlesnakeOfficial/rules"
+)
+
+// Unreachable is the cost and number of moves to cells that can't be reached.
+const Unreachable = -1
+
+// Options change how the board is moved around.
+type Options struct {
+	// Moving off an edge of the board comes back on at the opposite edge, as in wrapped games.
+	Wrapped bool
+	// The extra cost of moving onto a hazard, for each hazard stacked there.
+	HazardCost int
+	// Treat snake bodies as if they'll never move, instead of freeing each segment when the
+	// snake's tail would have moved past it.
+	StaticBodies bool
+}
+
+// OptionsForGame returns the options matching a game type, without any hazard cost.
+func OptionsForGame(gameType string) Options {
+	return Options{Wrapped: gameType == rules.GameTypeWrapped || gameType == rules.GameTypeWrappedConstrictor}
+}
+
+// graph describes the cells of a board for searching through.
+type graph struct {
+	width, height int
+	options       Options
+	// The extra cost of entering each cell.
+	hazardCost []int
+	// The number of moves until each cell can be entered, or -1 for cells that never can.
+	freeAfter []int
+}
+
+func newGraph(b *rules.BoardState, options Options) *graph {
+	g := &graph{
+		width:      b.Width,
+		height:     b.Height,
+		options:    options,
+		hazardCost: make([]int, b.Width*b.Height),
+		freeAfter:  make([]int, b.Width*b.Height),
+	}
+	for _, p := range b.Hazards {
+		if g.onBoard(p) {
+			g.hazardCost[g.cell(p)] += options.HazardCost
+		}
+	}
+	for _, snake := range b.Snakes {
+		if snake.EliminatedCause != rules.NotEliminated {
+			continue
+		}
+		for i, p := range snake.Body {
+			if !g.onBoard(p) {
+				continue
+			}
+			cell := g.cell(p)
+			if options.StaticBodies {
+				g.freeAfter[cell] = -1
+			} else if free := len(snake.Body) - i; g.freeAfter[cell] >= 0 && free > g.freeAfter[cell] {
+				g.freeAfter[cell] = free
+			}
+		}
+	}
+	for _, p := range b.Walls {
+		if g.onBoard(p) {
+			g.freeAfter[g.cell(p)] = -1
+		}
+	}
+	return g
+}
+
+func (g *graph) size() int {
+	return g.width * g.height
+}
+
+func (g *graph) onBoard(p rules.Point) bool {
+	return p.X >= 0 && p.X < g.width && p.Y >= 0 && p.Y < g.height
+}
+
+func (g *graph) cell(p rules.Point) int {
+	return p.Y*g.width + p.X
+}
+
+func (g *graph) point(cell int) rules.Point {
+	return rules.Point{X: cell % g.width, Y: cell / g.width}
+}
+
+// canEnter returns true if a cell can be entered after the given number of moves.
+func (g *graph) canEnter(cell, moves int) bool {
+	free := g.freeAfter[cell]
+	return free >= 0 && moves >= free
+}
+
+// neighbours appends the cells next to a cell to buf, wrapping around the edges if the board
+// wraps. A cell is never its own neighbour, and no neighbour is listed twice.
+func (g *graph) neighbours(cell int, buf []int) []int {
+	buf = buf[:0]
+	x, y := cell%g.width, cell/g.width
+	for _, d := range [4][2]int{{0, 1}, {0, -1}, {-1, 0}, {1, 0}} {
+		nx, ny := x+d[0], y+d[1]
+		if g.options.Wrapped {
+			nx = (nx + g.width) % g.width
+			ny = (ny + g.height) % g.height
+		} else if nx < 0 || nx >= g.width || ny < 0 || ny >= g.height {
+			continue
+		}
+		n := ny*g.width + nx
+		if n == cell {
+			continue
+		}
+		seen := false
+		for _, other := range buf {
+			if other == n {
+				seen = true
+				break
+			}
+		}
+		if !seen {
+			buf = append(buf, n)
+		}
+	}
+	return buf
+}
+
+// search is a step in a search through the board.
+type search struct {
+	cell  int
+	cost  int
+	moves int
+	from  int
+	owner int
+}
+
+// bucketQueue returns searches in order of cost. Costs are small integers, so searches are
+// kept in a bucket for each cost instead of a heap.
+type bucketQueue struct {
+	buckets [][]search
+	current int
+}
+
+func (q *bucketQueue) push(s search) {
+	for len(q.buckets) <= s.cost {
+		q.buckets = append(q.buckets, nil)
+	}
+	q.buckets[s.cost] = append(q.buckets[s.cost], s)
+}
+
+// popCost removes and returns all of the searches with the lowest cost, or nil when it's empty.
+func (q *bucketQueue) popCost() []search {
+	for ; q.current < len(q.buckets); q.current++ {
+		if len(q.buckets[q.current]) > 0 {
+			searches := q.buckets[q.current]
+			q.buckets[q.current] = nil
+			return searches
+		}
+	}
+	return nil
+}
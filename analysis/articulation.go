@@ -0,0 +1,60 @@
+package analysis
+
+import (
+	"github.com/BattlesnakeOfficial/rules"
+)
+
+// ArticulationPoints returns the open cells that split the open space on the board in two, so
+// that a snake moving onto one cuts off one side from the other. Open cells are cells without a
+// wall or a snake body in them right now; Options.StaticBodies and Options.HazardCost don't
+// change the result. Points are returned in order of Y, then X.
+func ArticulationPoints(b *rules.BoardState, options Options) []rules.Point {
+	options.StaticBodies = true
+	g := newGraph(b, options)
+
+	discovered := make([]int, g.size())
+	low := make([]int, g.size())
+	cut := make([]bool, g.size())
+	time := 0
+
+	var visit func(cell, parent int)
+	visit = func(cell, parent int) {
+		time++
+		discovered[cell], low[cell] = time, time
+		children := 0
+		for _, n := range g.neighbours(cell, nil) {
+			if !g.canEnter(n, 0) {
+				continue
+			}
+			if discovered[n] == 0 {
+				children++
+				visit(n, cell)
+				if low[n] < low[cell] {
+					low[cell] = low[n]
+				}
+				if parent != Unreachable && low[n] >= discovered[cell] {
+					cut[cell] = true
+				}
+			} else if n != parent && discovered[n] < low[cell] {
+				low[cell] = discovered[n]
+			}
+		}
+		if parent == Unreachable && children > 1 {
+			cut[cell] = true
+		}
+	}
+
+	for cell := 0; cell < g.size(); cell++ {
+		if discovered[cell] == 0 && g.canEnter(cell, 0) {
+			visit(cell, Unreachable)
+		}
+	}
+
+	var points []rules.Point
+	for cell, isCut := range cut {
+		if isCut {
+			points = append(points, g.point(cell))
+		}
+	}
+	return points
+}
@@ -0,0 +1,27 @@
+package analysis
+
+import (
+	"testing"
+
+	"github.com/BattlesnakeOfficial/rules"
+	"github.com/stretchr/testify/require"
+)
+
+func TestArticulationPoints(t *testing.T) {
+	require.Empty(t, ArticulationPoints(rules.NewBoardState(5, 5), Options{}))
+	require.Equal(t, []rules.Point{{X: 1, Y: 0}}, ArticulationPoints(rules.NewBoardState(3, 1), Options{}))
+
+	// Two rooms joined by a door
+	b := rules.NewBoardState(5, 5).WithWalls([]rules.Point{{X: 2, Y: 0}, {X: 2, Y: 1}, {X: 2, Y: 3}, {X: 2, Y: 4}})
+	require.Equal(t, []rules.Point{{X: 1, Y: 2}, {X: 2, Y: 2}, {X: 3, Y: 2}}, ArticulationPoints(b, Options{}))
+
+	// The rooms are also joined around the edges of a wrapped board
+	require.Empty(t, ArticulationPoints(b, Options{Wrapped: true}))
+
+	// Snake bodies close off space the same way
+	b = rules.NewBoardState(5, 5).WithSnakes([]rules.Snake{
+		{ID: "one", Body: []rules.Point{{X: 2, Y: 0}, {X: 2, Y: 1}, {X: 2, Y: 2}, {X: 2, Y: 3}}},
+		{ID: "gone", Body: []rules.Point{{X: 0, Y: 0}, {X: 1, Y: 0}}, EliminatedCause: rules.EliminatedByCollision},
+	})
+	require.Equal(t, []rules.Point{{X: 1, Y: 4}, {X: 2, Y: 4}, {X: 3, Y: 4}}, ArticulationPoints(b, Options{}))
+}
@@ -0,0 +1,139 @@
+package analysis
+
+import (
+	"github.com/BattlesnakeOfficial/rules"
+)
+
+// DistanceMap holds the cheapest way to reach every cell on the board from a starting point.
+type DistanceMap struct {
+	Width  int
+	Height int
+	// The cost of reaching each cell, or Unreachable.
+	Cost []int
+	// The number of moves along the cheapest way to each cell, or Unreachable.
+	Moves []int
+
+	start    int
+	previous []int
+}
+
+// Distances finds the cheapest way to reach every cell on the board from a point, which is
+// normally the head of a snake. The starting point can always be left, even if it's in a body.
+//
+// Cells that are only free of snake bodies after more moves than it takes to reach them are
+// treated as blocked, even if a longer way around would reach them late enough.
+func Distances(b *rules.BoardState, from rules.Point, options Options) *DistanceMap {
+	g := newGraph(b, options)
+	d := &DistanceMap{
+		Width:    b.Width,
+		Height:   b.Height,
+		Cost:     make([]int, g.size()),
+		Moves:    make([]int, g.size()),
+		start:    Unreachable,
+		previous: make([]int, g.size()),
+	}
+	for i := range d.Cost {
+		d.Cost[i] = Unreachable
+		d.Moves[i] = Unreachable
+		d.previous[i] = Unreachable
+	}
+	if !g.onBoard(from) {
+		return d
+	}
+
+	d.start = g.cell(from)
+	var queue bucketQueue
+	queue.push(search{cell: d.start, from: Unreachable})
+	var buf []int
+	for searches := queue.popCost(); searches != nil; searches = queue.popCost() {
+		for _, s := range searches {
+			if d.Cost[s.cell] != Unreachable {
+				continue
+			}
+			d.Cost[s.cell] = s.cost
+			d.Moves[s.cell] = s.moves
+			d.previous[s.cell] = s.from
+
+			buf = g.neighbours(s.cell, buf)
+			for _, n := range buf {
+				if d.Cost[n] != Unreachable || !g.canEnter(n, s.moves+1) {
+					continue
+				}
+				queue.push(search{cell: n, cost: s.cost + 1 + g.hazardCost[n], moves: s.moves + 1, from: s.cell})
+			}
+		}
+	}
+	return d
+}
+
+func (d *DistanceMap) onBoard(p rules.Point) bool {
+	return p.X >= 0 && p.X < d.Width && p.Y >= 0 && p.Y < d.Height
+}
+
+// CostTo returns the cost of reaching a point, or Unreachable.
+func (d *DistanceMap) CostTo(p rules.Point) int {
+	if !d.onBoard(p) {
+		return Unreachable
+	}
+	return d.Cost[p.Y*d.Width+p.X]
+}
+
+// MovesTo returns the number of moves along the cheapest way to a point, or Unreachable.
+func (d *DistanceMap) MovesTo(p rules.Point) int {
+	if !d.onBoard(p) {
+		return Unreachable
+	}
+	return d.Moves[p.Y*d.Width+p.X]
+}
+
+// PathTo returns the cheapest way to a point, as the points moved onto in order, ending with the
+// point itself. It returns nil if the point can't be reached, and an empty path for the start.
+func (d *DistanceMap) PathTo(p rules.Point) []rules.Point {
+	if d.CostTo(p) == Unreachable {
+		return nil
+	}
+	path := []rules.Point{}
+	for cell := p.Y*d.Width + p.X; cell != d.start; cell = d.previous[cell] {
+		path = append(path, rules.Point{X: cell % d.Width, Y: cell / d.Width})
+	}
+	for i, j := 0, len(path)-1; i < j; i, j = i+1, j-1 {
+		path[i], path[j] = path[j], path[i]
+	}
+	return path
+}
+
+// Nearest returns the cheapest of the points to reach, preferring the first listed when several
+// cost the same. It returns false if none of them can be reached.
+func (d *DistanceMap) Nearest(points []rules.Point) (rules.Point, bool) {
+	var nearest rules.Point
+	found := false
+	for _, p := range points {
+		cost := d.CostTo(p)
+		if cost != Unreachable && (!found || cost < d.CostTo(nearest)) {
+			nearest, found = p, true
+		}
+	}
+	return nearest, found
+}
+
+// PathToFood returns the cheapest way from a point to the nearest food, as the points moved onto
+// in order, ending with the food. It returns nil if no food can be reached.
+func PathToFood(b *rules.BoardState, from rules.Point, options Options) []rules.Point {
+	d := Distances(b, from, options)
+	food, ok := d.Nearest(b.Food)
+	if !ok {
+		return nil
+	}
+	return d.PathTo(rules.Point{X: food.X, Y: food.Y})
+}
+
+// ReachableArea counts the cells that can be reached from a point, not counting the point itself.
+func ReachableArea(b *rules.BoardState, from rules.Point, options Options) int {
+	area := 0
+	for _, cost := range Distances(b, from, options).Cost {
+		if cost > 0 {
+			area++
+		}
+	}
+	return area
+}
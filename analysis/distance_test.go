@@ -0,0 +1,121 @@
+package analysis
+
+import (
+	"testing"
+
+	"github.com/BattlesnakeOfficial/rules"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDistances(t *testing.T) {
+	b := rules.NewBoardState(3, 3)
+	d := Distances(b, rules.Point{X: 0, Y: 0}, Options{})
+	require.Equal(t, []int{
+		0, 1, 2,
+		1, 2, 3,
+		2, 3, 4,
+	}, d.Cost)
+	require.Equal(t, d.Cost, d.Moves)
+	require.Equal(t, 4, d.CostTo(rules.Point{X: 2, Y: 2}))
+	require.Equal(t, Unreachable, d.CostTo(rules.Point{X: 3, Y: 0}))
+	require.Equal(t, Unreachable, d.MovesTo(rules.Point{X: -1, Y: 0}))
+	require.Equal(t, []rules.Point{}, d.PathTo(rules.Point{X: 0, Y: 0}))
+
+	path := d.PathTo(rules.Point{X: 2, Y: 2})
+	require.Len(t, path, 4)
+	require.Equal(t, rules.Point{X: 2, Y: 2}, path[3])
+	previous := rules.Point{X: 0, Y: 0}
+	for _, p := range path {
+		require.Equal(t, 1, absInt(p.X-previous.X)+absInt(p.Y-previous.Y), "path %v", path)
+		previous = p
+	}
+
+	d = Distances(b, rules.Point{X: 5, Y: 5}, Options{})
+	require.Equal(t, Unreachable, d.CostTo(rules.Point{X: 0, Y: 0}))
+	require.Nil(t, d.PathTo(rules.Point{X: 0, Y: 0}))
+}
+
+func TestDistancesWrapped(t *testing.T) {
+	b := rules.NewBoardState(3, 3)
+	d := Distances(b, rules.Point{X: 0, Y: 0}, Options{Wrapped: true})
+	require.Equal(t, []int{
+		0, 1, 1,
+		1, 2, 2,
+		1, 2, 2,
+	}, d.Cost)
+	require.Equal(t, []rules.Point{{X: 2, Y: 0}}, d.PathTo(rules.Point{X: 2, Y: 0}))
+
+	require.Equal(t, Options{Wrapped: true}, OptionsForGame(rules.GameTypeWrappedConstrictor))
+	require.Equal(t, Options{}, OptionsForGame(rules.GameTypeStandard))
+}
+
+func TestDistancesHazardCost(t *testing.T) {
+	b := rules.NewBoardState(3, 2).WithHazards([]rules.Point{{X: 1, Y: 0}})
+
+	d := Distances(b, rules.Point{X: 0, Y: 0}, Options{HazardCost: 5})
+	require.Equal(t, 4, d.CostTo(rules.Point{X: 2, Y: 0}))
+	require.Equal(t, []rules.Point{{X: 0, Y: 1}, {X: 1, Y: 1}, {X: 2, Y: 1}, {X: 2, Y: 0}}, d.PathTo(rules.Point{X: 2, Y: 0}))
+	require.Equal(t, 6, d.CostTo(rules.Point{X: 1, Y: 0}))
+	require.Equal(t, 1, d.MovesTo(rules.Point{X: 1, Y: 0}))
+
+	// Stacked hazards cost more
+	b.Hazards = append(b.Hazards, rules.Point{X: 1, Y: 0})
+	d = Distances(b, rules.Point{X: 0, Y: 0}, Options{HazardCost: 5})
+	require.Equal(t, 11, d.CostTo(rules.Point{X: 1, Y: 0}))
+
+	d = Distances(b, rules.Point{X: 0, Y: 0}, Options{})
+	require.Equal(t, []rules.Point{{X: 1, Y: 0}, {X: 2, Y: 0}}, d.PathTo(rules.Point{X: 2, Y: 0}))
+}
+
+func TestDistancesBodies(t *testing.T) {
+	b := rules.NewBoardState(3, 3).WithSnakes([]rules.Snake{
+		{ID: "you", Body: []rules.Point{{X: 0, Y: 0}}},
+		{ID: "other", Body: []rules.Point{{X: 1, Y: 0}, {X: 1, Y: 1}, {X: 1, Y: 2}}},
+	})
+
+	// The other snake's head only moves out of the way after three moves
+	d := Distances(b, rules.Point{X: 0, Y: 0}, Options{})
+	require.Equal(t, []int{
+		0, 3, 4,
+		1, 2, 3,
+		2, 3, 4,
+	}, d.Cost)
+	require.Equal(t, 8, ReachableArea(b, rules.Point{X: 0, Y: 0}, Options{}))
+
+	d = Distances(b, rules.Point{X: 0, Y: 0}, Options{StaticBodies: true})
+	require.Equal(t, Unreachable, d.CostTo(rules.Point{X: 1, Y: 1}))
+	require.Equal(t, 2, ReachableArea(b, rules.Point{X: 0, Y: 0}, Options{StaticBodies: true}))
+
+	// Eliminated snakes are ignored, and walls are never free
+	b.Snakes[1].EliminatedCause = rules.EliminatedByCollision
+	b.Walls = []rules.Point{{X: 1, Y: 1}}
+	require.Equal(t, 7, ReachableArea(b, rules.Point{X: 0, Y: 0}, Options{}))
+}
+
+func TestPathToFood(t *testing.T) {
+	b := rules.NewBoardState(5, 5).WithFood([]rules.Point{{X: 4, Y: 4}, {X: 0, Y: 3, TTL: 2}})
+
+	path := PathToFood(b, rules.Point{X: 0, Y: 0}, Options{})
+	require.Equal(t, []rules.Point{{X: 0, Y: 1}, {X: 0, Y: 2}, {X: 0, Y: 3}}, path)
+
+	// The wall makes the nearer food further away
+	b.Walls = []rules.Point{{X: 0, Y: 2}, {X: 1, Y: 2}, {X: 2, Y: 2}, {X: 3, Y: 2}}
+	path = PathToFood(b, rules.Point{X: 0, Y: 0}, Options{})
+	require.Len(t, path, 8)
+	require.Equal(t, rules.Point{X: 4, Y: 4}, path[7])
+
+	b.Walls = append(b.Walls, rules.Point{X: 4, Y: 2})
+	require.Nil(t, PathToFood(b, rules.Point{X: 0, Y: 0}, Options{}))
+
+	d := Distances(b, rules.Point{X: 0, Y: 4}, Options{})
+	nearest, ok := d.Nearest(b.Food)
+	require.True(t, ok)
+	require.Equal(t, rules.Point{X: 0, Y: 3, TTL: 2}, nearest)
+}
+
+func absInt(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
@@ -0,0 +1,105 @@
+package analysis
+
+import (
+	"github.com/BattlesnakeOfficial/rules"
+)
+
+// NoOwner is the owner of cells that no snake reaches first.
+const NoOwner = -1
+
+// TerritoryMap splits the board between the snakes, giving each cell to the snake that can reach
+// it most cheaply. This is often called a Voronoi partition of the board.
+type TerritoryMap struct {
+	Width  int
+	Height int
+	// The snakes that territory is split between, in the order they're on the board.
+	SnakeIDs []string
+	// The index in SnakeIDs of the snake that owns each cell, or NoOwner for cells that no snake
+	// can reach, or that more than one snake can reach for the same cost.
+	Owner []int
+	// The cost of reaching each cell for the snakes that reach it first, or Unreachable.
+	Cost []int
+}
+
+// Territory finds the snakes that can reach each cell most cheaply, searching from all of their
+// heads at once. Cells that two snakes can reach for the same cost are contested, and belong to
+// neither of them. Contested cells, and cells owned by one snake, can't be moved through by the
+// other snakes, so each snake's territory is the area it can claim before the others get there.
+func Territory(b *rules.BoardState, options Options) *TerritoryMap {
+	g := newGraph(b, options)
+	t := &TerritoryMap{
+		Width:  b.Width,
+		Height: b.Height,
+		Owner:  make([]int, g.size()),
+		Cost:   make([]int, g.size()),
+	}
+	for i := range t.Owner {
+		t.Owner[i] = NoOwner
+		t.Cost[i] = Unreachable
+	}
+
+	var queue bucketQueue
+	for _, snake := range b.Snakes {
+		if snake.EliminatedCause != rules.NotEliminated || len(snake.Body) == 0 {
+			continue
+		}
+		if head := snake.Body[0]; g.onBoard(head) {
+			queue.push(search{cell: g.cell(head), from: Unreachable, owner: len(t.SnakeIDs)})
+		}
+		t.SnakeIDs = append(t.SnakeIDs, snake.ID)
+	}
+
+	var buf []int
+	for searches := queue.popCost(); searches != nil; searches = queue.popCost() {
+		// Settle every cell reached for this cost before moving on, so ties can be found
+		var settled []search
+		for _, s := range searches {
+			if t.Cost[s.cell] == Unreachable {
+				t.Cost[s.cell] = s.cost
+				t.Owner[s.cell] = s.owner
+				settled = append(settled, s)
+			} else if t.Cost[s.cell] == s.cost && t.Owner[s.cell] != s.owner {
+				t.Owner[s.cell] = NoOwner
+			}
+		}
+
+		for _, s := range settled {
+			if t.Owner[s.cell] == NoOwner {
+				continue
+			}
+			buf = g.neighbours(s.cell, buf)
+			for _, n := range buf {
+				if t.Cost[n] != Unreachable || !g.canEnter(n, s.moves+1) {
+					continue
+				}
+				queue.push(search{cell: n, cost: s.cost + 1 + g.hazardCost[n], moves: s.moves + 1, from: s.cell, owner: s.owner})
+			}
+		}
+	}
+	return t
+}
+
+// OwnerAt returns the ID of the snake that owns a point, or an empty string if there isn't one.
+func (t *TerritoryMap) OwnerAt(p rules.Point) string {
+	if p.X < 0 || p.X >= t.Width || p.Y < 0 || p.Y >= t.Height {
+		return ""
+	}
+	if owner := t.Owner[p.Y*t.Width+p.X]; owner != NoOwner {
+		return t.SnakeIDs[owner]
+	}
+	return ""
+}
+
+// Areas returns the number of cells each snake owns, including the cell its head is on.
+func (t *TerritoryMap) Areas() map[string]int {
+	areas := make(map[string]int, len(t.SnakeIDs))
+	for _, id := range t.SnakeIDs {
+		areas[id] = 0
+	}
+	for _, owner := range t.Owner {
+		if owner != NoOwner {
+			areas[t.SnakeIDs[owner]]++
+		}
+	}
+	return areas
+}
@@ -0,0 +1,70 @@
+package analysis
+
+import (
+	"testing"
+
+	"github.com/BattlesnakeOfficial/rules"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTerritory(t *testing.T) {
+	b := rules.NewBoardState(3, 3).WithSnakes([]rules.Snake{
+		{ID: "one", Body: []rules.Point{{X: 0, Y: 0}}},
+		{ID: "two", Body: []rules.Point{{X: 2, Y: 2}}},
+		{ID: "gone", Body: []rules.Point{{X: 1, Y: 1}}, EliminatedCause: rules.EliminatedByCollision},
+	})
+
+	territory := Territory(b, Options{})
+	require.Equal(t, []string{"one", "two"}, territory.SnakeIDs)
+	require.Equal(t, []int{
+		0, 0, NoOwner,
+		0, NoOwner, 1,
+		NoOwner, 1, 1,
+	}, territory.Owner)
+	require.Equal(t, []int{
+		0, 1, 2,
+		1, 2, 1,
+		2, 1, 0,
+	}, territory.Cost)
+	require.Equal(t, map[string]int{"one": 3, "two": 3}, territory.Areas())
+	require.Equal(t, "one", territory.OwnerAt(rules.Point{X: 1, Y: 0}))
+	require.Equal(t, "", territory.OwnerAt(rules.Point{X: 1, Y: 1}))
+	require.Equal(t, "", territory.OwnerAt(rules.Point{X: 3, Y: 3}))
+}
+
+func TestTerritoryContestedCellsBlock(t *testing.T) {
+	// The middle cell is contested, so neither snake can get past it
+	b := rules.NewBoardState(5, 1).WithSnakes([]rules.Snake{
+		{ID: "one", Body: []rules.Point{{X: 0, Y: 0}}},
+		{ID: "two", Body: []rules.Point{{X: 4, Y: 0}}},
+	})
+	territory := Territory(b, Options{})
+	require.Equal(t, []int{0, 0, NoOwner, 1, 1}, territory.Owner)
+
+	// Wrapping around the edges gives each snake the cell behind it
+	b.Width = 6
+	b.Snakes[1].Body = []rules.Point{{X: 3, Y: 0}}
+	territory = Territory(b, Options{Wrapped: true})
+	require.Equal(t, []int{0, 0, 1, 1, 1, 0}, territory.Owner)
+}
+
+func TestTerritoryHazardsAndBodies(t *testing.T) {
+	b := rules.NewBoardState(5, 1).
+		WithSnakes([]rules.Snake{
+			{ID: "one", Body: []rules.Point{{X: 0, Y: 0}}},
+			{ID: "two", Body: []rules.Point{{X: 4, Y: 0}}},
+		}).
+		WithHazards([]rules.Point{{X: 1, Y: 0}})
+
+	territory := Territory(b, Options{HazardCost: 2})
+	require.Equal(t, map[string]int{"one": 2, "two": 3}, territory.Areas())
+
+	// Snake one is trapped by its own body, which snake two can follow as it moves away
+	b.Snakes[0].Body = []rules.Point{{X: 0, Y: 0}, {X: 1, Y: 0}, {X: 2, Y: 0}}
+	b.Hazards = nil
+	territory = Territory(b, Options{})
+	require.Equal(t, []int{0, 1, 1, 1, 1}, territory.Owner)
+
+	territory = Territory(b, Options{StaticBodies: true})
+	require.Equal(t, []int{0, NoOwner, NoOwner, 1, 1}, territory.Owner)
+}
@@ -13,6 +13,9 @@ type BoardState struct {
 	Snakes  []Snake
 	Hazards []Point
 
+	// Impassable cells. Unlike hazards, a snake that moves its head into a wall is eliminated.
+	Walls []Point
+
 	// Generic game-level state for maps and rules stages to persist data between turns.
 	GameState map[string]string
 
@@ -27,6 +30,16 @@ type Point struct {
 	Value int `json:"Value,omitempty"`
 }
 
+// HazardDamage returns the health a snake loses each turn it ends with its head on this hazard.
+// A hazard with a Value deals that much damage, and heals when it's negative. Other hazards deal
+// defaultDamage, which is normally the ruleset's ParamHazardDamagePerTurn.
+func (p Point) HazardDamage(defaultDamage int) int {
+	if p.Value != 0 {
+		return p.Value
+	}
+	return defaultDamage
+}
+
 // Makes it easier to copy sample points out of Go logs and test failures.
 func (p Point) GoString() string {
 	if p.TTL != 0 || p.Value != 0 {
@@ -53,6 +66,7 @@ func NewBoardState(width, height int) *BoardState {
 		Food:       []Point{},
 		Snakes:     []Snake{},
 		Hazards:    []Point{},
+		Walls:      []Point{},
 		GameState:  map[string]string{},
 		PointState: map[Point]int{},
 	}
@@ -67,6 +81,7 @@ func (prevState *BoardState) Clone() *BoardState {
 		Food:       append([]Point{}, prevState.Food...),
 		Snakes:     make([]Snake, len(prevState.Snakes)),
 		Hazards:    append([]Point{}, prevState.Hazards...),
+		Walls:      append([]Point{}, prevState.Walls...),
 		GameState:  make(map[string]string, len(prevState.GameState)),
 		PointState: make(map[Point]int, len(prevState.PointState)),
 	}
@@ -105,6 +120,12 @@ func (state *BoardState) WithHazards(hazards []Point) *BoardState {
 	return state
 }
 
+// Builder method to set Walls and return the modified BoardState.
+func (state *BoardState) WithWalls(walls []Point) *BoardState {
+	state.Walls = walls
+	return state
+}
+
 // Builder method to set Snakes and return the modified BoardState.
 func (state *BoardState) WithSnakes(snakes []Snake) *BoardState {
 	state.Snakes = snakes
@@ -163,8 +184,8 @@ func PlaceSnakesAutomatically(rand Rand, b *BoardState, snakeIDs []string) error
 		}
 	}
 
-	// last resort for unexpected board sizes we'll just randomly place snakes
-	return PlaceSnakesRandomly(rand, b, snakeIDs)
+	// for other board sizes, search for a fair placement
+	return PlaceSnakesFairly(rand, b, snakeIDs)
 }
 
 func PlaceSnakesFixed(rand Rand, b *BoardState, snakeIDs []string) error {
@@ -380,7 +401,8 @@ func PlaceFoodAutomatically(rand Rand, b *BoardState) error {
 		return PlaceFoodFixed(rand, b)
 	}
 
-	return PlaceFoodRandomly(rand, b, len(b.Snakes))
+	// Snakes on other board sizes are placed by PlaceSnakesFairly, so food is placed fairly too
+	return PlaceFoodFairly(rand, b, len(b.Snakes))
 }
 
 // Deprecated: will be replaced by maps.PlaceFoodFixed
@@ -555,6 +577,14 @@ func GetUnoccupiedPoints(b *BoardState, includePossibleMoves bool, includeHazard
 		}
 	}
 
+	// Walls are never free, whatever else is included
+	for _, p := range b.Walls {
+		if _, xExists := pointIsOccupied[p.X]; !xExists {
+			pointIsOccupied[p.X] = map[int]bool{}
+		}
+		pointIsOccupied[p.X][p.Y] = true
+	}
+
 	if includeHazards {
 		for _, p := range b.Hazards {
 			if _, xExists := pointIsOccupied[p.X]; !xExists {
@@ -40,6 +40,7 @@ type GameFrame struct {
 	Snakes  []Snake       `json:"Snakes"`
 	Food    []rules.Point `json:"Food"`
 	Hazards []rules.Point `json:"Hazards"`
+	Walls   []rules.Point `json:"Walls,omitempty"`
 }
 
 type GameEnd struct {
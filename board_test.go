@@ -16,6 +16,7 @@ func TestBoardStateClone(t *testing.T) {
 		WithTurn(99).
 		WithFood([]Point{{X: 1, Y: 2, TTL: 10, Value: 100}}).
 		WithHazards([]Point{{X: 3, Y: 4, TTL: 5, Value: 50}}).
+		WithWalls([]Point{{X: 5, Y: 6}}).
 		WithSnakes([]Snake{
 			{
 				ID:               "1",
@@ -822,6 +823,14 @@ func TestGetUnoccupiedPoints(t *testing.T) {
 			},
 			[]Point{{X: 2, Y: 1}},
 		},
+		{
+			&BoardState{
+				Height: 2,
+				Width:  2,
+				Walls:  []Point{{X: 0, Y: 1}, {X: 1, Y: 1}},
+			},
+			[]Point{{X: 0, Y: 0}, {X: 1, Y: 0}},
+		},
 	}
 
 	for _, test := range tests {
@@ -0,0 +1,499 @@
+package commands
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+	log "github.com/spf13/jwalterweatherman"
+
+	"github.com/BattlesnakeOfficial/rules"
+	"github.com/BattlesnakeOfficial/rules/analysis"
+	"github.com/BattlesnakeOfficial/rules/maps"
+	"github.com/BattlesnakeOfficial/rules/replay"
+)
+
+type gameAnalyzer struct {
+	Seed  int64
+	Depth int
+}
+
+func NewAnalyzeCommand() *cobra.Command {
+	analyzer := gameAnalyzer{}
+	var analyzeCmd = &cobra.Command{
+		Use:   "analyze [flags] game.jsonl",
+		Short: "Find blunders and missed kills in an exported game",
+		Long: `Re-simulate a game exported with "play --output" and search every turn for mistakes:
+
+  blunder      a move that let the other snakes force an elimination within --depth turns,
+               when another move would have survived whatever they did
+  missed kill  a move that would have eliminated another snake whatever it did, when the
+               move that was made didn't eliminate anyone
+
+The search runs the map along with the ruleset, so hazards and walls the map adds during the search
+are taken into account, and it assumes the other snakes near each snake work together against it.
+
+Game exports don't include the random seed, so the seed printed by "play" must be passed with --seed.
+The turn limit, tie-breakers and map parameters are recorded in the export, so they don't need to
+be given again.`,
+		Args: cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			record, err := loadGameRecord(args[0])
+			if err != nil {
+				log.ERROR.Fatalf("Error reading game: %v", err)
+			}
+			analysis, err := analyzer.Analyze(record)
+			if err != nil {
+				log.ERROR.Fatalf("Error analyzing game: %v", err)
+			}
+			analysis.Write(os.Stdout)
+		},
+	}
+
+	analyzeCmd.Flags().Int64VarP(&analyzer.Seed, "seed", "r", 0, "Random seed the game was played with")
+	analyzeCmd.Flags().IntVar(&analyzer.Depth, "depth", 2, "Number of turns to search ahead for forced eliminations")
+	_ = analyzeCmd.MarkFlagRequired("seed")
+
+	return analyzeCmd
+}
+
+// Kinds of mistake found by gameAnalyzer.
+const (
+	mistakeBlunder    = "blunder"
+	mistakeMissedKill = "missed kill"
+)
+
+// mistake is a move that a gameAnalyzer found a better alternative to.
+type mistake struct {
+	Turn int
+	Kind string
+	Move string
+	// The moves that would have been better
+	Alternatives []string
+	// For missed kills, the names of the snakes that would have been eliminated
+	Victims []string
+}
+
+func (m mistake) String() string {
+	switch m.Kind {
+	case mistakeBlunder:
+		return fmt.Sprintf("Turn %d: blunder, moved %s into a forced elimination; %s would have survived",
+			m.Turn, m.Move, strings.Join(m.Alternatives, " or "))
+	case mistakeMissedKill:
+		return fmt.Sprintf("Turn %d: missed kill, moved %s; %s would have eliminated %s",
+			m.Turn, m.Move, strings.Join(m.Alternatives, " or "), strings.Join(m.Victims, " and "))
+	}
+	return fmt.Sprintf("Turn %d: %s", m.Turn, m.Kind)
+}
+
+// snakeAnalysis is the outcome of a game for a single snake, and the mistakes it made.
+type snakeAnalysis struct {
+	ID       string
+	Name     string
+	Outcome  string
+	Mistakes []mistake
+}
+
+// gameAnalysis holds the mistakes found in a game for every snake, in the order they were placed.
+type gameAnalysis struct {
+	GameID string
+	Turns  int
+	Snakes []*snakeAnalysis
+}
+
+// Write prints a summary of the mistakes each snake made.
+func (analysis *gameAnalysis) Write(w io.Writer) {
+	fmt.Fprintf(w, "Game %s, %d turns\n", analysis.GameID, analysis.Turns)
+	for _, snake := range analysis.Snakes {
+		fmt.Fprintf(w, "\n%s (%s): %s\n", snake.Name, snake.ID, snake.Outcome)
+		if len(snake.Mistakes) == 0 {
+			fmt.Fprintf(w, "  No blunders or missed kills found\n")
+		}
+		for _, m := range snake.Mistakes {
+			fmt.Fprintf(w, "  %v\n", m)
+		}
+	}
+}
+
+// Analyze re-simulates the recorded game and searches every turn for blunders and missed kills.
+func (analyzer *gameAnalyzer) Analyze(record *gameRecord) (*gameAnalysis, error) {
+	ruleset, gameMap, err := record.rulesetAndMap(analyzer.Seed)
+	if err != nil {
+		return nil, err
+	}
+	recorded, recordedMoves := record.boardStatesAndMoves()
+	allMoves, err := replay.Reconstruct(ruleset, gameMap, recorded, recordedMoves)
+	if err != nil {
+		return nil, err
+	}
+
+	initial := recorded[0]
+	snakeIDs := make([]string, 0, len(initial.Snakes))
+	for _, snake := range initial.Snakes {
+		snakeIDs = append(snakeIDs, snake.ID)
+	}
+	_, boardState, err := replay.Setup(ruleset, gameMap, initial.Width, initial.Height, snakeIDs)
+	if err != nil {
+		return nil, err
+	}
+	states := []*rules.BoardState{boardState}
+	for _, moves := range allMoves {
+		_, boardState, err = replay.Step(ruleset, gameMap, boardState, moves)
+		if err != nil {
+			return nil, err
+		}
+		states = append(states, boardState)
+	}
+	final := states[len(states)-1]
+
+	names := map[string]string{}
+	for _, snake := range record.Turns[0].Board.Snakes {
+		names[snake.ID] = snake.Name
+	}
+	summary := &gameAnalysis{GameID: record.Game.ID, Turns: final.Turn}
+	bySnake := map[string]*snakeAnalysis{}
+	for _, snake := range final.Snakes {
+		snakeAnalysis := &snakeAnalysis{ID: snake.ID, Name: names[snake.ID], Outcome: snakeOutcome(snake, names, record.Result)}
+		summary.Snakes = append(summary.Snakes, snakeAnalysis)
+		bySnake[snake.ID] = snakeAnalysis
+	}
+
+	searcher := &mistakeSearch{ruleset: ruleset, gameMap: gameMap, wrapped: analysis.OptionsForGame(ruleset.Name()).Wrapped}
+	depth := analyzer.Depth
+	if depth < 1 {
+		depth = 1
+	}
+	for i, moves := range allMoves {
+		before, after := states[i], states[i+1]
+		for _, move := range moves {
+			snake := findSnake(final, move.ID)
+			// Only look for blunders close enough to the snake's elimination for the search to see it
+			if snake.EliminatedCause != rules.NotEliminated && snake.EliminatedOnTurn-before.Turn <= depth {
+				m, err := searcher.findBlunder(before, move, depth)
+				if err != nil {
+					return nil, err
+				}
+				if m != nil {
+					bySnake[move.ID].Mistakes = append(bySnake[move.ID].Mistakes, *m)
+				}
+			}
+
+			m, err := searcher.findMissedKill(before, after, move)
+			if err != nil {
+				return nil, err
+			}
+			if m != nil {
+				for j, id := range m.Victims {
+					m.Victims[j] = names[id]
+				}
+				bySnake[move.ID].Mistakes = append(bySnake[move.ID].Mistakes, *m)
+			}
+		}
+	}
+
+	return summary, nil
+}
+
+// snakeOutcome describes how the game ended for a snake.
+func snakeOutcome(snake rules.Snake, names map[string]string, result result) string {
+	if snake.EliminatedCause == rules.NotEliminated {
+		if result.WinnerID == snake.ID {
+			return "won"
+		}
+		return "still in the game when it ended"
+	}
+	if snake.EliminatedBy != "" && snake.EliminatedBy != snake.ID {
+		return fmt.Sprintf("eliminated on turn %d (%s by %s)", snake.EliminatedOnTurn, snake.EliminatedCause, names[snake.EliminatedBy])
+	}
+	return fmt.Sprintf("eliminated on turn %d (%s)", snake.EliminatedOnTurn, snake.EliminatedCause)
+}
+
+func findSnake(boardState *rules.BoardState, id string) *rules.Snake {
+	for i := range boardState.Snakes {
+		if boardState.Snakes[i].ID == id {
+			return &boardState.Snakes[i]
+		}
+	}
+	return nil
+}
+
+func isEliminated(boardState *rules.BoardState, id string) bool {
+	snake := findSnake(boardState, id)
+	return snake == nil || snake.EliminatedCause != rules.NotEliminated
+}
+
+var searchMoves = []string{rules.MoveUp, rules.MoveDown, rules.MoveLeft, rules.MoveRight}
+
+// mistakeSearch searches a few turns ahead, stepping through the ruleset and map the same way the
+// game was played. Snakes that are close enough to a snake to reach it during the search are
+// assumed to work together against it, and every move they could make is tried. Snakes further
+// away make the first move that doesn't eliminate them straight away.
+type mistakeSearch struct {
+	ruleset rules.Ruleset
+	gameMap maps.GameMap
+	wrapped bool
+}
+
+// findBlunder returns a blunder if the move lets the other snakes force an elimination within
+// depth turns, but another move doesn't.
+func (s *mistakeSearch) findBlunder(b *rules.BoardState, move rules.SnakeMove, depth int) (*mistake, error) {
+	ok, err := s.survivesMove(b, move.ID, move.Move, depth)
+	if err != nil || ok {
+		return nil, err
+	}
+
+	var alternatives []string
+	for _, m := range s.candidateMoves(b, move.ID) {
+		if m == move.Move {
+			continue
+		}
+		ok, err := s.survivesMove(b, move.ID, m, depth)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			alternatives = append(alternatives, m)
+		}
+	}
+	if len(alternatives) == 0 {
+		return nil, nil
+	}
+	return &mistake{Turn: b.Turn, Kind: mistakeBlunder, Move: move.Move, Alternatives: alternatives}, nil
+}
+
+// findMissedKill returns a missed kill if the move didn't eliminate any other snake, but another
+// move would have eliminated one whatever it did, without the snake being eliminated itself.
+func (s *mistakeSearch) findMissedKill(before, after *rules.BoardState, move rules.SnakeMove) (*mistake, error) {
+	if len(eliminatedBy(before, after, move.ID)) > 0 {
+		return nil, nil
+	}
+
+	var alternatives []string
+	var victims []string
+	for _, m := range s.candidateMoves(before, move.ID) {
+		if m == move.Move {
+			continue
+		}
+		var killed []string
+		first := true
+		err := s.forEachReply(before, move.ID, m, 1, func(next *rules.BoardState) bool {
+			if isEliminated(next, move.ID) {
+				killed = nil
+				return false
+			}
+			if first {
+				killed, first = eliminatedBy(before, next, move.ID), false
+			} else {
+				killed = intersect(killed, eliminatedBy(before, next, move.ID))
+			}
+			return len(killed) > 0
+		})
+		if err != nil {
+			return nil, err
+		}
+		if len(killed) > 0 {
+			alternatives = append(alternatives, m)
+			victims = union(victims, killed)
+		}
+	}
+	if len(alternatives) == 0 {
+		return nil, nil
+	}
+	return &mistake{Turn: before.Turn, Kind: mistakeMissedKill, Move: move.Move, Alternatives: alternatives, Victims: victims}, nil
+}
+
+// survivesMove returns true if the snake can make sure it's still in the game after depth turns,
+// starting with the given move, whatever the snakes near it do.
+func (s *mistakeSearch) survivesMove(b *rules.BoardState, id, move string, depth int) (bool, error) {
+	survives := true
+	var searchErr error
+	err := s.forEachReply(b, id, move, depth, func(next *rules.BoardState) bool {
+		survives, searchErr = s.survives(next, id, depth-1)
+		return survives && searchErr == nil
+	})
+	if err != nil {
+		return false, err
+	}
+	return survives, searchErr
+}
+
+// survives returns true if the snake is still in the game, and can make sure it's still in the
+// game after depth more turns whatever the snakes near it do.
+func (s *mistakeSearch) survives(b *rules.BoardState, id string, depth int) (bool, error) {
+	if isEliminated(b, id) {
+		return false, nil
+	}
+	if depth == 0 {
+		return true, nil
+	}
+	for _, move := range s.candidateMoves(b, id) {
+		ok, err := s.survivesMove(b, id, move, depth)
+		if err != nil || ok {
+			return ok, err
+		}
+	}
+	return false, nil
+}
+
+// forEachReply calls visit with the board after the snake makes the move, for every combination
+// of moves the snakes near it could make in reply. It stops early if visit returns false.
+func (s *mistakeSearch) forEachReply(b *rules.BoardState, id, move string, depth int, visit func(*rules.BoardState) bool) error {
+	self := findSnake(b, id)
+	var others []string
+	var choices [][]string
+	for _, snake := range b.Snakes {
+		if snake.ID == id || snake.EliminatedCause != rules.NotEliminated {
+			continue
+		}
+		moves := s.candidateMoves(b, snake.ID)
+		if len(moves) == 0 {
+			moves = []string{rules.MoveUp}
+		}
+		if s.distance(b, self.Body[0], snake.Body[0]) > 2*depth+1 {
+			moves = moves[:1]
+		}
+		others = append(others, snake.ID)
+		choices = append(choices, moves)
+	}
+
+	// Step through every combination of moves like an odometer
+	indexes := make([]int, len(others))
+	for {
+		moves := []rules.SnakeMove{{ID: id, Move: move}}
+		for i, other := range others {
+			moves = append(moves, rules.SnakeMove{ID: other, Move: choices[i][indexes[i]]})
+		}
+		_, next, err := replay.Step(s.ruleset, s.gameMap, b, moves)
+		if err != nil {
+			return err
+		}
+		if !visit(next) {
+			return nil
+		}
+
+		i := 0
+		for ; i < len(indexes); i++ {
+			indexes[i]++
+			if indexes[i] < len(choices[i]) {
+				break
+			}
+			indexes[i] = 0
+		}
+		if i == len(indexes) {
+			return nil
+		}
+	}
+}
+
+// candidateMoves returns the moves a snake can make without leaving the board or moving into a
+// wall or a body segment that won't have moved out of the way. Tails are assumed to move, unless
+// the snake has just eaten.
+func (s *mistakeSearch) candidateMoves(b *rules.BoardState, id string) []string {
+	blocked := map[rules.Point]bool{}
+	for _, p := range b.Walls {
+		blocked[rules.Point{X: p.X, Y: p.Y}] = true
+	}
+	for _, snake := range b.Snakes {
+		if snake.EliminatedCause != rules.NotEliminated {
+			continue
+		}
+		for i, p := range snake.Body {
+			if i == len(snake.Body)-1 && i > 0 && p != snake.Body[i-1] {
+				continue
+			}
+			blocked[rules.Point{X: p.X, Y: p.Y}] = true
+		}
+	}
+
+	snake := findSnake(b, id)
+	if snake == nil || len(snake.Body) == 0 {
+		return nil
+	}
+	head := snake.Body[0]
+	var moves []string
+	for _, move := range searchMoves {
+		p := head
+		switch move {
+		case rules.MoveUp:
+			p.Y++
+		case rules.MoveDown:
+			p.Y--
+		case rules.MoveLeft:
+			p.X--
+		case rules.MoveRight:
+			p.X++
+		}
+		if s.wrapped {
+			p.X = (p.X + b.Width) % b.Width
+			p.Y = (p.Y + b.Height) % b.Height
+		} else if p.X < 0 || p.X >= b.Width || p.Y < 0 || p.Y >= b.Height {
+			continue
+		}
+		if !blocked[rules.Point{X: p.X, Y: p.Y}] {
+			moves = append(moves, move)
+		}
+	}
+	return moves
+}
+
+// distance returns the number of moves between two points, wrapping around the edges if needed.
+func (s *mistakeSearch) distance(b *rules.BoardState, p, q rules.Point) int {
+	dx, dy := p.X-q.X, p.Y-q.Y
+	if dx < 0 {
+		dx = -dx
+	}
+	if dy < 0 {
+		dy = -dy
+	}
+	if s.wrapped {
+		if b.Width-dx < dx {
+			dx = b.Width - dx
+		}
+		if b.Height-dy < dy {
+			dy = b.Height - dy
+		}
+	}
+	return dx + dy
+}
+
+// eliminatedBy returns the IDs of the snakes that were eliminated by a snake between two boards.
+func eliminatedBy(before, after *rules.BoardState, id string) []string {
+	var ids []string
+	for _, snake := range after.Snakes {
+		if snake.ID != id && snake.EliminatedBy == id && !isEliminated(before, snake.ID) {
+			ids = append(ids, snake.ID)
+		}
+	}
+	return ids
+}
+
+// intersect returns the IDs in both lists.
+func intersect(a, b []string) []string {
+	var ids []string
+	for _, id := range a {
+		for _, other := range b {
+			if id == other {
+				ids = append(ids, id)
+			}
+		}
+	}
+	return ids
+}
+
+func union(a, b []string) []string {
+	ids := append([]string{}, a...)
+	for _, id := range b {
+		found := false
+		for _, other := range ids {
+			found = found || id == other
+		}
+		if !found {
+			ids = append(ids, id)
+		}
+	}
+	sort.Strings(ids)
+	return ids
+}
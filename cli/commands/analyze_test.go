@@ -0,0 +1,141 @@
+package commands
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/BattlesnakeOfficial/rules"
+	"github.com/BattlesnakeOfficial/rules/maps"
+)
+
+func TestAnalyzeExportedGame(t *testing.T) {
+	// Snake one always moves up and snake two always moves left, until they hit the edge
+	record := playExportedGame(t, rules.GameTypeStandard, "standard", 98765)
+
+	analyzer := gameAnalyzer{Seed: 98765, Depth: 2}
+	analysis, err := analyzer.Analyze(record)
+	require.NoError(t, err)
+	require.Len(t, analysis.Snakes, 2)
+
+	var out bytes.Buffer
+	analysis.Write(&out)
+
+	expected := map[string]mistake{
+		"one": {Kind: mistakeBlunder, Move: rules.MoveUp, Alternatives: []string{rules.MoveLeft, rules.MoveRight}},
+		"two": {Kind: mistakeBlunder, Move: rules.MoveLeft, Alternatives: []string{rules.MoveUp, rules.MoveDown}},
+	}
+	for _, snake := range analysis.Snakes {
+		// Where the snakes start depends on their IDs, so one of them sometimes wins before the other hits the edge
+		if snake.Outcome == "won" {
+			require.Empty(t, snake.Mistakes)
+			continue
+		}
+		require.Len(t, snake.Mistakes, 1, snake.Name)
+		blunder := expected[snake.Name]
+		blunder.Turn = snake.Mistakes[0].Turn
+		require.Equal(t, blunder, snake.Mistakes[0])
+		outcome := fmt.Sprintf("eliminated on turn %d (wall-collision)", blunder.Turn+1)
+		require.Equal(t, outcome, snake.Outcome)
+		require.Contains(t, out.String(), fmt.Sprintf("%s (%s): %s\n  %v\n", snake.Name, snake.ID, outcome, blunder))
+	}
+
+	analyzer.Seed = 56789
+	_, err = analyzer.Analyze(record)
+	require.Error(t, err)
+}
+
+func TestAnalyzeMissedKill(t *testing.T) {
+	ruleset := rules.NewRulesetBuilder().NamedRuleset(rules.GameTypeStandard)
+	searcher := &mistakeSearch{ruleset: ruleset, gameMap: maps.EmptyMap{}}
+
+	// Snake two's only way out of the corner is where snake one can meet it head on
+	before := rules.NewBoardState(7, 7).WithSnakes([]rules.Snake{
+		{ID: "one", Health: 100, Body: []rules.Point{{X: 0, Y: 2}, {X: 0, Y: 3}, {X: 0, Y: 4}, {X: 0, Y: 5}}},
+		{ID: "two", Health: 100, Body: []rules.Point{{X: 0, Y: 0}, {X: 1, Y: 0}, {X: 2, Y: 0}}},
+	})
+	_, after, err := ruleset.Execute(before, []rules.SnakeMove{{ID: "one", Move: rules.MoveRight}, {ID: "two", Move: rules.MoveUp}})
+	require.NoError(t, err)
+
+	m, err := searcher.findMissedKill(before, after, rules.SnakeMove{ID: "one", Move: rules.MoveRight})
+	require.NoError(t, err)
+	require.Equal(t, &mistake{Turn: 0, Kind: mistakeMissedKill, Move: rules.MoveRight, Alternatives: []string{rules.MoveDown}, Victims: []string{"two"}}, m)
+	require.Equal(t, "Turn 0: missed kill, moved right; down would have eliminated two", m.String())
+
+	// Taking the kill isn't a mistake
+	_, after, err = ruleset.Execute(before, []rules.SnakeMove{{ID: "one", Move: rules.MoveDown}, {ID: "two", Move: rules.MoveUp}})
+	require.NoError(t, err)
+	m, err = searcher.findMissedKill(before, after, rules.SnakeMove{ID: "one", Move: rules.MoveDown})
+	require.NoError(t, err)
+	require.Nil(t, m)
+
+	// Snake two can escape if it has somewhere else to go
+	before.Snakes[1].Body = []rules.Point{{X: 1, Y: 1}, {X: 1, Y: 0}, {X: 2, Y: 0}}
+	m, err = searcher.findMissedKill(before, before, rules.SnakeMove{ID: "one", Move: rules.MoveRight})
+	require.NoError(t, err)
+	require.Nil(t, m)
+}
+
+func TestAnalyzeForcedElimination(t *testing.T) {
+	ruleset := rules.NewRulesetBuilder().WithSolo(true).NamedRuleset(rules.GameTypeSolo)
+	searcher := &mistakeSearch{ruleset: ruleset, gameMap: maps.EmptyMap{}}
+
+	// Every move runs out of room within two turns, so none of them is a blunder
+	b := rules.NewBoardState(1, 4).WithSnakes([]rules.Snake{
+		{ID: "one", Health: 100, Body: []rules.Point{{X: 0, Y: 1}, {X: 0, Y: 2}, {X: 0, Y: 3}}},
+	})
+	m, err := searcher.findBlunder(b, rules.SnakeMove{ID: "one", Move: rules.MoveDown}, 2)
+	require.NoError(t, err)
+	require.Nil(t, m)
+
+	ok, err := searcher.survivesMove(b, "one", rules.MoveDown, 1)
+	require.NoError(t, err)
+	require.True(t, ok)
+	ok, err = searcher.survivesMove(b, "one", rules.MoveDown, 2)
+	require.NoError(t, err)
+	require.False(t, ok)
+
+	// A wrapped board leaves room to keep going
+	searcher.wrapped = true
+	searcher.ruleset = rules.NewRulesetBuilder().WithSolo(true).NamedRuleset(rules.GameTypeWrapped)
+	ok, err = searcher.survivesMove(b, "one", rules.MoveDown, 2)
+	require.NoError(t, err)
+	require.True(t, ok)
+}
+
+// hazardFloodMap covers the whole board in deadly hazards at the end of every turn.
+type hazardFloodMap struct {
+	maps.EmptyMap
+}
+
+func (hazardFloodMap) PostUpdateBoard(lastBoardState *rules.BoardState, settings rules.Settings, editor maps.Editor) error {
+	for x := 0; x < lastBoardState.Width; x++ {
+		for y := 0; y < lastBoardState.Height; y++ {
+			editor.AddHazard(rules.Point{X: x, Y: y, Value: 1000})
+		}
+	}
+	return nil
+}
+
+func TestAnalyzeSearchRunsMap(t *testing.T) {
+	ruleset := rules.NewRulesetBuilder().WithSolo(true).NamedRuleset(rules.GameTypeSolo)
+	b := rules.NewBoardState(7, 7).WithSnakes([]rules.Snake{
+		{ID: "one", Health: 100, Body: []rules.Point{{X: 3, Y: 3}, {X: 3, Y: 2}, {X: 3, Y: 1}}},
+	})
+
+	searcher := &mistakeSearch{ruleset: ruleset, gameMap: maps.EmptyMap{}}
+	ok, err := searcher.survivesMove(b, "one", rules.MoveUp, 2)
+	require.NoError(t, err)
+	require.True(t, ok)
+
+	// The hazards the map adds after the first turn eliminate the snake on the second
+	searcher.gameMap = hazardFloodMap{}
+	ok, err = searcher.survivesMove(b, "one", rules.MoveUp, 1)
+	require.NoError(t, err)
+	require.True(t, ok)
+	ok, err = searcher.survivesMove(b, "one", rules.MoveUp, 2)
+	require.NoError(t, err)
+	require.False(t, ok)
+}
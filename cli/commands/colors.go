@@ -4,8 +4,9 @@ package commands
 const (
 	TERM_RESET = "\033[0m"
 
-	TERM_BG_GRAY  = "\033[48;2;127;127;127m"
-	TERM_BG_WHITE = "\033[107m"
+	TERM_BG_GRAY     = "\033[48;2;127;127;127m"
+	TERM_BG_DARKGRAY = "\033[48;2;40;40;40m"
+	TERM_BG_WHITE    = "\033[107m"
 
 	TERM_FG_GRAY      = "\033[38;2;127;127;127m"
 	TERM_FG_LIGHTGRAY = "\033[38;2;200;200;200m"
@@ -0,0 +1,169 @@
+package commands
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/spf13/cobra"
+	log "github.com/spf13/jwalterweatherman"
+
+	"github.com/BattlesnakeOfficial/rules/conformance"
+)
+
+func NewConformanceCommand() *cobra.Command {
+	var conformanceCmd = &cobra.Command{
+		Use:   "conformance",
+		Short: "Export rules test vectors and check other implementations against them",
+		Long: `Export rules test vectors and check other implementations against them.
+
+Each test vector is a single turn: the ruleset, settings and seed, the board before the turn and
+the moves made, along with the board this engine produces. Vectors come from the rules package's
+own test cases and from randomized games played with every ruleset. See conformance/README.md
+for the format of vectors and the protocol used to check other implementations.`,
+		Run: func(cmd *cobra.Command, args []string) {
+			err := cmd.Help()
+			if err != nil {
+				log.ERROR.Fatal(err)
+			}
+		},
+	}
+
+	return conformanceCmd
+}
+
+// suiteOptions are the flags that choose which randomized games are included in a suite.
+type suiteOptions struct {
+	Seed  int64
+	Games int
+	Turns int
+}
+
+func (options *suiteOptions) addFlags(cmd *cobra.Command) {
+	cmd.Flags().Int64VarP(&options.Seed, "seed", "r", 1, "Random seed for the randomized games")
+	cmd.Flags().IntVar(&options.Games, "games", 5, "Number of randomized games to play with each ruleset")
+	cmd.Flags().IntVar(&options.Turns, "turns", 100, "Maximum number of turns in each randomized game")
+}
+
+func (options *suiteOptions) suite() ([]conformance.Vector, error) {
+	return conformance.Suite(options.Seed, options.Games, options.Turns)
+}
+
+func NewConformanceExportCommand() *cobra.Command {
+	options := suiteOptions{}
+	var outputPath string
+	var exportCmd = &cobra.Command{
+		Use:   "export [flags]",
+		Short: "Write the test vectors as JSON Lines",
+		Long: `Write the test vectors as JSON Lines, one vector per line.
+
+The same flags always produce the same vectors.`,
+		Args: cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			vectors, err := options.suite()
+			if err != nil {
+				log.ERROR.Fatalf("Error generating vectors: %v", err)
+			}
+			if err := writeOutput(outputPath, func(w io.Writer) error { return conformance.Write(w, vectors) }); err != nil {
+				log.ERROR.Fatalf("Error writing vectors: %v", err)
+			}
+		},
+	}
+
+	options.addFlags(exportCmd)
+	exportCmd.Flags().StringVarP(&outputPath, "output", "o", "", "File path to write the vectors to (defaults to stdout)")
+
+	return exportCmd
+}
+
+type conformanceRunner struct {
+	suiteOptions
+	VectorsPath string
+	SkipRandom  bool
+	MaxFailures int
+}
+
+func NewConformanceRunCommand() *cobra.Command {
+	runner := conformanceRunner{}
+	var runCmd = &cobra.Command{
+		Use:   "run [flags] -- command [args...]",
+		Short: "Check an implementation of the rules against the test vectors",
+		Long: `Start a program implementing the rules and check that it produces the same result as this
+engine for every test vector. The program is sent one JSON request per line on its standard input,
+and must write one JSON response per line to its standard output.
+
+Vectors are read from --vectors if it's given, or generated the same way as "conformance export".`,
+		Args: cobra.MinimumNArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			vectors, err := runner.vectors()
+			if err != nil {
+				log.ERROR.Fatalf("Error loading vectors: %v", err)
+			}
+			process, err := conformance.StartProcess(args[0], args[1:]...)
+			if err != nil {
+				log.ERROR.Fatalf("Error starting implementation: %v", err)
+			}
+			report, err := conformance.Check(process, vectors, runner.SkipRandom)
+			if err != nil {
+				log.ERROR.Fatalf("Error checking implementation: %v", err)
+			}
+			if err := process.Close(); err != nil {
+				log.WARN.Printf("Implementation exited with an error: %v", err)
+			}
+
+			runner.WriteReport(os.Stdout, report)
+			if len(report.Failures) > 0 {
+				os.Exit(1)
+			}
+		},
+	}
+
+	runner.addFlags(runCmd)
+	runCmd.Flags().StringVar(&runner.VectorsPath, "vectors", "", "File to read vectors from (defaults to generating them)")
+	runCmd.Flags().BoolVar(&runner.SkipRandom, "skipRandom", false, "Skip vectors whose results depend on the random seed")
+	runCmd.Flags().IntVar(&runner.MaxFailures, "maxFailures", 20, "Maximum number of failures to list (0 to list all of them)")
+
+	return runCmd
+}
+
+func (runner *conformanceRunner) vectors() ([]conformance.Vector, error) {
+	if runner.VectorsPath == "" {
+		return runner.suite()
+	}
+	f, err := os.Open(runner.VectorsPath)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return conformance.Read(f)
+}
+
+// WriteReport lists the vectors that failed, followed by a summary.
+func (runner *conformanceRunner) WriteReport(w io.Writer, report *conformance.Report) {
+	for i, failure := range report.Failures {
+		if runner.MaxFailures > 0 && i == runner.MaxFailures {
+			fmt.Fprintf(w, "... and %d more\n", len(report.Failures)-i)
+			break
+		}
+		fmt.Fprintf(w, "FAIL %v\n", failure)
+	}
+	fmt.Fprintf(w, "%d passed, %d failed, %d skipped\n", report.Passed, len(report.Failures), report.Skipped)
+}
+
+func NewConformanceServeCommand() *cobra.Command {
+	var serveCmd = &cobra.Command{
+		Use:   "serve",
+		Short: "Answer conformance requests with this engine",
+		Long: `Answer conformance requests on standard input with this engine's results on standard output,
+speaking the same protocol that "conformance run" expects. This is useful for testing the runner,
+or for checking one implementation against another.`,
+		Args: cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			if err := conformance.Serve(conformance.Engine{}, os.Stdin, os.Stdout); err != nil {
+				log.ERROR.Fatalf("Error serving requests: %v", err)
+			}
+		},
+	}
+
+	return serveCmd
+}
@@ -0,0 +1,63 @@
+package commands
+
+import (
+	"bytes"
+	"io"
+	"path/filepath"
+	"testing"
+
+	"github.com/BattlesnakeOfficial/rules/conformance"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConformanceVectors(t *testing.T) {
+	runner := conformanceRunner{suiteOptions: suiteOptions{Seed: 3, Games: 1, Turns: 5}}
+	generated, err := runner.vectors()
+	require.NoError(t, err)
+	require.NotEmpty(t, generated)
+
+	path := filepath.Join(t.TempDir(), "vectors.jsonl")
+	require.NoError(t, writeOutput(path, func(w io.Writer) error { return conformance.Write(w, generated) }))
+
+	runner.VectorsPath = path
+	read, err := runner.vectors()
+	require.NoError(t, err)
+	require.Equal(t, generated, read)
+
+	report, err := conformance.Check(conformance.Engine{}, read, false)
+	require.NoError(t, err)
+	require.Empty(t, report.Failures)
+
+	runner.VectorsPath = filepath.Join(t.TempDir(), "missing.jsonl")
+	_, err = runner.vectors()
+	require.Error(t, err)
+}
+
+func TestConformanceReport(t *testing.T) {
+	report := &conformance.Report{
+		Passed:  10,
+		Skipped: 2,
+		Failures: []conformance.Failure{
+			{Name: "table/standard/one", Reason: "turn is 2, expected 1"},
+			{Name: "table/standard/two", Reason: "gameOver is true, expected false"},
+			{Name: "table/standard/three", Reason: "no state returned"},
+		},
+	}
+
+	var buf bytes.Buffer
+	runner := conformanceRunner{}
+	runner.WriteReport(&buf, report)
+	require.Equal(t, `FAIL table/standard/one: turn is 2, expected 1
+FAIL table/standard/two: gameOver is true, expected false
+FAIL table/standard/three: no state returned
+10 passed, 3 failed, 2 skipped
+`, buf.String())
+
+	buf.Reset()
+	runner.MaxFailures = 1
+	runner.WriteReport(&buf, report)
+	require.Equal(t, `FAIL table/standard/one: turn is 2, expected 1
+... and 2 more
+10 passed, 3 failed, 2 skipped
+`, buf.String())
+}
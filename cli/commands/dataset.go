@@ -0,0 +1,129 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+	log "github.com/spf13/jwalterweatherman"
+
+	"github.com/BattlesnakeOfficial/rules/features"
+)
+
+type datasetExport struct {
+	OutputDir string
+	Width     int
+	Height    int
+}
+
+func NewExportDatasetCommand() *cobra.Command {
+	export := datasetExport{}
+	var exportCmd = &cobra.Command{
+		Use:   "export-dataset [flags] game.jsonl [...game.jsonl]",
+		Short: "Convert exported games into feature planes for machine learning",
+		Long: `Convert games exported with "play --output" into fixed-size feature planes for machine learning.
+
+Every turn of every game is encoded once per snake, from that snake's perspective, and labelled
+with the move the snake made and the final outcome of the game for that snake. Turns where the
+move can't be determined (e.g. the snake was eliminated by it) are skipped.
+
+Three NPY files are written to the output directory:
+  features.npy  float32 (N, planes, height, width)
+  moves.npy     int8 (N,) - 0: up, 1: down, 2: left, 3: right
+  outcomes.npy  int8 (N,) - 1: win, 0: draw, -1: loss`,
+		Args: cobra.MinimumNArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			if err := export.Run(args); err != nil {
+				log.ERROR.Fatalf("Error exporting dataset: %v", err)
+			}
+		},
+	}
+
+	exportCmd.Flags().StringVarP(&export.OutputDir, "output", "o", ".", "Directory to write the dataset files to")
+	exportCmd.Flags().IntVarP(&export.Width, "width", "W", 0, "Width of the feature planes (defaults to the widest board in the input)")
+	exportCmd.Flags().IntVarP(&export.Height, "height", "H", 0, "Height of the feature planes (defaults to the tallest board in the input)")
+
+	return exportCmd
+}
+
+func (export *datasetExport) Run(paths []string) error {
+	records := make([]*gameRecord, 0, len(paths))
+	width, height := export.Width, export.Height
+	for _, path := range paths {
+		record, err := loadGameRecord(path)
+		if err != nil {
+			return err
+		}
+		records = append(records, record)
+
+		if export.Width == 0 && record.Turns[0].Board.Width > width {
+			width = record.Turns[0].Board.Width
+		}
+		if export.Height == 0 && record.Turns[0].Board.Height > height {
+			height = record.Turns[0].Board.Height
+		}
+	}
+
+	dataset := features.NewDataset(features.NewEncoder(width, height))
+	for i, record := range records {
+		if err := addGameToDataset(dataset, record); err != nil {
+			return fmt.Errorf("%s: %w", paths[i], err)
+		}
+	}
+
+	if err := os.MkdirAll(export.OutputDir, 0755); err != nil {
+		return err
+	}
+	files := make([]*os.File, 0, 3)
+	defer func() {
+		for _, f := range files {
+			f.Close()
+		}
+	}()
+	for _, name := range []string{"features.npy", "moves.npy", "outcomes.npy"} {
+		f, err := os.Create(filepath.Join(export.OutputDir, name))
+		if err != nil {
+			return err
+		}
+		files = append(files, f)
+	}
+	if err := dataset.WriteNPY(files[0], files[1], files[2]); err != nil {
+		return err
+	}
+
+	log.INFO.Printf("Wrote %d samples from %d games (%dx%d) to %s", dataset.Len(), len(records), width, height, export.OutputDir)
+	return nil
+}
+
+// addGameToDataset adds a sample for every snake on every turn of the game where the move made is known.
+func addGameToDataset(dataset *features.Dataset, record *gameRecord) error {
+	for i := range record.Turns {
+		moves := record.Moves(i)
+		if len(moves) == 0 {
+			continue
+		}
+		boardState := record.BoardState(i)
+		for _, snake := range boardState.Snakes {
+			move, ok := moves[snake.ID]
+			if !ok {
+				continue
+			}
+			if err := dataset.Add(boardState, snake.ID, move, gameOutcome(record, snake.ID)); err != nil {
+				return fmt.Errorf("turn %d: %w", boardState.Turn, err)
+			}
+		}
+	}
+	return nil
+}
+
+// gameOutcome returns whether the given snake won, lost or drew the game.
+func gameOutcome(record *gameRecord, snakeID string) int {
+	if record.Result.IsDraw {
+		return features.OutcomeDraw
+	}
+	if record.Result.WinnerID == snakeID {
+		return features.OutcomeWin
+	}
+	return features.OutcomeLoss
+}
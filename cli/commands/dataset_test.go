@@ -0,0 +1,66 @@
+package commands
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/BattlesnakeOfficial/rules"
+	"github.com/BattlesnakeOfficial/rules/features"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExportDataset(t *testing.T) {
+	states := []*rules.BoardState{
+		rules.NewBoardState(7, 7).WithSnakes([]rules.Snake{
+			{ID: "one", Health: 100, Body: []rules.Point{{X: 1, Y: 1}, {X: 1, Y: 1}, {X: 1, Y: 1}}},
+			{ID: "two", Health: 100, Body: []rules.Point{{X: 5, Y: 5}, {X: 5, Y: 5}, {X: 5, Y: 5}}},
+		}),
+		rules.NewBoardState(7, 7).WithTurn(1).WithSnakes([]rules.Snake{
+			{ID: "one", Health: 99, Body: []rules.Point{{X: 2, Y: 1}, {X: 1, Y: 1}, {X: 1, Y: 1}}},
+			{ID: "two", Health: 99, Body: []rules.Point{{X: 5, Y: 6}, {X: 5, Y: 5}, {X: 5, Y: 5}}},
+		}),
+		rules.NewBoardState(7, 7).WithTurn(2).WithSnakes([]rules.Snake{
+			{ID: "one", Health: 98, Body: []rules.Point{{X: 2, Y: 2}, {X: 2, Y: 1}, {X: 1, Y: 1}}},
+			{ID: "two", Health: 98, Body: []rules.Point{{X: 5, Y: 7}, {X: 5, Y: 6}, {X: 5, Y: 5}}, EliminatedCause: rules.EliminatedByOutOfBounds},
+		}),
+	}
+
+	dir := t.TempDir()
+	gamePath := filepath.Join(dir, "game.jsonl")
+	export := buildGameExport(t, states, SnakeState{ID: "one", Name: "one"}, false)
+	require.NoError(t, os.WriteFile(gamePath, export.Bytes(), 0644))
+
+	// The dataset can be built directly from the record
+	record, err := loadGameRecord(gamePath)
+	require.NoError(t, err)
+	dataset := features.NewDataset(features.NewEncoder(7, 7))
+	require.NoError(t, addGameToDataset(dataset, record))
+	require.Equal(t, 3, dataset.Len(), "two moves on turn 0 and one on turn 1")
+
+	// Running the command writes all three files
+	outputDir := filepath.Join(dir, "out")
+	datasetExport := datasetExport{OutputDir: outputDir}
+	require.NoError(t, datasetExport.Run([]string{gamePath}))
+
+	for _, name := range []string{"features.npy", "moves.npy", "outcomes.npy"} {
+		data, err := os.ReadFile(filepath.Join(outputDir, name))
+		require.NoError(t, err)
+		require.Equal(t, "\x93NUMPY", string(data[:6]))
+	}
+	moves, err := os.ReadFile(filepath.Join(outputDir, "moves.npy"))
+	require.NoError(t, err)
+	require.Equal(t, []byte{3, 0, 0}, moves[len(moves)-3:])
+	outcomes, err := os.ReadFile(filepath.Join(outputDir, "outcomes.npy"))
+	require.NoError(t, err)
+	require.Equal(t, []byte{1, 0xff, 1}, outcomes[len(outcomes)-3:])
+}
+
+func TestGameOutcome(t *testing.T) {
+	record := &gameRecord{Result: result{WinnerID: "one"}}
+	require.Equal(t, features.OutcomeWin, gameOutcome(record, "one"))
+	require.Equal(t, features.OutcomeLoss, gameOutcome(record, "two"))
+
+	record = &gameRecord{Result: result{IsDraw: true}}
+	require.Equal(t, features.OutcomeDraw, gameOutcome(record, "one"))
+}
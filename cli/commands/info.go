@@ -1,6 +1,7 @@
 package commands
 
 import (
+	"encoding/json"
 	"fmt"
 
 	"github.com/spf13/cobra"
@@ -10,7 +11,87 @@ import (
 )
 
 type mapInfo struct {
-	All bool
+	All    bool
+	Format string
+}
+
+// mapInfoJSON is the metadata of a map printed with --format json.
+type mapInfoJSON struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	Author      string `json:"author"`
+	Description string `json:"description"`
+	Version     int    `json:"version"`
+	MinPlayers  int    `json:"minPlayers"`
+	MaxPlayers  int    `json:"maxPlayers"`
+	// AnySize is true for maps that can be played on any board size, in which case BoardSizes
+	// is empty.
+	AnySize    bool               `json:"anySize"`
+	BoardSizes []boardSizeJSON    `json:"boardSizes"`
+	Tags       []string           `json:"tags"`
+	Params     []mapParamInfoJSON `json:"params"`
+}
+
+type boardSizeJSON struct {
+	Width  int `json:"width"`
+	Height int `json:"height"`
+}
+
+type mapParamInfoJSON struct {
+	Name        string `json:"name"`
+	Type        string `json:"type"`
+	Default     string `json:"default"`
+	Description string `json:"description"`
+}
+
+func newMapInfoJSON(gameMap maps.GameMap) mapInfoJSON {
+	meta := gameMap.Meta()
+	info := mapInfoJSON{
+		ID:          gameMap.ID(),
+		Name:        meta.Name,
+		Author:      meta.Author,
+		Description: meta.Description,
+		Version:     meta.Version,
+		MinPlayers:  meta.MinPlayers,
+		MaxPlayers:  meta.MaxPlayers,
+		AnySize:     meta.BoardSizes.IsUnlimited(),
+		BoardSizes:  []boardSizeJSON{},
+		Tags:        append([]string{}, meta.Tags...),
+		Params:      []mapParamInfoJSON{},
+	}
+	if !info.AnySize {
+		for _, size := range meta.BoardSizes {
+			info.BoardSizes = append(info.BoardSizes, boardSizeJSON{Width: size.Width, Height: size.Height})
+		}
+	}
+	for _, p := range meta.Params {
+		info.Params = append(info.Params, mapParamInfoJSON{Name: p.Name, Type: p.Type, Default: p.Default, Description: p.Description})
+	}
+	return info
+}
+
+// printMapsJSON prints the metadata of maps as a JSON array.
+func printMapsJSON(ids []string) {
+	infos := make([]mapInfoJSON, 0, len(ids))
+	for _, id := range ids {
+		gameMap, err := maps.GetMap(id)
+		if err != nil {
+			log.ERROR.Fatalf("Failed to load game map %v: %v", id, err)
+		}
+		infos = append(infos, newMapInfoJSON(gameMap))
+	}
+	data, err := json.MarshalIndent(infos, "", "  ")
+	if err != nil {
+		log.ERROR.Fatalf("Failed to encode map info: %v", err)
+	}
+	fmt.Println(string(data))
+}
+
+// checkFormat exits if an output format isn't supported.
+func checkFormat(format string) {
+	if format != "text" && format != "json" {
+		log.ERROR.Fatalf("Unknown format %q, expected text or json", format)
+	}
 }
 
 func NewMapInfoCommand() *cobra.Command {
@@ -20,9 +101,15 @@ func NewMapInfoCommand() *cobra.Command {
 		Short: "Display metadata for given map(s)",
 		Long:  "Display metadata for given map(s)",
 		Run: func(cmd *cobra.Command, args []string) {
+			checkFormat(info.Format)
+
 			// handle --all flag first as there would be no args
 			if info.All {
 				mapList := maps.List()
+				if info.Format == "json" {
+					printMapsJSON(mapList)
+					return
+				}
 				for i, m := range mapList {
 					info.display(m)
 					if i < (len(mapList) - 1) {
@@ -41,6 +128,11 @@ func NewMapInfoCommand() *cobra.Command {
 				return
 			}
 
+			if info.Format == "json" {
+				printMapsJSON(args)
+				return
+			}
+
 			// display all maps via command args
 			for i, m := range args {
 				info.display(m)
@@ -53,6 +145,7 @@ func NewMapInfoCommand() *cobra.Command {
 	}
 
 	infoCmd.Flags().BoolVarP(&info.All, "all", "a", false, "Display information for all maps")
+	infoCmd.Flags().StringVarP(&info.Format, "format", "f", "text", "Output format, text or json")
 
 	return infoCmd
 }
@@ -86,4 +179,14 @@ func (m *mapInfo) display(id string) {
 			fmt.Print("\n")
 		}
 	}
+	if len(meta.Params) > 0 {
+		fmt.Println("Params:")
+		for _, p := range meta.Params {
+			defaultValue := p.Default
+			if defaultValue == "" {
+				defaultValue = "none"
+			}
+			fmt.Printf("  %s (%s, default %s): %s\n", p.Name, p.Type, defaultValue, p.Description)
+		}
+	}
 }
@@ -0,0 +1,88 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	log "github.com/spf13/jwalterweatherman"
+
+	"github.com/BattlesnakeOfficial/rules/maps"
+)
+
+type mapLinter struct {
+	All   bool
+	Seeds int
+}
+
+func NewMapLintCommand() *cobra.Command {
+	linter := mapLinter{}
+	var lintCmd = &cobra.Command{
+		Use:   "lint [flags] map_name [...map_name]",
+		Short: "Check that map(s) set up correctly and fairly",
+		Long: `Set up each map many times for every board size and number of players it supports, and check
+that all snakes are placed, no snake starts in a hazard, and every cell without a hazard can be
+reached. Fairness is measured as the distance from each snake to the nearest food and the area each
+snake can reach before any other snake.
+
+Exits with a non-zero status if any problems are found.`,
+		Run: func(cmd *cobra.Command, args []string) {
+			mapIDs := args
+			if linter.All {
+				mapIDs = maps.List()
+			}
+			if len(mapIDs) < 1 {
+				err := cmd.Help()
+				if err != nil {
+					log.ERROR.Fatal(err)
+				}
+				return
+			}
+
+			ok := true
+			for i, id := range mapIDs {
+				if i > 0 {
+					fmt.Print("\n")
+				}
+				ok = linter.lint(id) && ok
+			}
+			if !ok {
+				os.Exit(1)
+			}
+		},
+	}
+
+	lintCmd.Flags().BoolVarP(&linter.All, "all", "a", false, "Lint all maps")
+	lintCmd.Flags().IntVarP(&linter.Seeds, "seeds", "s", 20, "Number of random seeds to set up each board with")
+
+	return lintCmd
+}
+
+// lint prints the results for a map, returning false if any problems were found.
+func (linter *mapLinter) lint(id string) bool {
+	gameMap, err := maps.GetMap(id)
+	if err != nil {
+		log.ERROR.Fatalf("Failed to load game map %v: %v", id, err)
+	}
+
+	fmt.Println(gameMap.ID())
+	ok := true
+	for _, result := range maps.LintMap(gameMap, linter.Seeds) {
+		status := "ok"
+		if !result.OK() {
+			status = "FAIL"
+			ok = false
+		}
+		fmt.Printf("  %dx%d, %d players: %s, food distance %s, reachable area %s\n",
+			result.Width, result.Height, result.Players, status,
+			formatLintStat(result.FoodDistance), formatLintStat(result.ReachableArea))
+		for _, problem := range result.Problems {
+			fmt.Printf("    %s (%d of %d games, first with seed %d)\n", problem.Message, problem.Count, result.Games, problem.Seed)
+		}
+	}
+	return ok
+}
+
+func formatLintStat(stat maps.LintStat) string {
+	return fmt.Sprintf("%d-%d (mean %.1f, spread %d)", stat.Min, stat.Max, stat.Mean(), stat.Spread)
+}
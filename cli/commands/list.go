@@ -5,18 +5,63 @@ import (
 
 	"github.com/BattlesnakeOfficial/rules/maps"
 	"github.com/spf13/cobra"
+	log "github.com/spf13/jwalterweatherman"
 )
 
+type mapList struct {
+	Tags                []string
+	Players             int
+	Size                string
+	ExcludeExperimental bool
+	Format              string
+}
+
 func NewMapListCommand() *cobra.Command {
+	list := mapList{}
 	var listCmd = &cobra.Command{
 		Use:   "list",
 		Short: "List available game maps",
-		Long:  "List available game maps",
+		Long:  "List available game maps, optionally only those matching all of the given filters",
 		Run: func(cmd *cobra.Command, args []string) {
-			for _, m := range maps.List() {
+			checkFormat(list.Format)
+
+			filter, err := list.filter()
+			if err != nil {
+				log.ERROR.Fatal(err)
+			}
+			ids := maps.FilterMaps(filter)
+
+			if list.Format == "json" {
+				printMapsJSON(ids)
+				return
+			}
+			for _, m := range ids {
 				fmt.Println(m)
 			}
 		},
 	}
+
+	listCmd.Flags().StringArrayVar(&list.Tags, "tag", nil, "Only list maps with this tag, can be repeated")
+	listCmd.Flags().IntVar(&list.Players, "players", 0, "Only list maps that support this many players")
+	listCmd.Flags().StringVar(&list.Size, "size", "", "Only list maps that support this board size, as WxH")
+	listCmd.Flags().BoolVar(&list.ExcludeExperimental, "exclude-experimental", false, "Leave out experimental maps")
+	listCmd.Flags().StringVarP(&list.Format, "format", "f", "text", "Output format, text or json")
+
 	return listCmd
 }
+
+func (list *mapList) filter() (maps.Filter, error) {
+	filter := maps.Filter{
+		Tags:                list.Tags,
+		Players:             list.Players,
+		ExcludeExperimental: list.ExcludeExperimental,
+	}
+	if list.Size != "" {
+		size, err := maps.ParseDimensions(list.Size)
+		if err != nil {
+			return maps.Filter{}, err
+		}
+		filter.Size = size
+	}
+	return filter, nil
+}
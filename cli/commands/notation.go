@@ -0,0 +1,239 @@
+package commands
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/spf13/cobra"
+	log "github.com/spf13/jwalterweatherman"
+
+	"github.com/BattlesnakeOfficial/rules/client"
+	"github.com/BattlesnakeOfficial/rules/maps"
+	"github.com/BattlesnakeOfficial/rules/notation"
+	"github.com/BattlesnakeOfficial/rules/replay"
+)
+
+func NewNotationCommand() *cobra.Command {
+	var notationCmd = &cobra.Command{
+		Use:   "notation",
+		Short: "Convert games to and from compact move-log notation",
+		Long: `Convert games to and from compact move-log notation.
+
+Move-log notation records the ruleset, map, seed, settings and snakes of a game followed by
+the moves made on every turn. Boards are recomputed by re-simulating the game.`,
+		Run: func(cmd *cobra.Command, args []string) {
+			err := cmd.Help()
+			if err != nil {
+				log.ERROR.Fatal(err)
+			}
+		},
+	}
+
+	return notationCmd
+}
+
+type notationEncoder struct {
+	Seed       int64
+	OutputPath string
+}
+
+func NewNotationEncodeCommand() *cobra.Command {
+	encoder := notationEncoder{}
+	var encodeCmd = &cobra.Command{
+		Use:   "encode [flags] game.jsonl",
+		Short: "Convert an exported game into move-log notation",
+		Long: `Convert a game exported with "play --output" into move-log notation.
+
+Game exports don't include the random seed, so the seed printed by "play" must be passed with --seed.
+The game is re-simulated to recover moves that aren't in the export, so the conversion fails if the
+game can't be reproduced.`,
+		Args: cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			record, err := loadGameRecord(args[0])
+			if err != nil {
+				log.ERROR.Fatalf("Error reading game: %v", err)
+			}
+			game, err := encoder.Encode(record)
+			if err != nil {
+				log.ERROR.Fatalf("Error converting game: %v", err)
+			}
+			if err := writeOutput(encoder.OutputPath, func(w io.Writer) error { return notation.Write(w, game) }); err != nil {
+				log.ERROR.Fatalf("Error writing game: %v", err)
+			}
+		},
+	}
+
+	encodeCmd.Flags().Int64VarP(&encoder.Seed, "seed", "r", 0, "Random seed the game was played with")
+	encodeCmd.Flags().StringVarP(&encoder.OutputPath, "output", "o", "", "File path to write the notation to (defaults to stdout)")
+	_ = encodeCmd.MarkFlagRequired("seed")
+
+	return encodeCmd
+}
+
+// Encode converts a recorded game into notation, re-simulating it to recover every move made.
+func (encoder *notationEncoder) Encode(record *gameRecord) (*notation.Game, error) {
+	ruleset, gameMap, err := record.rulesetAndMap(encoder.Seed)
+	if err != nil {
+		return nil, err
+	}
+	recorded, moves := record.boardStatesAndMoves()
+	allMoves, err := replay.Reconstruct(ruleset, gameMap, recorded, moves)
+	if err != nil {
+		return nil, err
+	}
+
+	settings, err := record.rulesetParams(gameMap)
+	if err != nil {
+		return nil, err
+	}
+	initial := record.Turns[0].Board
+	game := &notation.Game{
+		ID:       record.Game.ID,
+		Ruleset:  ruleset.Name(),
+		Map:      gameMap.ID(),
+		Seed:     encoder.Seed,
+		Width:    initial.Width,
+		Height:   initial.Height,
+		Settings: settings,
+	}
+	for _, snake := range initial.Snakes {
+		game.Snakes = append(game.Snakes, notation.Snake{ID: snake.ID, Name: snake.Name})
+	}
+	for _, turnMoves := range allMoves {
+		if err := game.AddTurn(turnMoves); err != nil {
+			return nil, err
+		}
+	}
+	return game, nil
+}
+
+type notationDecoder struct {
+	MapFile    string
+	OutputPath string
+}
+
+func NewNotationDecodeCommand() *cobra.Command {
+	decoder := notationDecoder{}
+	var decodeCmd = &cobra.Command{
+		Use:   "decode [flags] game.bsn",
+		Short: "Convert move-log notation into a game export",
+		Long: `Re-simulate a game written in move-log notation and write it in the same JSONL format as "play --output".
+
+Games played with "play --map-file" need the same map file passed with --map-file.`,
+		Args: cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			f, err := os.Open(args[0])
+			if err != nil {
+				log.ERROR.Fatalf("Error reading game: %v", err)
+			}
+			defer f.Close()
+			game, err := notation.Parse(f)
+			if err != nil {
+				log.ERROR.Fatalf("Error reading game: %s: %v", args[0], err)
+			}
+
+			exporter, err := decoder.Decode(game)
+			if err != nil {
+				log.ERROR.Fatalf("Error converting game: %v", err)
+			}
+			err = writeOutput(decoder.OutputPath, func(w io.Writer) error {
+				_, err := exporter.FlushToFile(w)
+				return err
+			})
+			if err != nil {
+				log.ERROR.Fatalf("Error writing game: %v", err)
+			}
+		},
+	}
+
+	decodeCmd.Flags().StringVar(&decoder.MapFile, "map-file", "", "JSON, YAML or ASCII art (.txt) file describing the custom game map the game was played with")
+	decodeCmd.Flags().StringVarP(&decoder.OutputPath, "output", "o", "", "File path to write the game export to (defaults to stdout)")
+
+	return decodeCmd
+}
+
+// Decode re-simulates a game in notation form and builds the equivalent game export.
+func (decoder *notationDecoder) Decode(game *notation.Game) (*GameExporter, error) {
+	gameMap, err := decoder.gameMap(game.Map)
+	if err != nil {
+		return nil, err
+	}
+	states, err := game.ReplayWithMap(gameMap)
+	if err != nil {
+		return nil, err
+	}
+
+	ruleset := game.NewRuleset()
+	snakeStates := map[string]SnakeState{}
+	for _, snake := range game.Snakes {
+		snakeStates[snake.ID] = SnakeState{ID: snake.ID, Name: snake.Name}
+	}
+
+	exporter := &GameExporter{
+		game: newExportedGame(client.Game{
+			ID: game.ID,
+			Ruleset: client.Ruleset{
+				Name:     ruleset.Name(),
+				Version:  "cli",
+				Settings: client.ConvertRulesetSettings(ruleset.Settings()),
+			},
+			Map: game.Map,
+		}, gameMap, ruleset.Settings()),
+	}
+	for _, state := range states {
+		request := client.SnakeRequest{
+			Game:  exporter.game.Game,
+			Turn:  state.Turn,
+			Board: convertStateToBoard(state, snakeStates),
+		}
+		// like "play --output", the first snake is used to fill in "you"
+		if len(state.Snakes) > 0 {
+			request.You = convertRulesSnake(state.Snakes[0], snakeStates[state.Snakes[0].ID])
+		}
+		exporter.AddTurn(request, state)
+	}
+
+	// Decide the result the same way "play" does, ranking the snakes with the game's tie-breakers
+	result := &GameState{ruleset: ruleset, snakeStates: snakeStates}
+	final := states[len(states)-1]
+	standings, err := result.rankSnakes(final)
+	if err != nil {
+		return nil, err
+	}
+	exporter.winner, exporter.isDraw = result.determineResult(final, standings)
+
+	return exporter, nil
+}
+
+// gameMap returns the map a game was played on, loading it from --map-file if one was given.
+func (decoder *notationDecoder) gameMap(mapID string) (maps.GameMap, error) {
+	if decoder.MapFile != "" {
+		fileMap, err := maps.LoadMapFile(decoder.MapFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load game map file: %w", err)
+		}
+		return fileMap, nil
+	}
+	gameMap, err := maps.GetMap(mapID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load game map %#v: %w", mapID, err)
+	}
+	return gameMap, nil
+}
+
+// writeOutput calls write with the file at path, or stdout if path is empty.
+func writeOutput(path string, write func(w io.Writer) error) error {
+	if path == "" {
+		return write(os.Stdout)
+	}
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := write(f); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
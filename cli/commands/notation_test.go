@@ -0,0 +1,118 @@
+package commands
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/BattlesnakeOfficial/rules"
+	"github.com/BattlesnakeOfficial/rules/notation"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNotationRoundTrip(t *testing.T) {
+	for _, test := range []struct {
+		gameType  string
+		mapName   string
+		mapParams []string
+	}{
+		{rules.GameTypeStandard, "standard", nil},
+		{rules.GameTypeRoyale, "royale", nil},
+		{rules.GameTypeWrapped, "standard", nil},
+		{rules.GameTypeStandard, "hz_scatter", []string{"spawnEveryNTurns=1"}},
+	} {
+		t.Run(test.gameType+"/"+test.mapName, func(t *testing.T) {
+			record := playExportedGame(t, test.gameType, test.mapName, 24680, test.mapParams...)
+
+			encoder := notationEncoder{Seed: 24680}
+			game, err := encoder.Encode(record)
+			require.NoError(t, err)
+			require.Len(t, game.Turns, len(record.Turns)-1)
+
+			var text bytes.Buffer
+			require.NoError(t, notation.Write(&text, game))
+			parsed, err := notation.Parse(&text)
+			require.NoError(t, err)
+
+			decoder := notationDecoder{}
+			exporter, err := decoder.Decode(parsed)
+			require.NoError(t, err)
+			var export bytes.Buffer
+			_, err = exporter.FlushToFile(&export)
+			require.NoError(t, err)
+			decoded, err := readGameRecord(&export)
+			require.NoError(t, err)
+
+			require.Equal(t, record.Game.ID, decoded.Game.ID)
+			require.Equal(t, record.Game.Ruleset, decoded.Game.Ruleset)
+			require.Equal(t, record.Game.Map, decoded.Game.Map)
+			require.Equal(t, record.Game.MapParams, decoded.Game.MapParams)
+			require.Equal(t, record.Result, decoded.Result)
+			require.Len(t, decoded.Turns, len(record.Turns))
+			for i := range record.Turns {
+				require.Equal(t, record.BoardState(i), decoded.BoardState(i), "turn %d", i)
+			}
+		})
+	}
+}
+
+func TestNotationTurnLimitResult(t *testing.T) {
+	// Both snakes are still in the game at the turn limit, tied on length and health
+	gameState := buildDefaultGameState()
+	gameState.Seed = 24680
+	gameState.TurnLimit = 1
+	record := playGameState(t, gameState)
+	require.Equal(t, result{IsDraw: true}, record.Result)
+
+	encoder := notationEncoder{Seed: 24680}
+	game, err := encoder.Encode(record)
+	require.NoError(t, err)
+	decoder := notationDecoder{}
+	exporter, err := decoder.Decode(game)
+	require.NoError(t, err)
+	require.True(t, exporter.isDraw)
+	require.Empty(t, exporter.winner.ID)
+}
+
+func TestNotationEncodeWrongSeed(t *testing.T) {
+	record := playExportedGame(t, rules.GameTypeStandard, "standard", 24680)
+	encoder := notationEncoder{Seed: 1}
+	_, err := encoder.Encode(record)
+	require.Error(t, err)
+}
+
+func TestNotationMapFile(t *testing.T) {
+	gameState := buildDefaultGameState()
+	gameState.Width, gameState.Height = 7, 7
+	gameState.MapFile = "../../maps/testdata/arena.yaml"
+	gameState.Seed = 24680
+	record := playGameState(t, gameState)
+
+	encoder := notationEncoder{Seed: 24680}
+	game, err := encoder.Encode(record)
+	require.NoError(t, err)
+	require.Equal(t, "arena", game.Map)
+
+	// The map file has to be given again, since notation only records the map's ID
+	decoder := notationDecoder{}
+	_, err = decoder.Decode(game)
+	require.EqualError(t, err, `failed to load game map "arena": map not found`)
+
+	decoder = notationDecoder{MapFile: "../../maps/testdata/arena.yaml"}
+	exporter, err := decoder.Decode(game)
+	require.NoError(t, err)
+	var export bytes.Buffer
+	_, err = exporter.FlushToFile(&export)
+	require.NoError(t, err)
+	decoded, err := readGameRecord(&export)
+	require.NoError(t, err)
+	require.Equal(t, record.Game.MapDefinition, decoded.Game.MapDefinition)
+	require.Len(t, decoded.Turns, len(record.Turns))
+	for i := range record.Turns {
+		require.Equal(t, record.BoardState(i), decoded.BoardState(i), "turn %d", i)
+	}
+
+	decoder = notationDecoder{MapFile: "../../maps/testdata/arena.txt"}
+	game.Map = "other"
+	_, err = decoder.Decode(game)
+	require.EqualError(t, err, `game was played on map "other", not "arena"`)
+}
@@ -4,15 +4,91 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"sort"
 
+	"github.com/BattlesnakeOfficial/rules"
 	"github.com/BattlesnakeOfficial/rules/client"
+	"github.com/BattlesnakeOfficial/rules/maps"
 )
 
 type GameExporter struct {
-	game          client.Game
-	snakeRequests []client.SnakeRequest
-	winner        SnakeState
-	isDraw        bool
+	game   exportedGame
+	turns  []exportedTurn
+	winner SnakeState
+	isDraw bool
+}
+
+// exportedGame is the first line of a game export. It's the game as sent to snakes, along with the
+// map parameters it was played with, which snakes never see. Games played with a map file also
+// include the map's definition, since the map can't be looked up by its ID.
+type exportedGame struct {
+	client.Game
+	MapParams     map[string]string   `json:"mapParams,omitempty"`
+	MapDefinition *maps.MapDefinition `json:"mapDefinition,omitempty"`
+}
+
+// newExportedGame returns the first line of a game export for a game played with the map.
+func newExportedGame(game client.Game, gameMap maps.GameMap, settings rules.Settings) exportedGame {
+	exported := exportedGame{Game: game, MapParams: gameMap.Meta().ParamValues(settings)}
+	if fileMap, ok := gameMap.(*maps.FileMap); ok {
+		definition := fileMap.Definition()
+		exported.MapDefinition = &definition
+	}
+	return exported
+}
+
+// exportedTurn is a line of a game export for a single turn. It's the request that was sent to
+// snakes, along with the private state of the map and rules that snakes never see, so that the
+// game can be picked up again from any turn.
+type exportedTurn struct {
+	client.SnakeRequest
+	State *exportedState `json:"state,omitempty"`
+}
+
+// exportedState is the private GameState and PointState of a board.
+type exportedState struct {
+	GameState  map[string]string    `json:"gameState,omitempty"`
+	PointState []exportedPointState `json:"pointState,omitempty"`
+}
+
+// exportedPointState is a single PointState value. JSON objects can only have string keys, so
+// they're exported as a list instead of a map.
+type exportedPointState struct {
+	X     int `json:"x"`
+	Y     int `json:"y"`
+	Value int `json:"value"`
+}
+
+// newExportedState returns the private state of a board, or nil if there isn't any.
+func newExportedState(boardState *rules.BoardState) *exportedState {
+	if len(boardState.GameState) == 0 && len(boardState.PointState) == 0 {
+		return nil
+	}
+	state := &exportedState{GameState: boardState.GameState}
+	for p, value := range boardState.PointState {
+		state.PointState = append(state.PointState, exportedPointState{X: p.X, Y: p.Y, Value: value})
+	}
+	sort.Slice(state.PointState, func(i, j int) bool {
+		a, b := state.PointState[i], state.PointState[j]
+		if a.Y != b.Y {
+			return a.Y < b.Y
+		}
+		return a.X < b.X
+	})
+	return state
+}
+
+// apply copies the private state onto a board.
+func (state *exportedState) apply(boardState *rules.BoardState) {
+	if state == nil {
+		return
+	}
+	for key, value := range state.GameState {
+		boardState.GameState[key] = value
+	}
+	for _, p := range state.PointState {
+		boardState.PointState[rules.Point{X: p.X, Y: p.Y}] = p.Value
+	}
 }
 
 type result struct {
@@ -44,8 +120,8 @@ func (ge *GameExporter) ConvertToJSON() ([]string, error) {
 		return output, err
 	}
 	output = append(output, string(serialisedGame))
-	for _, board := range ge.snakeRequests {
-		serialisedBoard, err := json.Marshal(board)
+	for _, turn := range ge.turns {
+		serialisedBoard, err := json.Marshal(turn)
 		if err != nil {
 			return output, err
 		}
@@ -64,5 +140,10 @@ func (ge *GameExporter) ConvertToJSON() ([]string, error) {
 }
 
 func (ge *GameExporter) AddSnakeRequest(snakeRequest client.SnakeRequest) {
-	ge.snakeRequests = append(ge.snakeRequests, snakeRequest)
+	ge.turns = append(ge.turns, exportedTurn{SnakeRequest: snakeRequest})
+}
+
+// AddTurn adds the request sent to snakes for a turn, along with the board's private state.
+func (ge *GameExporter) AddTurn(snakeRequest client.SnakeRequest, boardState *rules.BoardState) {
+	ge.turns = append(ge.turns, exportedTurn{SnakeRequest: snakeRequest, State: newExportedState(boardState)})
 }
@@ -6,7 +6,6 @@ import (
 	"fmt"
 	"io"
 	"io/ioutil"
-	"math/rand"
 	"net/http"
 	"net/url"
 	"os"
@@ -20,6 +19,7 @@ import (
 	"github.com/BattlesnakeOfficial/rules/board"
 	"github.com/BattlesnakeOfficial/rules/client"
 	"github.com/BattlesnakeOfficial/rules/maps"
+	"github.com/BattlesnakeOfficial/rules/replay"
 	"github.com/google/uuid"
 	"github.com/pkg/browser"
 	"github.com/spf13/cobra"
@@ -54,6 +54,8 @@ type GameState struct {
 	Sequential          bool
 	GameType            string
 	MapName             string
+	MapFile             string
+	MapParams           []string
 	ViewMap             bool
 	UseColor            bool
 	Seed                int64
@@ -64,7 +66,14 @@ type GameState struct {
 	FoodSpawnChance     int
 	MinimumFood         int
 	HazardDamagePerTurn int
+	FoodLifetime        int
+	FoodNutrition       int
+	FoodSpawner         string
+	FoodWaveEveryNTurns int
+	FoodWaveSize        int
 	ShrinkEveryNTurns   int
+	TurnLimit           int
+	TieBreakers         string
 
 	// Internal game state
 	settings    map[string]string
@@ -101,7 +110,9 @@ func NewPlayCommand() *cobra.Command {
 	playCmd.Flags().IntVarP(&gameState.Timeout, "timeout", "t", 500, "Request Timeout")
 	playCmd.Flags().BoolVarP(&gameState.Sequential, "sequential", "s", false, "Use Sequential Processing")
 	playCmd.Flags().StringVarP(&gameState.GameType, "gametype", "g", "standard", "Type of Game Rules")
-	playCmd.Flags().StringVarP(&gameState.MapName, "map", "m", "standard", "Game map to use to populate the board")
+	playCmd.Flags().StringVarP(&gameState.MapName, "map", "m", "standard", "Game map to use to populate the board. Join map IDs with + to stack them, e.g. hz_rings+royale")
+	playCmd.Flags().StringVar(&gameState.MapFile, "map-file", "", "JSON, YAML or ASCII art (.txt) file describing a custom game map to use instead of --map")
+	playCmd.Flags().StringArrayVar(&gameState.MapParams, "map-param", nil, "Map parameter as key=value, see \"map info\" for the parameters a map accepts")
 	playCmd.Flags().BoolVarP(&gameState.ViewMap, "viewmap", "v", false, "View the Map Each Turn")
 	playCmd.Flags().BoolVarP(&gameState.UseColor, "color", "c", false, "Use color to draw the map")
 	playCmd.Flags().Int64VarP(&gameState.Seed, "seed", "r", time.Now().UTC().UnixNano(), "Random Seed")
@@ -114,7 +125,14 @@ func NewPlayCommand() *cobra.Command {
 	playCmd.Flags().IntVar(&gameState.FoodSpawnChance, "foodSpawnChance", 15, "Percentage chance of spawning a new food every round")
 	playCmd.Flags().IntVar(&gameState.MinimumFood, "minimumFood", 1, "Minimum food to keep on the board every turn")
 	playCmd.Flags().IntVar(&gameState.HazardDamagePerTurn, "hazardDamagePerTurn", 14, "Health damage a snake will take when ending its turn in a hazard")
+	playCmd.Flags().IntVar(&gameState.FoodLifetime, "foodLifetime", 0, "Number of turns new food stays on the board before expiring (0 for food that never expires)")
+	playCmd.Flags().IntVar(&gameState.FoodNutrition, "foodNutrition", 0, "Health restored by new food, growing one extra segment per 100 (0 for a full meal, negative for poison)")
+	playCmd.Flags().StringVar(&gameState.FoodSpawner, "foodSpawner", "", fmt.Sprintf("How new food is placed on the board, uniform when not set (%s)", strings.Join(rules.FoodSpawnerNames(), ", ")))
+	playCmd.Flags().IntVar(&gameState.FoodWaveEveryNTurns, "foodWaveEveryNTurns", 20, "With the waves food spawner, the number of turns between waves of food")
+	playCmd.Flags().IntVar(&gameState.FoodWaveSize, "foodWaveSize", 0, "With the waves food spawner, the amount of food in each wave (0 for one per snake)")
 	playCmd.Flags().IntVar(&gameState.ShrinkEveryNTurns, "shrinkEveryNTurns", 25, "In Royale mode, the number of turns between generating new hazards")
+	playCmd.Flags().IntVar(&gameState.TurnLimit, "turnLimit", 0, "End the game after this many turns (0 for no limit)")
+	playCmd.Flags().StringVar(&gameState.TieBreakers, "tieBreakers", "", "Comma-separated tie-breakers used to rank tied snakes (length, health)")
 
 	playCmd.Flags().SortFlags = false
 
@@ -137,11 +155,20 @@ func (gameState *GameState) Initialize() error {
 	}
 
 	// Load game map
-	gameMap, err := maps.GetMap(gameState.MapName)
-	if err != nil {
-		return fmt.Errorf("Failed to load game map %#v: %v", gameState.MapName, err)
+	if gameState.MapFile != "" {
+		fileMap, err := maps.LoadMapFile(gameState.MapFile)
+		if err != nil {
+			return fmt.Errorf("Failed to load game map file: %w", err)
+		}
+		gameState.gameMap = fileMap
+		gameState.MapName = fileMap.ID()
+	} else {
+		gameMap, err := maps.GetMap(gameState.MapName)
+		if err != nil {
+			return fmt.Errorf("Failed to load game map %#v: %v", gameState.MapName, err)
+		}
+		gameState.gameMap = gameMap
 	}
-	gameState.gameMap = gameMap
 
 	// Create settings object
 	gameState.settings = map[string]string{
@@ -149,6 +176,20 @@ func (gameState *GameState) Initialize() error {
 		rules.ParamMinimumFood:         fmt.Sprint(gameState.MinimumFood),
 		rules.ParamHazardDamagePerTurn: fmt.Sprint(gameState.HazardDamagePerTurn),
 		rules.ParamShrinkEveryNTurns:   fmt.Sprint(gameState.ShrinkEveryNTurns),
+		rules.ParamFoodLifetime:        fmt.Sprint(gameState.FoodLifetime),
+		rules.ParamFoodNutrition:       fmt.Sprint(gameState.FoodNutrition),
+		rules.ParamFoodSpawner:         gameState.FoodSpawner,
+		rules.ParamFoodWaveEveryNTurns: fmt.Sprint(gameState.FoodWaveEveryNTurns),
+		rules.ParamFoodWaveSize:        fmt.Sprint(gameState.FoodWaveSize),
+		rules.ParamTurnLimit:           fmt.Sprint(gameState.TurnLimit),
+		rules.ParamTieBreakers:         gameState.TieBreakers,
+	}
+	mapParams, err := mapParamSettings(gameState.gameMap, gameState.MapParams)
+	if err != nil {
+		return err
+	}
+	for key, value := range mapParams {
+		gameState.settings[key] = value
 	}
 
 	// Build ruleset from settings
@@ -158,6 +199,15 @@ func (gameState *GameState) Initialize() error {
 		WithSolo(len(gameState.URLs) < 2).
 		NamedRuleset(gameState.GameType)
 	gameState.ruleset = ruleset
+	// A seed of 0 is replaced with a random one, which is needed to reproduce the game
+	gameState.Seed = ruleset.Settings().Seed()
+
+	if _, err := rules.TieBreakers(ruleset.Settings()); err != nil {
+		return fmt.Errorf("Invalid tie-breakers: %w", err)
+	}
+	if _, err := rules.GetFoodSpawner(ruleset.Settings()); err != nil {
+		return fmt.Errorf("Invalid food spawner: %w", err)
+	}
 
 	// Initialize snake states as empty until we can ping the snake URLs
 	gameState.snakeStates = map[string]SnakeState{}
@@ -184,18 +234,15 @@ func (gameState *GameState) Run() error {
 		return fmt.Errorf("Error getting snake metadata: %w", err)
 	}
 
-	rand.Seed(gameState.Seed)
-
 	gameOver, boardState, err := gameState.initializeBoardFromArgs()
 	if err != nil {
 		return fmt.Errorf("Error initializing board: %w", err)
 	}
 
 	gameExporter := GameExporter{
-		game:          gameState.createClientGame(),
-		snakeRequests: make([]client.SnakeRequest, 0),
-		winner:        SnakeState{},
-		isDraw:        false,
+		game:   newExportedGame(gameState.createClientGame(), gameState.gameMap, gameState.ruleset.Settings()),
+		winner: SnakeState{},
+		isDraw: false,
 	}
 	exportGame := gameState.outputFile != nil
 	if exportGame {
@@ -254,7 +301,7 @@ func (gameState *GameState) Run() error {
 		// be adjusted to look like an API call for a specific snake in the game.
 		for _, snakeState := range gameState.snakeStates {
 			snakeRequest := gameState.getRequestBodyForSnake(boardState, snakeState)
-			gameExporter.AddSnakeRequest(snakeRequest)
+			gameExporter.AddTurn(snakeRequest, boardState)
 			break
 		}
 	}
@@ -296,27 +343,20 @@ func (gameState *GameState) Run() error {
 		if exportGame {
 			for _, snakeState := range gameState.snakeStates {
 				snakeRequest := gameState.getRequestBodyForSnake(boardState, snakeState)
-				gameExporter.AddSnakeRequest(snakeRequest)
+				gameExporter.AddTurn(snakeRequest, boardState)
 				break
 			}
 		}
 	}
 
-	gameExporter.isDraw = false
-
-	if len(gameState.snakeStates) > 1 {
-		// A draw is possible if there is more than one snake in the game.
-		gameExporter.isDraw = true
+	standings, err := gameState.rankSnakes(boardState)
+	if err != nil {
+		return fmt.Errorf("Error ranking snakes: %w", err)
 	}
+	gameExporter.winner, gameExporter.isDraw = gameState.determineResult(boardState, standings)
 
 	for _, snake := range boardState.Snakes {
-		snakeState := gameState.snakeStates[snake.ID]
-		if snake.EliminatedCause == rules.NotEliminated {
-			gameExporter.isDraw = false
-			gameExporter.winner = snakeState
-		}
-
-		gameState.sendEndRequest(boardState, snakeState)
+		gameState.sendEndRequest(boardState, gameState.snakeStates[snake.ID])
 	}
 
 	if gameExporter.isDraw {
@@ -345,19 +385,58 @@ func (gameState *GameState) Run() error {
 	return nil
 }
 
+// rankSnakes computes and logs the final standings of a finished game.
+func (gameState *GameState) rankSnakes(boardState *rules.BoardState) ([]rules.Placement, error) {
+	tieBreakers, err := rules.TieBreakers(gameState.ruleset.Settings())
+	if err != nil {
+		return nil, err
+	}
+	standings, err := rules.Standings(boardState, tieBreakers)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(standings) > 1 {
+		log.INFO.Printf("Final standings:")
+		for _, placement := range standings {
+			log.INFO.Printf("  %d. %s", placement.Rank, gameState.snakeStates[placement.SnakeID].Name)
+		}
+	}
+	return standings, nil
+}
+
+// determineResult decides the winner of a finished game from the final standings.
+// In a solo game the snake only wins if it survived, otherwise the game is a draw
+// unless a single snake ranks first.
+func (gameState *GameState) determineResult(boardState *rules.BoardState, standings []rules.Placement) (SnakeState, bool) {
+	if len(standings) == 0 {
+		return SnakeState{}, false
+	}
+	if len(standings) == 1 {
+		for _, snake := range boardState.Snakes {
+			if snake.ID == standings[0].SnakeID && snake.EliminatedCause == rules.NotEliminated {
+				return gameState.snakeStates[snake.ID], false
+			}
+		}
+		return SnakeState{}, false
+	}
+	if standings[1].Rank == standings[0].Rank {
+		return SnakeState{}, true
+	}
+	return gameState.snakeStates[standings[0].SnakeID], false
+}
+
 func (gameState *GameState) initializeBoardFromArgs() (bool, *rules.BoardState, error) {
 	snakeIds := []string{}
 	for _, snakeState := range gameState.snakeStates {
 		snakeIds = append(snakeIds, snakeState.ID)
 	}
-	boardState, err := maps.SetupBoard(gameState.gameMap.ID(), gameState.ruleset.Settings(), gameState.Width, gameState.Height, snakeIds)
-	if err != nil {
-		return false, nil, fmt.Errorf("Error initializing BoardState with map: %w", err)
-	}
-	gameOver, boardState, err := gameState.ruleset.Execute(boardState, nil)
+	// Set up the board the same way the replay verifier does, so that exported games can be verified
+	gameOver, boardState, err := replay.Setup(gameState.ruleset, gameState.gameMap, gameState.Width, gameState.Height, snakeIds)
 	if err != nil {
-		return false, nil, fmt.Errorf("Error initializing BoardState with ruleset: %w", err)
+		return false, nil, err
 	}
+	log.DEBUG.Printf("Snake placement fairness: %v", rules.MeasurePlacementFairness(boardState))
 
 	for _, snakeState := range gameState.snakeStates {
 		snakeRequest := gameState.getRequestBodyForSnake(boardState, snakeState)
@@ -374,13 +453,13 @@ func (gameState *GameState) initializeBoardFromArgs() (bool, *rules.BoardState,
 }
 
 func (gameState *GameState) createNextBoardState(boardState *rules.BoardState) (bool, *rules.BoardState, error) {
-	// apply PreUpdateBoard before making requests to snakes
-	boardState, err := maps.PreUpdateBoard(gameState.gameMap, boardState, gameState.ruleset.Settings())
-	if err != nil {
-		return false, boardState, fmt.Errorf("Error pre-updating board with game map: %w", err)
-	}
+	// Advance the game the same way the replay verifier does, asking snakes for their moves once
+	// the map's PreUpdateBoard hook has run
+	return replay.StepWith(gameState.ruleset, gameState.gameMap, boardState, gameState.getMoves)
+}
 
-	// get moves from snakes
+// getMoves asks every snake still in the game for its move on the board.
+func (gameState *GameState) getMoves(boardState *rules.BoardState) ([]rules.SnakeMove, error) {
 	stateUpdates := make(chan SnakeState, len(gameState.snakeStates))
 	if gameState.Sequential {
 		for _, snakeState := range gameState.snakeStates {
@@ -417,21 +496,7 @@ func (gameState *GameState) createNextBoardState(boardState *rules.BoardState) (
 		gameState.snakeStates[snakeState.ID] = snakeState
 		moves = append(moves, rules.SnakeMove{ID: snakeState.ID, Move: snakeState.LastMove})
 	}
-
-	gameOver, boardState, err := gameState.ruleset.Execute(boardState, moves)
-	if err != nil {
-		return false, boardState, fmt.Errorf("Error updating board state from ruleset: %w", err)
-	}
-
-	// apply PostUpdateBoard after ruleset operates on snake moves
-	boardState, err = maps.PostUpdateBoard(gameState.gameMap, boardState, gameState.ruleset.Settings())
-	if err != nil {
-		return false, boardState, fmt.Errorf("Error post-updating board with game map: %w", err)
-	}
-
-	boardState.Turn += 1
-
-	return gameOver, boardState, nil
+	return moves, nil
 }
 
 func (gameState *GameState) getSnakeUpdate(boardState *rules.BoardState, snakeState SnakeState) SnakeState {
@@ -554,8 +619,28 @@ func (gameState *GameState) createClientGame() client.Game {
 	}
 }
 
+// mapParamSettings checks key=value map parameters against the parameters the map declares and
+// returns them keyed for the game settings.
+func mapParamSettings(gameMap maps.GameMap, params []string) (map[string]string, error) {
+	values := make(map[string]string, len(params))
+	for _, param := range params {
+		key, value, ok := strings.Cut(param, "=")
+		if !ok {
+			return nil, fmt.Errorf("Map parameter %q must be in the form key=value", param)
+		}
+		values[strings.TrimSpace(key)] = strings.TrimSpace(value)
+	}
+	settings, err := gameMap.Meta().ParamSettings(values)
+	if err != nil {
+		return nil, fmt.Errorf("Invalid map parameter: %w", err)
+	}
+	return settings, nil
+}
+
+// bodyChars are the characters used to draw snakes when the map isn't drawn in color.
+var bodyChars = []rune{'■', '⌀', '●', '☻', '◘', '☺', '□', '⍟'}
+
 func (gameState *GameState) buildSnakesFromOptions() (map[string]SnakeState, error) {
-	bodyChars := []rune{'■', '⌀', '●', '☻', '◘', '☺', '□', '⍟'}
 	var numSnakes int
 	snakes := map[string]SnakeState{}
 	numNames := len(gameState.Names)
@@ -594,7 +679,7 @@ func (gameState *GameState) buildSnakesFromOptions() (map[string]SnakeState, err
 		}
 
 		snakeState := SnakeState{
-			Name: snakeName, URL: snakeURL, ID: id, LastMove: "up", Character: bodyChars[i%8],
+			Name: snakeName, URL: snakeURL, ID: id, LastMove: "up", Character: bodyChars[i%len(bodyChars)],
 		}
 		var snakeErr error
 		res, _, err := gameState.httpClient.Get(snakeURL)
@@ -678,6 +763,20 @@ func (gameState *GameState) printMap(boardState *rules.BoardState) {
 	} else {
 		o.WriteString(fmt.Sprintf("Hazards ░: %v\n", boardState.Hazards))
 	}
+	for _, wall := range boardState.Walls {
+		if gameState.UseColor {
+			board[wall.X][wall.Y] = TERM_BG_DARKGRAY + " " + TERM_BG_WHITE
+		} else {
+			board[wall.X][wall.Y] = "▓"
+		}
+	}
+	if len(boardState.Walls) > 0 {
+		if gameState.UseColor {
+			o.WriteString(fmt.Sprintf("Walls "+TERM_BG_DARKGRAY+" "+TERM_RESET+": %v\n", boardState.Walls))
+		} else {
+			o.WriteString(fmt.Sprintf("Walls ▓: %v\n", boardState.Walls))
+		}
+	}
 	for _, f := range boardState.Food {
 		if gameState.UseColor {
 			board[f.X][f.Y] = TERM_FG_FOOD + "●"
@@ -778,6 +877,9 @@ func (gameState *GameState) buildFrameEvent(boardState *rules.BoardState) board.
 		Food:    boardState.Food,
 		Hazards: boardState.Hazards,
 	}
+	if len(boardState.Walls) > 0 {
+		gameFrame.Walls = boardState.Walls
+	}
 
 	return board.GameEvent{
 		EventType: board.EVENT_TYPE_FRAME,
@@ -824,11 +926,17 @@ func convertRulesSnakes(snakes []rules.Snake, snakeStates map[string]SnakeState)
 }
 
 func convertStateToBoard(boardState *rules.BoardState, snakeStates map[string]SnakeState) client.Board {
+	// Walls are left out of the request entirely on boards without any
+	var walls []client.Coord
+	if len(boardState.Walls) > 0 {
+		walls = client.CoordFromPointArray(boardState.Walls)
+	}
 	return client.Board{
 		Height:  boardState.Height,
 		Width:   boardState.Width,
-		Food:    client.CoordFromPointArray(boardState.Food),
-		Hazards: client.CoordFromPointArray(boardState.Hazards),
+		Food:    client.FoodFromPointArray(boardState.Food),
+		Hazards: client.HazardFromPointArray(boardState.Hazards),
+		Walls:   walls,
 		Snakes:  convertRulesSnakes(boardState.Snakes, snakeStates),
 	}
 }
@@ -548,6 +548,75 @@ func TestCreateNextBoardState(t *testing.T) {
 	}
 }
 
+func TestDetermineResult(t *testing.T) {
+	gameState := buildDefaultGameState()
+	gameState.snakeStates = map[string]SnakeState{
+		"one": {ID: "one", Name: "Snake One"},
+		"two": {ID: "two", Name: "Snake Two"},
+	}
+	alive := rules.Snake{ID: "one"}
+	eliminated := rules.Snake{ID: "one", EliminatedCause: rules.EliminatedByCollision}
+
+	winner, isDraw := gameState.determineResult(&rules.BoardState{Snakes: []rules.Snake{alive}}, []rules.Placement{{SnakeID: "one", Rank: 1}})
+	require.Equal(t, "one", winner.ID)
+	require.False(t, isDraw)
+
+	winner, isDraw = gameState.determineResult(&rules.BoardState{Snakes: []rules.Snake{eliminated}}, []rules.Placement{{SnakeID: "one", Rank: 1}})
+	require.Empty(t, winner.ID)
+	require.False(t, isDraw)
+
+	winner, isDraw = gameState.determineResult(nil, []rules.Placement{{SnakeID: "two", Rank: 1}, {SnakeID: "one", Rank: 2}})
+	require.Equal(t, "two", winner.ID)
+	require.False(t, isDraw)
+
+	winner, isDraw = gameState.determineResult(nil, []rules.Placement{{SnakeID: "one", Rank: 1}, {SnakeID: "two", Rank: 1}})
+	require.Empty(t, winner.ID)
+	require.True(t, isDraw)
+}
+
+func TestInitializeWithMapFile(t *testing.T) {
+	gameState := buildDefaultGameState()
+	gameState.Width, gameState.Height = 7, 7
+	gameState.MapFile = "../../maps/testdata/arena.yaml"
+	gameState.Names = []string{"one", "two"}
+	gameState.URLs = []string{"http://one.example.com", "http://two.example.com"}
+	require.NoError(t, gameState.Initialize())
+	require.Equal(t, "arena", gameState.MapName)
+	gameState.httpClient = stubHTTPClient{nil, http.StatusOK, func(url string) string { return "{}" }, time.Millisecond}
+
+	snakeStates, err := gameState.buildSnakesFromOptions()
+	require.NoError(t, err)
+	gameState.snakeStates = snakeStates
+	_, boardState, err := gameState.initializeBoardFromArgs()
+	require.NoError(t, err)
+	require.Equal(t, []rules.Point{{X: 0, Y: 3}, {X: 6, Y: 3}}, boardState.Food)
+	require.Equal(t, []rules.Point{{X: 3, Y: 3}}, boardState.Hazards)
+	for _, snake := range boardState.Snakes {
+		require.Contains(t, []rules.Point{{X: 1, Y: 1}, {X: 5, Y: 1}, {X: 1, Y: 5}, {X: 5, Y: 5}}, snake.Body[0])
+	}
+
+	gameState.MapFile = "../../maps/testdata/missing.yaml"
+	require.Error(t, gameState.Initialize())
+}
+
+func TestInitializeWithMapParams(t *testing.T) {
+	gameState := buildDefaultGameState()
+	gameState.MapName = "sinkholes"
+	gameState.MapParams = []string{"maxRings=2", "spawnEveryNTurns = 4"}
+	require.NoError(t, gameState.Initialize())
+	require.Equal(t, 2, gameState.ruleset.Settings().Int("map.maxRings", 0))
+	require.Equal(t, 4, gameState.ruleset.Settings().Int("map.spawnEveryNTurns", 0))
+
+	gameState.MapParams = []string{"maxRings"}
+	require.EqualError(t, gameState.Initialize(), `Map parameter "maxRings" must be in the form key=value`)
+
+	gameState.MapParams = []string{"rings=2"}
+	require.EqualError(t, gameState.Initialize(), `Invalid map parameter: map "Sinkholes" has no parameter "rings"`)
+
+	gameState.MapParams = []string{"maxRings=lots"}
+	require.EqualError(t, gameState.Initialize(), `Invalid map parameter: parameter "maxRings" must be an int, got "lots"`)
+}
+
 func TestOutputFile(t *testing.T) {
 	gameState := buildDefaultGameState()
 	gameState.Names = []string{"example snake"}
@@ -586,7 +655,7 @@ func TestOutputFile(t *testing.T) {
 			rules.ParamMinimumFood:         "2",
 			rules.ParamHazardDamagePerTurn: "3",
 			rules.ParamShrinkEveryNTurns:   "4",
-		}),
+		}).WithSeed(1),
 	}
 
 	err = gameState.Run()
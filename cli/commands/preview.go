@@ -0,0 +1,123 @@
+package commands
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+	log "github.com/spf13/jwalterweatherman"
+
+	"github.com/BattlesnakeOfficial/rules"
+	"github.com/BattlesnakeOfficial/rules/maps"
+)
+
+type mapPreview struct {
+	Width    int
+	Height   int
+	Snakes   int
+	Turns    int
+	Every    int
+	Seed     int64
+	UseColor bool
+
+	FoodSpawnChance   int
+	MinimumFood       int
+	ShrinkEveryNTurns int
+	MapParams         []string
+}
+
+func NewMapPreviewCommand() *cobra.Command {
+	preview := mapPreview{}
+	var previewCmd = &cobra.Command{
+		Use:   "preview [flags] map_name",
+		Short: "Draw how a map changes over a number of turns",
+		Long: `Set up a board with a map and draw it, then run the map's updates for a number of turns and
+draw the board after each one. The snakes never move, so the preview shows how the map places food
+and hazards on its own.`,
+		Args: cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			gameMap, err := maps.GetMap(args[0])
+			if err != nil {
+				log.ERROR.Fatalf("Failed to load game map %v: %v", args[0], err)
+			}
+			if err := preview.Simulate(gameMap, preview.render); err != nil {
+				log.ERROR.Fatalf("Error previewing map: %v", err)
+			}
+		},
+	}
+
+	previewCmd.Flags().IntVarP(&preview.Width, "width", "W", 11, "Width of Board")
+	previewCmd.Flags().IntVarP(&preview.Height, "height", "H", 11, "Height of Board")
+	previewCmd.Flags().IntVarP(&preview.Snakes, "snakes", "n", 2, "Number of snakes to place on the board")
+	previewCmd.Flags().IntVarP(&preview.Turns, "turns", "t", 20, "Number of turns to run")
+	previewCmd.Flags().IntVarP(&preview.Every, "every", "e", 1, "Only draw the board every this many turns")
+	previewCmd.Flags().Int64VarP(&preview.Seed, "seed", "r", time.Now().UTC().UnixNano(), "Random Seed")
+	previewCmd.Flags().BoolVarP(&preview.UseColor, "color", "c", false, "Use color to draw the map")
+	previewCmd.Flags().IntVar(&preview.FoodSpawnChance, "foodSpawnChance", 15, "Percentage chance of spawning a new food every round")
+	previewCmd.Flags().IntVar(&preview.MinimumFood, "minimumFood", 1, "Minimum food to keep on the board every turn")
+	previewCmd.Flags().IntVar(&preview.ShrinkEveryNTurns, "shrinkEveryNTurns", 25, "The number of turns between generating new hazards, for maps that use it")
+	previewCmd.Flags().StringArrayVar(&preview.MapParams, "map-param", nil, "Map parameter as key=value, see \"map info\" for the parameters a map accepts")
+
+	previewCmd.Flags().SortFlags = false
+
+	return previewCmd
+}
+
+// Simulate sets up a board with the map, then runs the map's updates with snakes that never move.
+// The board is passed to render after setup, and then every Every turns and on the last turn.
+func (preview *mapPreview) Simulate(gameMap maps.GameMap, render func(*rules.BoardState)) error {
+	params, err := mapParamSettings(gameMap, preview.MapParams)
+	if err != nil {
+		return err
+	}
+	params[rules.ParamFoodSpawnChance] = fmt.Sprint(preview.FoodSpawnChance)
+	params[rules.ParamMinimumFood] = fmt.Sprint(preview.MinimumFood)
+	params[rules.ParamShrinkEveryNTurns] = fmt.Sprint(preview.ShrinkEveryNTurns)
+	settings := rules.NewSettings(params).WithSeed(preview.Seed)
+
+	boardState := rules.NewBoardState(preview.Width, preview.Height)
+	rules.InitializeSnakes(boardState, preview.snakeIDs())
+	if err := gameMap.SetupBoard(boardState, settings, maps.NewBoardStateEditor(boardState)); err != nil {
+		return fmt.Errorf("Error initializing BoardState with map: %w", err)
+	}
+	render(boardState)
+
+	for boardState.Turn < preview.Turns {
+		nextBoardState, err := maps.PreUpdateBoard(gameMap, boardState, settings)
+		if err != nil {
+			return fmt.Errorf("Error applying map to turn %d: %w", boardState.Turn, err)
+		}
+		nextBoardState, err = maps.PostUpdateBoard(gameMap, nextBoardState, settings)
+		if err != nil {
+			return fmt.Errorf("Error applying map to turn %d: %w", boardState.Turn, err)
+		}
+		nextBoardState.Turn++
+		boardState = nextBoardState
+
+		if preview.Every <= 1 || boardState.Turn%preview.Every == 0 || boardState.Turn == preview.Turns {
+			render(boardState)
+		}
+	}
+	return nil
+}
+
+func (preview *mapPreview) snakeIDs() []string {
+	snakeIDs := make([]string, preview.Snakes)
+	for i := range snakeIDs {
+		snakeIDs[i] = fmt.Sprintf("snake_%d", i+1)
+	}
+	return snakeIDs
+}
+
+// render draws the board the same way as "play --viewmap".
+func (preview *mapPreview) render(boardState *rules.BoardState) {
+	gameState := &GameState{UseColor: preview.UseColor, snakeStates: map[string]SnakeState{}}
+	for i, id := range preview.snakeIDs() {
+		gameState.snakeStates[id] = SnakeState{
+			ID:        id,
+			Name:      fmt.Sprintf("Snake %d", i+1),
+			Character: bodyChars[i%len(bodyChars)],
+		}
+	}
+	gameState.printMap(boardState)
+}
@@ -0,0 +1,50 @@
+package commands
+
+import (
+	"testing"
+
+	"github.com/BattlesnakeOfficial/rules"
+	"github.com/BattlesnakeOfficial/rules/maps"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMapPreviewSimulate(t *testing.T) {
+	preview := mapPreview{Width: 11, Height: 11, Snakes: 2, Turns: 10, Every: 1, Seed: 1, MinimumFood: 1, ShrinkEveryNTurns: 1}
+	gameMap, err := maps.GetMap("hz_expand_box")
+	require.NoError(t, err)
+
+	var boards []*rules.BoardState
+	err = preview.Simulate(gameMap, func(boardState *rules.BoardState) {
+		boards = append(boards, boardState)
+	})
+	require.NoError(t, err)
+	require.Len(t, boards, 11)
+
+	start := boards[0]
+	for turn, boardState := range boards {
+		require.Equal(t, turn, boardState.Turn)
+		// snakes never move
+		require.Equal(t, start.Snakes, boardState.Snakes)
+	}
+	require.Greater(t, len(boards[10].Hazards), len(boards[0].Hazards), "hazards should expand")
+}
+
+func TestMapPreviewEvery(t *testing.T) {
+	preview := mapPreview{Width: 7, Height: 7, Snakes: 1, Turns: 10, Every: 4, Seed: 1}
+
+	var turns []int
+	err := preview.Simulate(maps.StandardMap{}, func(boardState *rules.BoardState) {
+		turns = append(turns, boardState.Turn)
+	})
+	require.NoError(t, err)
+	require.Equal(t, []int{0, 4, 8, 10}, turns)
+}
+
+func TestMapPreviewSetupError(t *testing.T) {
+	preview := mapPreview{Width: 7, Height: 7, Snakes: 1, Turns: 10}
+	gameMap, err := maps.GetMap("arcade_maze")
+	require.NoError(t, err)
+
+	err = preview.Simulate(gameMap, func(*rules.BoardState) {})
+	require.Error(t, err)
+}
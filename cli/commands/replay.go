@@ -0,0 +1,175 @@
+package commands
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/BattlesnakeOfficial/rules"
+	"github.com/BattlesnakeOfficial/rules/client"
+)
+
+// gameRecord is a game read back from the JSONL output written by GameExporter.
+type gameRecord struct {
+	Game  exportedGame
+	Turns []client.SnakeRequest
+	// States holds the private state of the board for each turn, or nil for turns without any.
+	States []*exportedState
+	Result result
+}
+
+// Maximum size of a single line in a game export. Large boards with many snakes
+// produce long lines, so this is well above bufio's default.
+const maxExportLineSize = 16 * 1024 * 1024
+
+func loadGameRecord(path string) (*gameRecord, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	record, err := readGameRecord(f)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return record, nil
+}
+
+// readGameRecord parses a game export: one line describing the game, one line per turn,
+// and a final line containing the result of the game.
+func readGameRecord(r io.Reader) (*gameRecord, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxExportLineSize)
+
+	record := &gameRecord{}
+	lineNumber := 0
+	hasResult := false
+	for scanner.Scan() {
+		line := scanner.Bytes()
+		if len(line) == 0 {
+			continue
+		}
+		lineNumber++
+
+		if hasResult {
+			return nil, fmt.Errorf("line %d: unexpected data after game result", lineNumber)
+		}
+
+		if lineNumber == 1 {
+			if err := json.Unmarshal(line, &record.Game); err != nil {
+				return nil, fmt.Errorf("line %d: invalid game: %w", lineNumber, err)
+			}
+			continue
+		}
+
+		var probe struct {
+			Board json.RawMessage `json:"board"`
+		}
+		if err := json.Unmarshal(line, &probe); err != nil {
+			return nil, fmt.Errorf("line %d: %w", lineNumber, err)
+		}
+		if probe.Board == nil {
+			if err := json.Unmarshal(line, &record.Result); err != nil {
+				return nil, fmt.Errorf("line %d: invalid result: %w", lineNumber, err)
+			}
+			hasResult = true
+			continue
+		}
+
+		var turn client.SnakeRequest
+		if err := json.Unmarshal(line, &turn); err != nil {
+			return nil, fmt.Errorf("line %d: invalid turn: %w", lineNumber, err)
+		}
+		var state struct {
+			State *exportedState `json:"state"`
+		}
+		if err := json.Unmarshal(line, &state); err != nil {
+			return nil, fmt.Errorf("line %d: invalid state: %w", lineNumber, err)
+		}
+		record.Turns = append(record.Turns, turn)
+		record.States = append(record.States, state.State)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	if lineNumber == 0 {
+		return nil, fmt.Errorf("game export is empty")
+	}
+	if len(record.Turns) == 0 {
+		return nil, fmt.Errorf("game export has no turns")
+	}
+
+	return record, nil
+}
+
+// BoardState returns the board state recorded for the given index into Turns, including its
+// private state. Eliminated snakes are not included in game exports, so they are missing from
+// the result.
+func (record *gameRecord) BoardState(index int) *rules.BoardState {
+	boardState := boardStateFromRequest(record.Turns[index])
+	record.States[index].apply(boardState)
+	return boardState
+}
+
+// Moves returns the moves made between the turn at index and the following turn,
+// keyed by snake ID. Snakes that were eliminated by those moves are not included,
+// because they no longer appear in the following turn.
+func (record *gameRecord) Moves(index int) map[string]string {
+	moves := map[string]string{}
+	if index+1 >= len(record.Turns) {
+		return moves
+	}
+
+	board, next := record.Turns[index].Board, record.Turns[index+1].Board
+	for _, snake := range board.Snakes {
+		for _, nextSnake := range next.Snakes {
+			if snake.ID != nextSnake.ID {
+				continue
+			}
+			move := inferMove(client.PointFromCoord(snake.Head), client.PointFromCoord(nextSnake.Head), board.Width, board.Height)
+			if move != "" {
+				moves[snake.ID] = move
+			}
+		}
+	}
+	return moves
+}
+
+func boardStateFromRequest(request client.SnakeRequest) *rules.BoardState {
+	snakes := make([]rules.Snake, 0, len(request.Board.Snakes))
+	for _, snake := range request.Board.Snakes {
+		snakes = append(snakes, rules.Snake{
+			ID:     snake.ID,
+			Body:   client.PointFromCoordArray(snake.Body),
+			Health: snake.Health,
+		})
+	}
+	return rules.NewBoardState(request.Board.Width, request.Board.Height).
+		WithTurn(request.Turn).
+		WithFood(client.PointFromFoodArray(request.Board.Food)).
+		WithHazards(client.PointFromHazardArray(request.Board.Hazards)).
+		WithWalls(client.PointFromCoordArray(request.Board.Walls)).
+		WithSnakes(snakes)
+}
+
+// inferMove determines which move takes a snake's head from one point to the next,
+// taking into account moves that wrap around the edges of the board.
+// An empty string is returned if the points are not adjacent.
+func inferMove(from, to rules.Point, width, height int) string {
+	dx, dy := to.X-from.X, to.Y-from.Y
+	switch {
+	case dy == 0 && (dx == 1 || dx == 1-width):
+		return rules.MoveRight
+	case dy == 0 && (dx == -1 || dx == width-1):
+		return rules.MoveLeft
+	case dx == 0 && (dy == 1 || dy == 1-height):
+		return rules.MoveUp
+	case dx == 0 && (dy == -1 || dy == height-1):
+		return rules.MoveDown
+	}
+	return ""
+}
@@ -0,0 +1,158 @@
+package commands
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/BattlesnakeOfficial/rules"
+	"github.com/BattlesnakeOfficial/rules/client"
+	"github.com/stretchr/testify/require"
+)
+
+// buildGameExport returns the JSONL export of a game that went through the given board states.
+func buildGameExport(t *testing.T, states []*rules.BoardState, winner SnakeState, isDraw bool) *bytes.Buffer {
+	t.Helper()
+
+	snakeStates := map[string]SnakeState{}
+	for _, snake := range states[0].Snakes {
+		snakeStates[snake.ID] = SnakeState{ID: snake.ID, Name: snake.ID}
+	}
+
+	exporter := GameExporter{
+		game:   exportedGame{Game: client.Game{ID: "GAME_ID", Map: "standard", Ruleset: client.Ruleset{Name: rules.GameTypeStandard}}},
+		winner: winner,
+		isDraw: isDraw,
+	}
+	for _, state := range states {
+		exporter.AddSnakeRequest(client.SnakeRequest{
+			Game:  exporter.game.Game,
+			Turn:  state.Turn,
+			Board: convertStateToBoard(state, snakeStates),
+		})
+	}
+
+	var buf bytes.Buffer
+	_, err := exporter.FlushToFile(&buf)
+	require.NoError(t, err)
+	return &buf
+}
+
+func TestReadGameRecord(t *testing.T) {
+	states := []*rules.BoardState{
+		rules.NewBoardState(5, 5).
+			WithFood([]rules.Point{{X: 2, Y: 2}}).
+			WithSnakes([]rules.Snake{
+				{ID: "one", Health: 100, Body: []rules.Point{{X: 1, Y: 1}, {X: 1, Y: 1}, {X: 1, Y: 1}}},
+				{ID: "two", Health: 100, Body: []rules.Point{{X: 3, Y: 3}, {X: 3, Y: 3}, {X: 3, Y: 3}}},
+			}),
+		rules.NewBoardState(5, 5).
+			WithTurn(1).
+			WithHazards([]rules.Point{{X: 0, Y: 0}}).
+			WithSnakes([]rules.Snake{
+				{ID: "one", Health: 99, Body: []rules.Point{{X: 1, Y: 2}, {X: 1, Y: 1}, {X: 1, Y: 1}}},
+				{ID: "two", Health: 99, Body: []rules.Point{{X: 3, Y: 2}, {X: 3, Y: 3}, {X: 3, Y: 3}}},
+			}),
+		rules.NewBoardState(5, 5).
+			WithTurn(2).
+			WithSnakes([]rules.Snake{
+				{ID: "one", Health: 98, Body: []rules.Point{{X: 0, Y: 2}, {X: 1, Y: 2}, {X: 1, Y: 1}}},
+				{ID: "two", Health: 0, Body: []rules.Point{{X: 3, Y: 1}, {X: 3, Y: 2}, {X: 3, Y: 3}}, EliminatedCause: rules.EliminatedByOutOfHealth},
+			}),
+	}
+	export := buildGameExport(t, states, SnakeState{ID: "one", Name: "one"}, false)
+
+	record, err := readGameRecord(export)
+	require.NoError(t, err)
+	require.Equal(t, "GAME_ID", record.Game.ID)
+	require.Len(t, record.Turns, 3)
+	require.Equal(t, result{WinnerID: "one", WinnerName: "one"}, record.Result)
+
+	require.Equal(t, states[1], record.BoardState(1))
+	require.Len(t, record.BoardState(2).Snakes, 1, "eliminated snakes should not be recorded")
+
+	require.Equal(t, map[string]string{"one": rules.MoveUp, "two": rules.MoveDown}, record.Moves(0))
+	require.Equal(t, map[string]string{"one": rules.MoveLeft}, record.Moves(1))
+	require.Equal(t, map[string]string{}, record.Moves(2))
+}
+
+func TestGameExportPrivateState(t *testing.T) {
+	state := rules.NewBoardState(5, 5).
+		WithSnakes([]rules.Snake{{ID: "one", Health: 100, Body: []rules.Point{{X: 1, Y: 1}}}}).
+		WithGameState(map[string]string{"solo_maze/level": "secret"}).
+		WithPointState(map[rules.Point]int{{X: 2, Y: 3}: 4, {X: 1, Y: 0}: 5})
+	snakeStates := map[string]SnakeState{"one": {ID: "one", Name: "one"}}
+	request := client.SnakeRequest{Game: client.Game{ID: "GAME_ID"}, Board: convertStateToBoard(state, snakeStates)}
+
+	// Private state is never sent to snakes
+	require.NotContains(t, string(serialiseSnakeRequest(request)), "secret")
+
+	exporter := GameExporter{game: exportedGame{Game: request.Game}}
+	exporter.AddTurn(request, state)
+	exporter.AddTurn(request, rules.NewBoardState(5, 5))
+	lines, err := exporter.ConvertToJSON()
+	require.NoError(t, err)
+	require.Len(t, lines, 4)
+	require.Contains(t, lines[1], `"state":{"gameState":{"solo_maze/level":"secret"},"pointState":[{"x":1,"y":0,"value":5},{"x":2,"y":3,"value":4}]}`)
+	require.NotContains(t, lines[2], `"state"`)
+
+	var buf bytes.Buffer
+	_, err = exporter.FlushToFile(&buf)
+	require.NoError(t, err)
+	record, err := readGameRecord(&buf)
+	require.NoError(t, err)
+	require.Equal(t, state.GameState, record.BoardState(0).GameState)
+	require.Equal(t, state.PointState, record.BoardState(0).PointState)
+	require.Empty(t, record.BoardState(1).GameState)
+	require.Equal(t, request, record.Turns[0])
+}
+
+func TestBoardStateFromRequestWalls(t *testing.T) {
+	state := rules.NewBoardState(5, 5).
+		WithSnakes([]rules.Snake{{ID: "one", Health: 100, Body: []rules.Point{{X: 1, Y: 1}}}}).
+		WithWalls([]rules.Point{{X: 2, Y: 2}, {X: 2, Y: 3}})
+	snakeStates := map[string]SnakeState{"one": {ID: "one", Name: "one"}}
+	request := client.SnakeRequest{Board: convertStateToBoard(state, snakeStates)}
+	require.Contains(t, string(serialiseSnakeRequest(request)), `"walls":[{"x":2,"y":2},{"x":2,"y":3}]`)
+	require.Equal(t, state.Walls, boardStateFromRequest(request).Walls)
+}
+
+func TestReadGameRecordErrors(t *testing.T) {
+	for _, test := range []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{"empty", "", "game export is empty"},
+		{"no turns", `{"id": "GAME_ID"}` + "\n" + `{"winnerId": ""}`, "game export has no turns"},
+		{"invalid game", "[]", "line 1: invalid game: json: cannot unmarshal array into Go value of type commands.exportedGame"},
+		{"invalid turn", `{"id": "GAME_ID"}` + "\n" + `{"board": []}`, "line 2: invalid turn: json: cannot unmarshal array into Go struct field SnakeRequest.board of type client.Board"},
+		{"data after result", `{"id": "GAME_ID"}` + "\n" + `{"winnerId": ""}` + "\n" + `{"board": {}}`, "line 3: unexpected data after game result"},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := readGameRecord(bytes.NewBufferString(test.input))
+			require.EqualError(t, err, test.expected)
+		})
+	}
+}
+
+func TestInferMove(t *testing.T) {
+	for _, test := range []struct {
+		from     rules.Point
+		to       rules.Point
+		expected string
+	}{
+		{rules.Point{X: 5, Y: 5}, rules.Point{X: 5, Y: 6}, rules.MoveUp},
+		{rules.Point{X: 5, Y: 5}, rules.Point{X: 5, Y: 4}, rules.MoveDown},
+		{rules.Point{X: 5, Y: 5}, rules.Point{X: 4, Y: 5}, rules.MoveLeft},
+		{rules.Point{X: 5, Y: 5}, rules.Point{X: 6, Y: 5}, rules.MoveRight},
+		{rules.Point{X: 5, Y: 10}, rules.Point{X: 5, Y: 0}, rules.MoveUp},
+		{rules.Point{X: 5, Y: 0}, rules.Point{X: 5, Y: 10}, rules.MoveDown},
+		{rules.Point{X: 0, Y: 5}, rules.Point{X: 10, Y: 5}, rules.MoveLeft},
+		{rules.Point{X: 10, Y: 5}, rules.Point{X: 0, Y: 5}, rules.MoveRight},
+		{rules.Point{X: 5, Y: 5}, rules.Point{X: 5, Y: 5}, ""},
+		{rules.Point{X: 5, Y: 5}, rules.Point{X: 6, Y: 6}, ""},
+		{rules.Point{X: 5, Y: 5}, rules.Point{X: 7, Y: 5}, ""},
+	} {
+		require.Equal(t, test.expected, inferMove(test.from, test.to, 11, 11), "%v -> %v", test.from, test.to)
+	}
+}
@@ -23,10 +23,26 @@ var rootCmd = &cobra.Command{
 
 func Execute() {
 	rootCmd.AddCommand(NewPlayCommand())
+	rootCmd.AddCommand(NewExportDatasetCommand())
+	rootCmd.AddCommand(NewVerifyCommand())
+	rootCmd.AddCommand(NewAnalyzeCommand())
+
+	notationCommand := NewNotationCommand()
+	notationCommand.AddCommand(NewNotationEncodeCommand())
+	notationCommand.AddCommand(NewNotationDecodeCommand())
+	rootCmd.AddCommand(notationCommand)
+
+	conformanceCommand := NewConformanceCommand()
+	conformanceCommand.AddCommand(NewConformanceExportCommand())
+	conformanceCommand.AddCommand(NewConformanceRunCommand())
+	conformanceCommand.AddCommand(NewConformanceServeCommand())
+	rootCmd.AddCommand(conformanceCommand)
 
 	mapCommand := NewMapCommand()
 	mapCommand.AddCommand(NewMapListCommand())
 	mapCommand.AddCommand(NewMapInfoCommand())
+	mapCommand.AddCommand(NewMapLintCommand())
+	mapCommand.AddCommand(NewMapPreviewCommand())
 
 	rootCmd.AddCommand(mapCommand)
 
@@ -0,0 +1,145 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/spf13/cobra"
+	log "github.com/spf13/jwalterweatherman"
+
+	"github.com/BattlesnakeOfficial/rules"
+	"github.com/BattlesnakeOfficial/rules/maps"
+	"github.com/BattlesnakeOfficial/rules/replay"
+)
+
+type gameVerifier struct {
+	Seed int64
+}
+
+func NewVerifyCommand() *cobra.Command {
+	verifier := gameVerifier{}
+	var verifyCmd = &cobra.Command{
+		Use:   "verify [flags] game.jsonl",
+		Short: "Check that an exported game can be reproduced exactly",
+		Long: `Re-simulate a game exported with "play --output" through the ruleset and map, and report the
+first turn where the recomputed board doesn't match the recorded one.
+
+Game exports don't include the random seed, so the seed printed by "play" must be passed with --seed.
+The turn limit, tie-breakers and map parameters are recorded in the export, so they don't need to
+be given again.`,
+		Args: cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			record, err := loadGameRecord(args[0])
+			if err != nil {
+				log.ERROR.Fatalf("Error reading game: %v", err)
+			}
+
+			err = verifier.Verify(record)
+			var divergence *replay.Divergence
+			if errors.As(err, &divergence) {
+				log.ERROR.Fatalf("Game diverged from the recording on %v", divergence)
+			} else if err != nil {
+				log.ERROR.Fatalf("Error verifying game: %v", err)
+			}
+			log.INFO.Printf("Verified %d turns of game %s", len(record.Turns), record.Game.ID)
+		},
+	}
+
+	verifyCmd.Flags().Int64VarP(&verifier.Seed, "seed", "r", 0, "Random seed the game was played with")
+	_ = verifyCmd.MarkFlagRequired("seed")
+
+	return verifyCmd
+}
+
+// Verify re-simulates the recorded game, returning a *replay.Divergence if it can't be reproduced.
+func (verifier *gameVerifier) Verify(record *gameRecord) error {
+	ruleset, gameMap, err := record.rulesetAndMap(verifier.Seed)
+	if err != nil {
+		return err
+	}
+	recorded, moves := record.boardStatesAndMoves()
+	return replay.Verify(ruleset, gameMap, recorded, moves)
+}
+
+// rulesetAndMap builds the ruleset and map the recorded game was played with.
+// The seed isn't included in game exports so it must be provided.
+func (record *gameRecord) rulesetAndMap(seed int64) (rules.Ruleset, maps.GameMap, error) {
+	gameMap, err := record.gameMap()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	params, err := record.rulesetParams(gameMap)
+	if err != nil {
+		return nil, nil, err
+	}
+	ruleset := rules.NewRulesetBuilder().
+		WithSeed(seed).
+		WithParams(params).
+		WithSolo(len(record.Turns[0].Board.Snakes) < 2).
+		NamedRuleset(record.Game.Ruleset.Name)
+	return ruleset, gameMap, nil
+}
+
+// gameMap returns the map the recorded game was played with, built from its definition for games
+// played with a map file.
+func (record *gameRecord) gameMap() (maps.GameMap, error) {
+	if record.Game.MapDefinition != nil {
+		fileMap, err := maps.NewFileMap(*record.Game.MapDefinition)
+		if err != nil {
+			return nil, fmt.Errorf("invalid map definition: %w", err)
+		}
+		return fileMap, nil
+	}
+	mapID := record.Game.Map
+	if mapID == "" {
+		mapID = "standard"
+	}
+	gameMap, err := maps.GetMap(mapID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load game map %#v: %w", mapID, err)
+	}
+	return gameMap, nil
+}
+
+// boardStatesAndMoves returns every recorded board state and the moves made on each turn.
+func (record *gameRecord) boardStatesAndMoves() ([]*rules.BoardState, []map[string]string) {
+	recorded := make([]*rules.BoardState, 0, len(record.Turns))
+	moves := make([]map[string]string, 0, len(record.Turns))
+	for i := range record.Turns {
+		recorded = append(recorded, record.BoardState(i))
+		moves = append(moves, record.Moves(i))
+	}
+	return recorded, moves
+}
+
+// rulesetParams converts the settings exposed through the API and the recorded map parameters
+// back into ruleset parameters.
+func (record *gameRecord) rulesetParams(gameMap maps.GameMap) (map[string]string, error) {
+	settings := record.Game.Ruleset.Settings
+	params := map[string]string{
+		rules.ParamFoodSpawnChance:     fmt.Sprint(settings.FoodSpawnChance),
+		rules.ParamMinimumFood:         fmt.Sprint(settings.MinimumFood),
+		rules.ParamHazardDamagePerTurn: fmt.Sprint(settings.HazardDamagePerTurn),
+		rules.ParamShrinkEveryNTurns:   fmt.Sprint(settings.RoyaleSettings.ShrinkEveryNTurns),
+		rules.ParamFoodLifetime:        fmt.Sprint(settings.FoodLifetime),
+		rules.ParamFoodNutrition:       fmt.Sprint(settings.FoodNutrition),
+		rules.ParamFoodSpawner:         settings.FoodSpawner,
+		rules.ParamFoodWaveEveryNTurns: fmt.Sprint(settings.FoodWaveEveryNTurns),
+		rules.ParamFoodWaveSize:        fmt.Sprint(settings.FoodWaveSize),
+	}
+	if settings.TurnLimit > 0 {
+		params[rules.ParamTurnLimit] = fmt.Sprint(settings.TurnLimit)
+	}
+	if settings.TieBreakers != "" {
+		params[rules.ParamTieBreakers] = settings.TieBreakers
+	}
+	mapParams, err := gameMap.Meta().ParamSettings(record.Game.MapParams)
+	if err != nil {
+		return nil, fmt.Errorf("invalid map parameter: %w", err)
+	}
+	for key, value := range mapParams {
+		params[key] = value
+	}
+	return params, nil
+}
@@ -0,0 +1,136 @@
+package commands
+
+import (
+	"bytes"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/BattlesnakeOfficial/rules"
+	"github.com/BattlesnakeOfficial/rules/client"
+	"github.com/BattlesnakeOfficial/rules/replay"
+	"github.com/stretchr/testify/require"
+)
+
+// playExportedGame plays a game between two stub snakes that always move the same way and returns the export.
+func playExportedGame(t *testing.T, gameType, mapName string, seed int64, mapParams ...string) *gameRecord {
+	t.Helper()
+
+	gameState := buildDefaultGameState()
+	gameState.GameType = gameType
+	gameState.MapName = mapName
+	gameState.MapParams = mapParams
+	gameState.Seed = seed
+	return playGameState(t, gameState)
+}
+
+// playGameState plays a game set up by the caller between two stub snakes and returns the export.
+func playGameState(t *testing.T, gameState *GameState) *gameRecord {
+	t.Helper()
+
+	gameState.Names = []string{"one", "two"}
+	gameState.URLs = []string{"http://one.example.com", "http://two.example.com"}
+	require.NoError(t, gameState.Initialize())
+
+	gameState.httpClient = stubHTTPClient{nil, http.StatusOK, func(url string) string {
+		switch {
+		case strings.HasSuffix(url, "one.example.com/move"):
+			return `{"move": "up"}`
+		case strings.HasSuffix(url, "two.example.com/move"):
+			return `{"move": "left"}`
+		}
+		return "{}"
+	}, time.Millisecond}
+	outputFile := new(closableBuffer)
+	gameState.outputFile = outputFile
+
+	require.NoError(t, gameState.Run())
+
+	record, err := readGameRecord(bytes.NewBufferString(outputFile.String()))
+	require.NoError(t, err)
+	return record
+}
+
+func TestVerifyExportedGame(t *testing.T) {
+	for _, test := range []struct {
+		gameType string
+		mapName  string
+	}{
+		{rules.GameTypeStandard, "standard"},
+		{rules.GameTypeRoyale, "royale"},
+		{rules.GameTypeWrapped, "hz_spiral"},
+		{rules.GameTypeStandard, "healing_pools"},
+	} {
+		t.Run(test.gameType+"/"+test.mapName, func(t *testing.T) {
+			record := playExportedGame(t, test.gameType, test.mapName, 98765)
+
+			verifier := gameVerifier{Seed: 98765}
+			require.NoError(t, verifier.Verify(record))
+
+			verifier = gameVerifier{Seed: 56789}
+			var divergence *replay.Divergence
+			require.True(t, errors.As(verifier.Verify(record), &divergence))
+		})
+	}
+}
+
+func TestVerifyMapParams(t *testing.T) {
+	record := playExportedGame(t, rules.GameTypeStandard, "hz_scatter", 98765, "spawnEveryNTurns=1")
+	require.Equal(t, map[string]string{"spawnEveryNTurns": "1"}, record.Game.MapParams)
+
+	verifier := gameVerifier{Seed: 98765}
+	require.NoError(t, verifier.Verify(record))
+
+	// The recorded parameters are passed on to the map
+	record.Game.MapParams = nil
+	var divergence *replay.Divergence
+	require.True(t, errors.As(verifier.Verify(record), &divergence))
+
+	record.Game.MapParams = map[string]string{"missing": "1"}
+	require.EqualError(t, verifier.Verify(record), `invalid map parameter: map "hz_scatter" has no parameter "missing"`)
+}
+
+func TestVerifyTurnLimit(t *testing.T) {
+	gameState := buildDefaultGameState()
+	gameState.Seed = 98765
+	gameState.TurnLimit = 1
+	gameState.TieBreakers = "length,health"
+	record := playGameState(t, gameState)
+	require.Equal(t, 1, record.Game.Ruleset.Settings.TurnLimit)
+	require.Equal(t, "length,health", record.Game.Ruleset.Settings.TieBreakers)
+
+	verifier := gameVerifier{Seed: 98765}
+	require.NoError(t, verifier.Verify(record))
+
+	// Without the recorded turn limit, the game doesn't end where the recording stops
+	record.Game.Ruleset.Settings.TurnLimit = 0
+	var divergence *replay.Divergence
+	require.True(t, errors.As(verifier.Verify(record), &divergence))
+}
+
+func TestVerifyMapFile(t *testing.T) {
+	gameState := buildDefaultGameState()
+	gameState.Width, gameState.Height = 7, 7
+	gameState.MapFile = "../../maps/testdata/arena.yaml"
+	gameState.Seed = 98765
+	record := playGameState(t, gameState)
+	require.Equal(t, "arena", record.Game.Map)
+	require.NotNil(t, record.Game.MapDefinition)
+	require.Equal(t, "arena", record.Game.MapDefinition.ID)
+
+	// The map is rebuilt from the definition in the export, since it isn't registered
+	verifier := gameVerifier{Seed: 98765}
+	require.NoError(t, verifier.Verify(record))
+
+	record.Game.MapDefinition.Layouts = nil
+	require.EqualError(t, verifier.Verify(record), `invalid map definition: map "arena" has no layouts`)
+}
+
+func TestVerifyUnknownMap(t *testing.T) {
+	record := &gameRecord{Turns: []client.SnakeRequest{{}}}
+	record.Game.Map = "missing"
+	verifier := gameVerifier{}
+	require.EqualError(t, verifier.Verify(record), `failed to load game map "missing": map not found`)
+}
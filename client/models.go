@@ -26,6 +26,10 @@ type Board struct {
 	Snakes  []Snake `json:"snakes"`
 	Food    []Coord `json:"food"`
 	Hazards []Coord `json:"hazards"`
+
+	// Impassable cells that eliminate snakes moving into them. Left out when there are none, so
+	// that snakes playing on boards without walls see the same request as before.
+	Walls []Coord `json:"walls,omitempty"`
 }
 
 // Snake represents information about a snake in the game
@@ -59,6 +63,13 @@ type RulesetSettings struct {
 	FoodSpawnChance     int            `json:"foodSpawnChance"`
 	MinimumFood         int            `json:"minimumFood"`
 	HazardDamagePerTurn int            `json:"hazardDamagePerTurn"`
+	FoodLifetime        int            `json:"foodLifetime,omitempty"`
+	FoodNutrition       int            `json:"foodNutrition,omitempty"`
+	FoodSpawner         string         `json:"foodSpawner,omitempty"`
+	FoodWaveEveryNTurns int            `json:"foodWaveEveryNTurns,omitempty"`
+	FoodWaveSize        int            `json:"foodWaveSize,omitempty"`
+	TurnLimit           int            `json:"turnLimit,omitempty"`
+	TieBreakers         string         `json:"tieBreakers,omitempty"`
 	HazardMap           string         `json:"hazardMap"`       // Deprecated, replaced by Game.Map
 	HazardMapAuthor     string         `json:"hazardMapAuthor"` // Deprecated, no planned replacement
 	RoyaleSettings      RoyaleSettings `json:"royale"`
@@ -80,21 +91,41 @@ type SquadSettings struct {
 
 // Converts a rules.Settings (which can contain arbitrary settings) into the static RulesetSettings used in the client API.
 func ConvertRulesetSettings(settings rules.Settings) RulesetSettings {
-	return RulesetSettings{
+	rulesetSettings := RulesetSettings{
 		FoodSpawnChance:     settings.Int(rules.ParamFoodSpawnChance, 0),
 		MinimumFood:         settings.Int(rules.ParamMinimumFood, 0),
 		HazardDamagePerTurn: settings.Int(rules.ParamHazardDamagePerTurn, 0),
+		FoodLifetime:        settings.Int(rules.ParamFoodLifetime, 0),
+		FoodNutrition:       settings.Int(rules.ParamFoodNutrition, 0),
+		FoodSpawner:         settings.String(rules.ParamFoodSpawner, ""),
+		TurnLimit:           settings.Int(rules.ParamTurnLimit, 0),
+		TieBreakers:         settings.String(rules.ParamTieBreakers, ""),
 		RoyaleSettings: RoyaleSettings{
 			ShrinkEveryNTurns: settings.Int(rules.ParamShrinkEveryNTurns, 0),
 		},
 		SquadSettings: SquadSettings{},
 	}
+	if rulesetSettings.FoodSpawner == rules.FoodSpawnerWaves {
+		rulesetSettings.FoodWaveEveryNTurns = settings.Int(rules.ParamFoodWaveEveryNTurns, 0)
+		rulesetSettings.FoodWaveSize = settings.Int(rules.ParamFoodWaveSize, 0)
+	}
+	return rulesetSettings
 }
 
 // Coord represents a point on the board
 type Coord struct {
 	X int `json:"x"`
 	Y int `json:"y"`
+
+	// Food that expires includes the number of turns left to eat it as TTL, and food that isn't a
+	// standard full meal includes its Nutrition, measured in health. Negative nutrition is poison.
+	TTL       int `json:"ttl,omitempty"`
+	Nutrition int `json:"nutrition,omitempty"`
+
+	// Hazards that deal their own damage each turn, instead of the ruleset's hazardDamagePerTurn,
+	// include it as Damage. Negative damage heals. Stacked hazards appear once for each hazard in
+	// the stack, and their damage adds up.
+	Damage int `json:"damage,omitempty"`
 }
 
 // The expected format of the response body from a /move request
@@ -124,3 +155,63 @@ func CoordFromPointArray(ptArray []rules.Point) []Coord {
 	}
 	return a
 }
+
+func PointFromCoord(c Coord) rules.Point {
+	return rules.Point{X: c.X, Y: c.Y}
+}
+
+func PointFromCoordArray(cArray []Coord) []rules.Point {
+	a := make([]rules.Point, 0)
+	for _, c := range cArray {
+		a = append(a, PointFromCoord(c))
+	}
+	return a
+}
+
+func FoodFromPoint(pt rules.Point) Coord {
+	return Coord{X: pt.X, Y: pt.Y, TTL: pt.TTL, Nutrition: pt.Value}
+}
+
+func FoodFromPointArray(ptArray []rules.Point) []Coord {
+	a := make([]Coord, 0)
+	for _, pt := range ptArray {
+		a = append(a, FoodFromPoint(pt))
+	}
+	return a
+}
+
+func PointFromFood(f Coord) rules.Point {
+	return rules.Point{X: f.X, Y: f.Y, TTL: f.TTL, Value: f.Nutrition}
+}
+
+func PointFromFoodArray(fArray []Coord) []rules.Point {
+	a := make([]rules.Point, 0)
+	for _, f := range fArray {
+		a = append(a, PointFromFood(f))
+	}
+	return a
+}
+
+func HazardFromPoint(pt rules.Point) Coord {
+	return Coord{X: pt.X, Y: pt.Y, Damage: pt.Value}
+}
+
+func HazardFromPointArray(ptArray []rules.Point) []Coord {
+	a := make([]Coord, 0)
+	for _, pt := range ptArray {
+		a = append(a, HazardFromPoint(pt))
+	}
+	return a
+}
+
+func PointFromHazard(h Coord) rules.Point {
+	return rules.Point{X: h.X, Y: h.Y, Value: h.Damage}
+}
+
+func PointFromHazardArray(hArray []Coord) []rules.Point {
+	a := make([]rules.Point, 0)
+	for _, h := range hArray {
+		a = append(a, PointFromHazard(h))
+	}
+	return a
+}
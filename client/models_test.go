@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"testing"
 
+	"github.com/BattlesnakeOfficial/rules"
 	"github.com/BattlesnakeOfficial/rules/test"
 	"github.com/stretchr/testify/require"
 )
@@ -24,3 +25,47 @@ func TestBuildSnakeRequestJSONEmptyRulesetSettings(t *testing.T) {
 
 	test.RequireJSONMatchesFixture(t, "testdata/snake_request_empty_ruleset_settings.json", string(data))
 }
+
+func TestHazardDamageJSON(t *testing.T) {
+	hazards := HazardFromPointArray([]rules.Point{{X: 1, Y: 2}, {X: 3, Y: 4, Value: -10}})
+	data, err := json.Marshal(hazards)
+	require.NoError(t, err)
+	require.JSONEq(t, `[{"x": 1, "y": 2}, {"x": 3, "y": 4, "damage": -10}]`, string(data))
+
+	require.Equal(t, []rules.Point{{X: 1, Y: 2}, {X: 3, Y: 4, Value: -10}}, PointFromHazardArray(hazards))
+}
+
+func TestBoardWallsJSON(t *testing.T) {
+	data, err := json.Marshal(Board{Width: 3, Height: 3})
+	require.NoError(t, err)
+	require.NotContains(t, string(data), "walls")
+
+	data, err = json.Marshal(Board{Width: 3, Height: 3, Walls: CoordFromPointArray([]rules.Point{{X: 1, Y: 1}})})
+	require.NoError(t, err)
+	require.Contains(t, string(data), `"walls":[{"x":1,"y":1}]`)
+}
+
+func TestFoodJSON(t *testing.T) {
+	food := FoodFromPointArray([]rules.Point{{X: 1, Y: 2}, {X: 3, Y: 4, TTL: 5, Value: -10}})
+	data, err := json.Marshal(food)
+	require.NoError(t, err)
+	require.JSONEq(t, `[{"x": 1, "y": 2}, {"x": 3, "y": 4, "ttl": 5, "nutrition": -10}]`, string(data))
+
+	require.Equal(t, []rules.Point{{X: 1, Y: 2}, {X: 3, Y: 4, TTL: 5, Value: -10}}, PointFromFoodArray(food))
+}
+
+func TestConvertRulesetSettingsFoodSpawner(t *testing.T) {
+	settings := rules.NewSettingsWithParams(rules.ParamFoodWaveEveryNTurns, "10", rules.ParamFoodWaveSize, "3")
+	require.Equal(t, RulesetSettings{}, ConvertRulesetSettings(settings), "wave settings only apply to the waves spawner")
+
+	converted := ConvertRulesetSettings(rules.NewSettingsWithParams(rules.ParamFoodSpawner, rules.FoodSpawnerFair))
+	require.Equal(t, RulesetSettings{FoodSpawner: "fair"}, converted)
+
+	converted = ConvertRulesetSettings(rules.NewSettingsWithParams(rules.ParamFoodSpawner, rules.FoodSpawnerWaves, rules.ParamFoodWaveEveryNTurns, "10", rules.ParamFoodWaveSize, "3"))
+	require.Equal(t, RulesetSettings{FoodSpawner: "waves", FoodWaveEveryNTurns: 10, FoodWaveSize: 3}, converted)
+}
+
+func TestConvertRulesetSettingsTurnLimit(t *testing.T) {
+	converted := ConvertRulesetSettings(rules.NewSettingsWithParams(rules.ParamTurnLimit, "300", rules.ParamTieBreakers, "length,health"))
+	require.Equal(t, RulesetSettings{TurnLimit: 300, TieBreakers: "length,health"}, converted)
+}
@@ -0,0 +1,251 @@
+// Package conformance checks that other implementations of the rules match this engine exactly,
+// using portable test vectors. Each vector is a single turn: the ruleset, its settings and seed,
+// the board before the turn and the moves made, along with the result this engine produces.
+//
+// Vectors are stored as JSON Lines, one Vector per line, in the format described in README.md.
+// They come from the rules package's own test cases (TableVectors) and from randomized games
+// played across every named ruleset (GameVectors). Check runs vectors through an
+// Implementation, which is normally another program speaking the protocol in README.md over
+// its standard input and output (see StartProcess).
+package conformance
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/BattlesnakeOfficial/rules"
+)
+
+// Point is a position on the board. Food also has a lifetime and nutrition value.
+type Point struct {
+	X     int `json:"x"`
+	Y     int `json:"y"`
+	TTL   int `json:"ttl,omitempty"`
+	Value int `json:"value,omitempty"`
+}
+
+type Snake struct {
+	ID               string  `json:"id"`
+	Body             []Point `json:"body"`
+	Health           int     `json:"health"`
+	EliminatedCause  string  `json:"eliminatedCause"`
+	EliminatedOnTurn int     `json:"eliminatedOnTurn"`
+	EliminatedBy     string  `json:"eliminatedBy"`
+}
+
+// PointState is a number stored on a point by the rules or a map.
+type PointState struct {
+	X     int `json:"x"`
+	Y     int `json:"y"`
+	Value int `json:"value"`
+}
+
+// Board is the complete state of a board. Lists are always present, even when they're empty.
+type Board struct {
+	Turn       int               `json:"turn"`
+	Width      int               `json:"width"`
+	Height     int               `json:"height"`
+	Food       []Point           `json:"food"`
+	Snakes     []Snake           `json:"snakes"`
+	Hazards    []Point           `json:"hazards"`
+	Walls      []Point           `json:"walls"`
+	GameState  map[string]string `json:"gameState"`
+	PointState []PointState      `json:"pointState"`
+}
+
+type Move struct {
+	ID   string `json:"id"`
+	Move string `json:"move"`
+}
+
+// Request asks an implementation to run a single turn.
+type Request struct {
+	// The name of the ruleset, as passed to rules.RulesetBuilder.NamedRuleset.
+	Ruleset string `json:"ruleset"`
+	// Whether the game only needs one snake to keep going, as with rules.RulesetBuilder.WithSolo.
+	Solo bool  `json:"solo"`
+	Seed int64 `json:"seed"`
+	// The game parameters given to the ruleset. Parameters that aren't listed take their defaults.
+	Settings map[string]string `json:"settings"`
+	State    Board             `json:"state"`
+	// The moves made this turn. There are no moves when the board is being initialized.
+	Moves []Move `json:"moves"`
+}
+
+// Response is the result of a turn. When the ruleset returns an error, only Error is set.
+type Response struct {
+	Error    string `json:"error,omitempty"`
+	GameOver bool   `json:"gameOver"`
+	State    *Board `json:"state,omitempty"`
+}
+
+// Vector is a turn and the result expected from it.
+type Vector struct {
+	Name string `json:"name"`
+	Request
+	// Whether the result depends on the seed. These vectors can only be matched by implementations
+	// that generate random numbers the same way as rules.NewStreamRand.
+	Random   bool     `json:"random"`
+	Expected Response `json:"expected"`
+}
+
+// NewBoard converts a board state into its portable form.
+func NewBoard(b *rules.BoardState) Board {
+	board := Board{
+		Turn:       b.Turn,
+		Width:      b.Width,
+		Height:     b.Height,
+		Food:       newPoints(b.Food),
+		Snakes:     make([]Snake, 0, len(b.Snakes)),
+		Hazards:    newPoints(b.Hazards),
+		Walls:      newPoints(b.Walls),
+		GameState:  map[string]string{},
+		PointState: make([]PointState, 0, len(b.PointState)),
+	}
+	for _, snake := range b.Snakes {
+		board.Snakes = append(board.Snakes, Snake{
+			ID:               snake.ID,
+			Body:             newPoints(snake.Body),
+			Health:           snake.Health,
+			EliminatedCause:  snake.EliminatedCause,
+			EliminatedOnTurn: snake.EliminatedOnTurn,
+			EliminatedBy:     snake.EliminatedBy,
+		})
+	}
+	for key, value := range b.GameState {
+		board.GameState[key] = value
+	}
+	for p, value := range b.PointState {
+		board.PointState = append(board.PointState, PointState{X: p.X, Y: p.Y, Value: value})
+	}
+	sort.Slice(board.PointState, func(i, j int) bool {
+		a, b := board.PointState[i], board.PointState[j]
+		if a.Y != b.Y {
+			return a.Y < b.Y
+		}
+		return a.X < b.X
+	})
+	return board
+}
+
+func newPoints(points []rules.Point) []Point {
+	converted := make([]Point, 0, len(points))
+	for _, p := range points {
+		converted = append(converted, Point{X: p.X, Y: p.Y, TTL: p.TTL, Value: p.Value})
+	}
+	return converted
+}
+
+// BoardState converts a board back into a board state.
+func (board Board) BoardState() *rules.BoardState {
+	b := rules.NewBoardState(board.Width, board.Height).
+		WithTurn(board.Turn).
+		WithFood(boardPoints(board.Food)).
+		WithHazards(boardPoints(board.Hazards)).
+		WithWalls(boardPoints(board.Walls))
+	for _, snake := range board.Snakes {
+		b.Snakes = append(b.Snakes, rules.Snake{
+			ID:               snake.ID,
+			Body:             boardPoints(snake.Body),
+			Health:           snake.Health,
+			EliminatedCause:  snake.EliminatedCause,
+			EliminatedOnTurn: snake.EliminatedOnTurn,
+			EliminatedBy:     snake.EliminatedBy,
+		})
+	}
+	for key, value := range board.GameState {
+		b.GameState[key] = value
+	}
+	for _, p := range board.PointState {
+		b.PointState[rules.Point{X: p.X, Y: p.Y}] = p.Value
+	}
+	return b
+}
+
+func boardPoints(points []Point) []rules.Point {
+	converted := make([]rules.Point, 0, len(points))
+	for _, p := range points {
+		converted = append(converted, rules.Point{X: p.X, Y: p.Y, TTL: p.TTL, Value: p.Value})
+	}
+	return converted
+}
+
+// normalize fills in lists left out of a board, so that they compare equal to empty ones.
+func (board *Board) normalize() {
+	if board.Food == nil {
+		board.Food = []Point{}
+	}
+	if board.Snakes == nil {
+		board.Snakes = []Snake{}
+	}
+	for i := range board.Snakes {
+		if board.Snakes[i].Body == nil {
+			board.Snakes[i].Body = []Point{}
+		}
+	}
+	if board.Hazards == nil {
+		board.Hazards = []Point{}
+	}
+	if board.Walls == nil {
+		board.Walls = []Point{}
+	}
+	if board.GameState == nil {
+		board.GameState = map[string]string{}
+	}
+	if board.PointState == nil {
+		board.PointState = []PointState{}
+	}
+}
+
+// SnakeMoves returns the moves in the request in the form the rules engine takes them.
+func (request Request) SnakeMoves() []rules.SnakeMove {
+	var moves []rules.SnakeMove
+	for _, move := range request.Moves {
+		moves = append(moves, rules.SnakeMove{ID: move.ID, Move: move.Move})
+	}
+	return moves
+}
+
+// NewRuleset returns the ruleset for the request.
+func (request Request) NewRuleset() rules.Ruleset {
+	return rules.NewRulesetBuilder().
+		WithParams(request.Settings).
+		WithSeed(request.Seed).
+		WithSolo(request.Solo).
+		NamedRuleset(request.Ruleset)
+}
+
+// Write writes vectors as JSON Lines.
+func Write(w io.Writer, vectors []Vector) error {
+	encoder := json.NewEncoder(w)
+	for i := range vectors {
+		if err := encoder.Encode(&vectors[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Read reads vectors written by Write.
+func Read(r io.Reader) ([]Vector, error) {
+	var vectors []Vector
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(nil, 64*1024*1024)
+	for line := 1; scanner.Scan(); line++ {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+		var vector Vector
+		if err := json.Unmarshal(scanner.Bytes(), &vector); err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		vectors = append(vectors, vector)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return vectors, nil
+}
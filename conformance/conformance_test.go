@@ -0,0 +1,209 @@
+package conformance
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/BattlesnakeOfficial/rules"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMain(m *testing.M) {
+	// Run as the implementation under test for TestStartProcess
+	if os.Getenv("CONFORMANCE_TEST_SERVE") == "1" {
+		if err := Serve(Engine{}, os.Stdin, os.Stdout); err != nil {
+			os.Exit(1)
+		}
+		os.Exit(0)
+	}
+	os.Exit(m.Run())
+}
+
+func TestBoardRoundTrip(t *testing.T) {
+	b := rules.NewBoardState(7, 5).
+		WithTurn(3).
+		WithFood([]rules.Point{{X: 1, Y: 1, TTL: 4, Value: 20}}).
+		WithHazards([]rules.Point{{X: 2, Y: 2}, {X: 2, Y: 2}}).
+		WithWalls([]rules.Point{{X: 0, Y: 4}}).
+		WithSnakes([]rules.Snake{
+			{ID: "one", Health: 90, Body: []rules.Point{{X: 3, Y: 3}, {X: 3, Y: 2}}},
+			{ID: "two", Health: 0, Body: []rules.Point{{X: 6, Y: 0}}, EliminatedCause: rules.EliminatedByCollision, EliminatedOnTurn: 2, EliminatedBy: "one"},
+		}).
+		WithGameState(map[string]string{"key": "value"}).
+		WithPointState(map[rules.Point]int{{X: 4, Y: 1}: 2, {X: 1, Y: 0}: -1, {X: 0, Y: 1}: 5})
+
+	board := NewBoard(b)
+	require.Equal(t, []PointState{{X: 1, Y: 0, Value: -1}, {X: 0, Y: 1, Value: 5}, {X: 4, Y: 1, Value: 2}}, board.PointState)
+	require.Equal(t, b, board.BoardState())
+
+	// Empty lists are always written out
+	var buf bytes.Buffer
+	require.NoError(t, Write(&buf, []Vector{{Name: "empty", Request: Request{State: NewBoard(rules.NewBoardState(3, 3))}}}))
+	require.Contains(t, buf.String(), `"state":{"turn":0,"width":3,"height":3,"food":[],"snakes":[],"hazards":[],"walls":[],"gameState":{},"pointState":[]}`)
+}
+
+func TestTableVectors(t *testing.T) {
+	vectors, err := TableVectors()
+	require.NoError(t, err)
+	require.NotEmpty(t, vectors)
+
+	names := map[string]bool{}
+	for _, vector := range vectors {
+		require.True(t, strings.HasPrefix(vector.Name, "table/"+vector.Ruleset+"/"), vector.Name)
+		require.False(t, names[vector.Name], "duplicate vector %s", vector.Name)
+		names[vector.Name] = true
+		// a seed of 0 picks a random seed, so those results can't be random
+		if vector.Seed == 0 {
+			require.False(t, vector.Random, vector.Name)
+		}
+	}
+	require.True(t, names["table/standard/Standard Case Error No Move Found"])
+	require.True(t, names["table/royale/Royale Case Hazards Placed"])
+
+	report, err := Check(Engine{}, vectors, false)
+	require.NoError(t, err)
+	require.Empty(t, report.Failures)
+	require.Equal(t, len(vectors), report.Passed)
+}
+
+func TestGameVectors(t *testing.T) {
+	vectors, err := GameVectors(42, 2, 30)
+	require.NoError(t, err)
+
+	rulesets := map[string]bool{}
+	random, eliminated, initialized := 0, 0, 0
+	for _, vector := range vectors {
+		rulesets[vector.Ruleset] = true
+		require.Empty(t, vector.Expected.Error, vector.Name)
+		if vector.Random {
+			random++
+		}
+		if strings.HasSuffix(vector.Name, "/turn-0") {
+			initialized++
+			require.Empty(t, vector.Moves, vector.Name)
+		}
+		for _, snake := range vector.Expected.State.Snakes {
+			if snake.EliminatedCause != rules.NotEliminated {
+				eliminated++
+				break
+			}
+		}
+	}
+	require.Len(t, rulesets, len(GameTypes))
+	require.Equal(t, 2*len(GameTypes), initialized)
+	require.Greater(t, random, 0)
+	require.Greater(t, eliminated, 0)
+
+	report, err := Check(Engine{}, vectors, false)
+	require.NoError(t, err)
+	require.Empty(t, report.Failures)
+
+	// The same seed always produces the same vectors
+	again, err := GameVectors(42, 2, 30)
+	require.NoError(t, err)
+	require.Equal(t, vectors, again)
+
+	// Vectors survive being written and read back
+	var buf bytes.Buffer
+	require.NoError(t, Write(&buf, vectors))
+	read, err := Read(&buf)
+	require.NoError(t, err)
+	require.Equal(t, vectors, read)
+}
+
+func TestCompare(t *testing.T) {
+	board := NewBoard(rules.NewBoardState(3, 3).WithSnakes([]rules.Snake{
+		{ID: "one", Health: 100, Body: []rules.Point{{X: 1, Y: 1}}},
+	}))
+	expected := Response{State: &board}
+
+	same := board
+	same.Food, same.GameState = nil, nil
+	require.Equal(t, "", Compare(expected, Response{State: &same}))
+
+	require.Equal(t, "no state returned", Compare(expected, Response{}))
+	require.Equal(t, "gameOver is true, expected false", Compare(expected, Response{GameOver: true, State: &board}))
+	require.Equal(t, "unexpected error: oops", Compare(expected, Response{Error: "oops"}))
+	require.Equal(t, "", Compare(Response{Error: "move not provided for snake"}, Response{Error: "missing move"}))
+	require.Equal(t, "expected an error (move not provided for snake)", Compare(Response{Error: "move not provided for snake"}, Response{State: &board}))
+
+	different := NewBoard(board.BoardState())
+	different.Snakes[0].Health = 99
+	require.Equal(t,
+		`snakes is [{"id":"one","body":[{"x":1,"y":1}],"health":99,"eliminatedCause":"","eliminatedOnTurn":0,"eliminatedBy":""}], expected [{"id":"one","body":[{"x":1,"y":1}],"health":100,"eliminatedCause":"","eliminatedOnTurn":0,"eliminatedBy":""}]`,
+		Compare(expected, Response{State: &different}))
+}
+
+// starvingEngine gets health wrong, so that it fails any vector where a snake survives.
+type starvingEngine struct{}
+
+func (starvingEngine) Execute(request Request) (Response, error) {
+	response, err := Engine{}.Execute(request)
+	if response.State != nil {
+		for i := range response.State.Snakes {
+			response.State.Snakes[i].Health--
+		}
+	}
+	return response, err
+}
+
+func TestServeAndClient(t *testing.T) {
+	vectors, err := Suite(1, 1, 10)
+	require.NoError(t, err)
+
+	for name, test := range map[string]struct {
+		impl     Implementation
+		failures bool
+	}{
+		"engine":   {Engine{}, false},
+		"starving": {starvingEngine{}, true},
+	} {
+		t.Run(name, func(t *testing.T) {
+			requests, requestWriter := io.Pipe()
+			responseReader, responses := io.Pipe()
+			served := make(chan error)
+			go func() {
+				served <- Serve(test.impl, requests, responses)
+			}()
+
+			report, err := Check(NewClient(requestWriter, responseReader), vectors, true)
+			require.NoError(t, err)
+			require.NoError(t, requestWriter.Close())
+			require.NoError(t, <-served)
+
+			require.Greater(t, report.Skipped, 0)
+			require.Equal(t, len(vectors), report.Passed+report.Skipped+len(report.Failures))
+			if test.failures {
+				require.NotEmpty(t, report.Failures)
+				require.Contains(t, report.Failures[0].String(), ": snakes is ")
+			} else {
+				require.Empty(t, report.Failures)
+			}
+		})
+	}
+}
+
+func TestStartProcess(t *testing.T) {
+	vectors, err := TableVectors()
+	require.NoError(t, err)
+
+	os.Setenv("CONFORMANCE_TEST_SERVE", "1")
+	defer os.Unsetenv("CONFORMANCE_TEST_SERVE")
+	process, err := StartProcess(os.Args[0])
+	require.NoError(t, err)
+
+	report, err := Check(process, vectors, false)
+	require.NoError(t, err)
+	require.Empty(t, report.Failures)
+	require.NoError(t, process.Close())
+
+	// A program that exits without answering
+	process, err = StartProcess("true")
+	require.NoError(t, err)
+	_, err = Check(process, vectors, false)
+	require.Error(t, err)
+	_ = process.Close()
+}
@@ -0,0 +1,187 @@
+package conformance
+
+import (
+	"bytes"
+	_ "embed"
+	"fmt"
+
+	"github.com/BattlesnakeOfficial/rules"
+	"github.com/BattlesnakeOfficial/rules/search"
+)
+
+// GameTypes are the named rulesets that randomized games are played with.
+var GameTypes = []string{
+	rules.GameTypeStandard,
+	rules.GameTypeConstrictor,
+	rules.GameTypeWrappedConstrictor,
+	rules.GameTypeRoyale,
+	rules.GameTypeSolo,
+	rules.GameTypeWrapped,
+}
+
+// Engine is the reference Implementation, running requests through this rules engine.
+type Engine struct{}
+
+func (Engine) Execute(request Request) (Response, error) {
+	gameOver, next, err := request.NewRuleset().Execute(request.State.BoardState(), request.SnakeMoves())
+	if err != nil {
+		return Response{Error: err.Error()}, nil
+	}
+	board := NewBoard(next)
+	return Response{GameOver: gameOver, State: &board}, nil
+}
+
+// NewVector runs a request through the engine to find the expected result. The request is also
+// run with other seeds to find out whether the result is random.
+func NewVector(name string, request Request) Vector {
+	if request.Settings == nil {
+		request.Settings = map[string]string{}
+	}
+	if request.Moves == nil {
+		request.Moves = []Move{}
+	}
+	vector := Vector{Name: name, Request: request}
+	vector.Expected, _ = Engine{}.Execute(request)
+	for _, offset := range []int64{1, 7919} {
+		other := request
+		other.Seed += offset
+		response, _ := Engine{}.Execute(other)
+		if Compare(vector.Expected, response) != "" {
+			vector.Random = true
+		}
+	}
+	return vector
+}
+
+//go:embed tables.jsonl
+var tables []byte
+
+// TableVectors returns vectors for the test cases the rules package checks every ruleset
+// against. They're kept in tables.jsonl, which is regenerated from the test cases by running
+// the rules package tests with -update-fixtures.
+func TableVectors() ([]Vector, error) {
+	return Read(bytes.NewReader(tables))
+}
+
+// GameVectors plays randomized games with every ruleset in GameTypes, returning a vector for
+// every turn. Each game uses a random board size, number of snakes and settings, and is played
+// for up to maxTurns turns with mostly safe, random moves. The same seed always produces the same vectors.
+func GameVectors(seed int64, gamesPerRuleset, maxTurns int) ([]Vector, error) {
+	rand := rules.NewSeedRand(seed)
+	var vectors []Vector
+	for _, gameType := range GameTypes {
+		for game := 1; game <= gamesPerRuleset; game++ {
+			name := fmt.Sprintf("game/%s/%d", gameType, game)
+			played, err := playRandomGame(rand, name, gameType, maxTurns)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", name, err)
+			}
+			vectors = append(vectors, played...)
+		}
+	}
+	return vectors, nil
+}
+
+// Suite returns the table vectors followed by the randomized game vectors.
+func Suite(seed int64, gamesPerRuleset, maxTurns int) ([]Vector, error) {
+	vectors, err := TableVectors()
+	if err != nil {
+		return nil, err
+	}
+	games, err := GameVectors(seed, gamesPerRuleset, maxTurns)
+	if err != nil {
+		return nil, err
+	}
+	return append(vectors, games...), nil
+}
+
+func playRandomGame(rand rules.Rand, name, gameType string, maxTurns int) ([]Vector, error) {
+	sizes := []int{7, 9, 11}
+	width, height := sizes[rand.Intn(len(sizes))], sizes[rand.Intn(len(sizes))]
+	snakeCount := rand.Range(2, 4)
+	if gameType == rules.GameTypeSolo {
+		snakeCount = 1
+	}
+	var snakeIDs []string
+	for i := 1; i <= snakeCount; i++ {
+		snakeIDs = append(snakeIDs, fmt.Sprintf("snake-%d", i))
+	}
+
+	b, err := rules.CreateDefaultBoardState(rand, width, height, snakeIDs)
+	if err != nil {
+		return nil, err
+	}
+	// Hazards and walls aren't placed on snakes' first moves, so that games don't end straight away
+	free := rules.GetUnoccupiedPoints(b, false, false)
+	rand.Shuffle(len(free), func(i, j int) { free[i], free[j] = free[j], free[i] })
+	for i := rand.Intn(4); i > 0 && len(free) > 0; i-- {
+		b.Hazards = append(b.Hazards, free[0])
+		free = free[1:]
+	}
+	for i := rand.Intn(3); i > 0 && len(free) > 0; i-- {
+		b.Walls = append(b.Walls, free[0])
+		free = free[1:]
+	}
+
+	request := Request{
+		Ruleset:  gameType,
+		Solo:     gameType == rules.GameTypeSolo,
+		Seed:     int64(rand.Range(1, 1<<30)), // 0 would pick a random seed
+		Settings: randomSettings(rand, gameType),
+		State:    NewBoard(b),
+	}
+	wrapped := gameType == rules.GameTypeWrapped || gameType == rules.GameTypeWrappedConstrictor
+
+	// The first turn initializes the board, without any moves
+	var vectors []Vector
+	for turn := 0; turn <= maxTurns; turn++ {
+		vector := NewVector(fmt.Sprintf("%s/turn-%d", name, turn), request)
+		vectors = append(vectors, vector)
+		if vector.Expected.Error != "" {
+			return nil, fmt.Errorf("turn %d: %s", turn, vector.Expected.Error)
+		}
+		if vector.Expected.GameOver {
+			break
+		}
+
+		// Move on to the next turn, as the CLI does after running the ruleset
+		request.State = *vector.Expected.State
+		request.State.Turn++
+		b := request.State.BoardState()
+		request.Moves = nil
+		for _, snake := range b.Snakes {
+			if snake.EliminatedCause != rules.NotEliminated {
+				continue
+			}
+			moves := search.SafeMoves(b, snake.ID, wrapped)
+			// Sometimes make an unsafe move, so that every kind of elimination is covered
+			if len(moves) == 0 || rand.Intn(10) == 0 {
+				moves = []string{rules.MoveUp, rules.MoveDown, rules.MoveLeft, rules.MoveRight}
+			}
+			request.Moves = append(request.Moves, Move{ID: snake.ID, Move: moves[rand.Intn(len(moves))]})
+		}
+	}
+	return vectors, nil
+}
+
+func randomSettings(rand rules.Rand, gameType string) map[string]string {
+	settings := map[string]string{
+		rules.ParamFoodSpawnChance:     fmt.Sprint(rand.Range(0, 25)),
+		rules.ParamMinimumFood:         fmt.Sprint(rand.Range(0, 3)),
+		rules.ParamHazardDamagePerTurn: fmt.Sprint(rand.Range(0, 30)),
+	}
+	if gameType == rules.GameTypeRoyale {
+		settings[rules.ParamShrinkEveryNTurns] = fmt.Sprint(rand.Range(1, 10))
+	}
+	if rand.Intn(3) == 0 {
+		settings[rules.ParamFoodLifetime] = fmt.Sprint(rand.Range(1, 20))
+		settings[rules.ParamFoodNutrition] = fmt.Sprint(rand.Range(-50, 100))
+	}
+	if rand.Intn(3) == 0 {
+		spawners := rules.FoodSpawnerNames()
+		settings[rules.ParamFoodSpawner] = spawners[rand.Intn(len(spawners))]
+		settings[rules.ParamFoodWaveEveryNTurns] = fmt.Sprint(rand.Range(1, 10))
+		settings[rules.ParamFoodWaveSize] = fmt.Sprint(rand.Range(0, 3))
+	}
+	return settings
+}
@@ -0,0 +1,195 @@
+package conformance
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+)
+
+// Implementation runs turns of a game for Check.
+type Implementation interface {
+	// Execute runs a single turn. Errors from the ruleset belong in the response, and the error
+	// returned is only for failing to get a response at all.
+	Execute(request Request) (Response, error)
+}
+
+// Failure is a vector that an implementation didn't match.
+type Failure struct {
+	Name   string
+	Reason string
+}
+
+func (f Failure) String() string {
+	return fmt.Sprintf("%s: %s", f.Name, f.Reason)
+}
+
+// Report is the result of checking an implementation against vectors.
+type Report struct {
+	Passed   int
+	Skipped  int
+	Failures []Failure
+}
+
+// Check runs every vector through an implementation and reports the ones it doesn't match.
+// Vectors with random results are skipped if skipRandom is set. It stops at the first error
+// returned by the implementation.
+func Check(impl Implementation, vectors []Vector, skipRandom bool) (*Report, error) {
+	report := &Report{}
+	for _, vector := range vectors {
+		if skipRandom && vector.Random {
+			report.Skipped++
+			continue
+		}
+		response, err := impl.Execute(vector.Request)
+		if err != nil {
+			return report, fmt.Errorf("%s: %w", vector.Name, err)
+		}
+		if reason := Compare(vector.Expected, response); reason != "" {
+			report.Failures = append(report.Failures, Failure{Name: vector.Name, Reason: reason})
+		} else {
+			report.Passed++
+		}
+	}
+	return report, nil
+}
+
+// Compare describes the first difference between the expected and actual results of a turn, or
+// returns an empty string if they match. Error messages don't have to match, as long as an
+// error is returned when one is expected.
+func Compare(expected, actual Response) string {
+	if expected.Error != "" {
+		if actual.Error == "" {
+			return fmt.Sprintf("expected an error (%s)", expected.Error)
+		}
+		return ""
+	}
+	if actual.Error != "" {
+		return "unexpected error: " + actual.Error
+	}
+	if expected.GameOver != actual.GameOver {
+		return fmt.Sprintf("gameOver is %v, expected %v", actual.GameOver, expected.GameOver)
+	}
+	if actual.State == nil {
+		return "no state returned"
+	}
+
+	want, got := *expected.State, *actual.State
+	want.normalize()
+	got.normalize()
+	for _, field := range []struct {
+		name      string
+		want, got interface{}
+	}{
+		{"turn", want.Turn, got.Turn},
+		{"width", want.Width, got.Width},
+		{"height", want.Height, got.Height},
+		{"food", want.Food, got.Food},
+		{"snakes", want.Snakes, got.Snakes},
+		{"hazards", want.Hazards, got.Hazards},
+		{"walls", want.Walls, got.Walls},
+		{"gameState", want.GameState, got.GameState},
+		{"pointState", want.PointState, got.PointState},
+	} {
+		wantJSON, _ := json.Marshal(field.want)
+		gotJSON, _ := json.Marshal(field.got)
+		if string(wantJSON) != string(gotJSON) {
+			return fmt.Sprintf("%s is %s, expected %s", field.name, gotJSON, wantJSON)
+		}
+	}
+	return ""
+}
+
+// Serve answers requests read from r with the responses from an implementation written to w,
+// one JSON object per line, until r is closed.
+func Serve(impl Implementation, r io.Reader, w io.Writer) error {
+	reader := bufio.NewReader(r)
+	encoder := json.NewEncoder(w)
+	for {
+		line, err := reader.ReadBytes('\n')
+		if len(line) > 0 {
+			var request Request
+			if err := json.Unmarshal(line, &request); err != nil {
+				return err
+			}
+			response, err := impl.Execute(request)
+			if err != nil {
+				return err
+			}
+			if err := encoder.Encode(response); err != nil {
+				return err
+			}
+		}
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// Client is an Implementation that writes requests to another implementation, one JSON object
+// per line, and reads a line with the response to each one.
+type Client struct {
+	encoder *json.Encoder
+	reader  *bufio.Reader
+}
+
+func NewClient(w io.Writer, r io.Reader) *Client {
+	return &Client{encoder: json.NewEncoder(w), reader: bufio.NewReader(r)}
+}
+
+func (c *Client) Execute(request Request) (Response, error) {
+	if err := c.encoder.Encode(request); err != nil {
+		return Response{}, err
+	}
+	line, err := c.reader.ReadBytes('\n')
+	if err == io.EOF && len(line) > 0 {
+		err = nil
+	}
+	if err != nil {
+		return Response{}, fmt.Errorf("error reading response: %w", err)
+	}
+	var response Response
+	if err := json.Unmarshal(line, &response); err != nil {
+		return Response{}, fmt.Errorf("invalid response %q: %w", line, err)
+	}
+	return response, nil
+}
+
+// Process is an implementation running as another program, speaking to it over its standard
+// input and output. Anything it writes to standard error is passed through.
+type Process struct {
+	*Client
+	cmd   *exec.Cmd
+	stdin io.WriteCloser
+}
+
+// StartProcess runs a program to check.
+func StartProcess(name string, args ...string) (*Process, error) {
+	cmd := exec.Command(name, args...)
+	cmd.Stderr = os.Stderr
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		return nil, err
+	}
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, err
+	}
+	if err := cmd.Start(); err != nil {
+		return nil, err
+	}
+	return &Process{Client: NewClient(stdin, stdout), cmd: cmd, stdin: stdin}, nil
+}
+
+// Close closes the program's standard input and waits for it to exit.
+func (p *Process) Close() error {
+	if err := p.stdin.Close(); err != nil {
+		return err
+	}
+	return p.cmd.Wait()
+}
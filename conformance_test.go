@@ -0,0 +1,33 @@
+package rules_test
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+
+	"github.com/BattlesnakeOfficial/rules"
+	"github.com/BattlesnakeOfficial/rules/conformance"
+	"github.com/BattlesnakeOfficial/rules/test"
+	"github.com/stretchr/testify/require"
+)
+
+// The conformance vectors for the gameTestCase tables must be regenerated when the tables change.
+func TestConformanceTableVectors(t *testing.T) {
+	var vectors []conformance.Vector
+	for _, gc := range rules.ConformanceCases() {
+		request := conformance.Request{
+			Ruleset:  gc.Ruleset,
+			Seed:     gc.Seed,
+			Settings: gc.Params,
+			State:    conformance.NewBoard(gc.PrevState),
+		}
+		for _, move := range gc.Moves {
+			request.Moves = append(request.Moves, conformance.Move{ID: move.ID, Move: move.Move})
+		}
+		vectors = append(vectors, conformance.NewVector(fmt.Sprintf("table/%s/%s", gc.Ruleset, gc.Name), request))
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, conformance.Write(&buf, vectors))
+	test.RequireTextMatchesFixture(t, "conformance/tables.jsonl", buf.String())
+}
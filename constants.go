@@ -27,6 +27,7 @@ const (
 	EliminatedByHeadToHeadCollision = "head-collision"
 	EliminatedByOutOfBounds         = "wall-collision"
 	EliminatedByHazard              = "hazard"
+	EliminatedByWall                = "wall"
 
 	// Error constants
 	ErrorTooManySnakes   = RulesetError("too many snakes for fixed start positions")
@@ -59,4 +60,11 @@ const (
 	ParamSharedElimination   = "sharedElimination"
 	ParamSharedHealth        = "sharedHealth"
 	ParamSharedLength        = "sharedLength"
+	ParamTurnLimit           = "turnLimit"
+	ParamTieBreakers         = "tieBreakers"
+	ParamFoodLifetime        = "foodLifetime"
+	ParamFoodNutrition       = "foodNutrition"
+	ParamFoodSpawner         = "foodSpawner"
+	ParamFoodWaveEveryNTurns = "foodWaveEveryNTurns"
+	ParamFoodWaveSize        = "foodWaveSize"
 )
@@ -2,6 +2,7 @@ package rules
 
 var constrictorRulesetStages = []string{
 	StageGameOverStandard,
+	StageGameOverTurnLimit,
 	StageMovementStandard,
 	StageStarvationStandard,
 	StageHazardDamageStandard,
@@ -13,6 +14,7 @@ var constrictorRulesetStages = []string{
 
 var wrappedConstrictorRulesetStages = []string{
 	StageGameOverStandard,
+	StageGameOverTurnLimit,
 	StageMovementWrapBoundaries,
 	StageStarvationStandard,
 	StageHazardDamageStandard,
@@ -0,0 +1,66 @@
+package rules
+
+// ConformanceCase is a gameTestCase along with the ruleset it's checked against. They're exported
+// for the tests in rules_test, which keep the conformance test vectors in sync with the tables.
+type ConformanceCase struct {
+	Name      string
+	Ruleset   string
+	Seed      int64
+	Params    map[string]string
+	PrevState *BoardState
+	Moves     []SnakeMove
+}
+
+// ConformanceCases returns the gameTestCase tables that each named ruleset is checked against.
+// Only the previous state and moves are used, since the vectors record the engine's own results.
+func ConformanceCases() []ConformanceCase {
+	var cases []ConformanceCase
+	add := func(ruleset string, seed int64, params map[string]string, tests ...gameTestCase) {
+		for _, gc := range tests {
+			cases = append(cases, ConformanceCase{
+				Name:      gc.name,
+				Ruleset:   ruleset,
+				Seed:      seed,
+				Params:    params,
+				PrevState: gc.prevState,
+				Moves:     gc.moves,
+			})
+		}
+	}
+	add(GameTypeStandard, 0, nil,
+		standardCaseErrNoMoveFound,
+		standardCaseErrZeroLengthSnake,
+		standardCaseMoveEatAndGrow,
+		standardMoveAndCollideMAD,
+	)
+	add(GameTypeConstrictor, 0, nil,
+		standardCaseErrNoMoveFound,
+		standardCaseErrZeroLengthSnake,
+		constrictorMoveAndCollideMAD,
+	)
+	add(GameTypeRoyale, 1234, map[string]string{
+		ParamHazardDamagePerTurn: "1",
+		ParamShrinkEveryNTurns:   "1",
+	},
+		standardCaseErrNoMoveFound,
+		standardCaseErrZeroLengthSnake,
+		standardCaseMoveEatAndGrow,
+		standardMoveAndCollideMAD,
+		royaleCaseHazardsPlaced,
+	)
+	add(GameTypeWrapped, 0, nil,
+		standardCaseErrNoMoveFound,
+		standardCaseErrZeroLengthSnake,
+		standardCaseMoveEatAndGrow,
+		standardMoveAndCollideMAD,
+		wrappedCaseMoveAndWrap,
+	)
+	add(GameTypeSolo, 0, nil,
+		standardCaseErrNoMoveFound,
+		standardCaseErrZeroLengthSnake,
+		standardCaseMoveEatAndGrow,
+		standardMoveAndCollideMAD,
+		soloCaseNotOver,
+	)
+	return cases
+}
@@ -0,0 +1,63 @@
+package features
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/BattlesnakeOfficial/rules"
+)
+
+// Dataset accumulates encoded samples along with their move and outcome labels.
+type Dataset struct {
+	encoder  *Encoder
+	features []float32
+	moves    []int8
+	outcomes []int8
+}
+
+// NewDataset returns an empty Dataset that encodes samples using the given encoder.
+func NewDataset(encoder *Encoder) *Dataset {
+	return &Dataset{encoder: encoder}
+}
+
+// Len returns the number of samples in the dataset.
+func (d *Dataset) Len() int {
+	return len(d.moves)
+}
+
+// Add encodes the board state from the perspective of snakeID and appends it to the dataset,
+// labelled with the move the snake made and the final outcome of the game for that snake.
+func (d *Dataset) Add(b *rules.BoardState, snakeID string, move string, outcome int) error {
+	moveIndex := MoveIndex(move)
+	if moveIndex < 0 {
+		return fmt.Errorf("invalid move %q", move)
+	}
+	if outcome < OutcomeLoss || outcome > OutcomeWin {
+		return fmt.Errorf("invalid outcome %d", outcome)
+	}
+
+	start := len(d.features)
+	d.features = append(d.features, make([]float32, d.encoder.Size())...)
+	if err := d.encoder.EncodeInto(d.features[start:], b, snakeID); err != nil {
+		d.features = d.features[:start]
+		return err
+	}
+	d.moves = append(d.moves, int8(moveIndex))
+	d.outcomes = append(d.outcomes, int8(outcome))
+	return nil
+}
+
+// WriteNPY writes the dataset as three NPY arrays:
+//   - features: float32 with shape (N, NumPlanes, Height, Width)
+//   - moves: int8 with shape (N,), indexes into Moves
+//   - outcomes: int8 with shape (N,), one of OutcomeLoss, OutcomeDraw or OutcomeWin
+func (d *Dataset) WriteNPY(features, moves, outcomes io.Writer) error {
+	n := d.Len()
+	if err := WriteNPY(features, []int{n, NumPlanes, d.encoder.Height, d.encoder.Width}, d.features); err != nil {
+		return err
+	}
+	if err := WriteNPY(moves, []int{n}, d.moves); err != nil {
+		return err
+	}
+	return WriteNPY(outcomes, []int{n}, d.outcomes)
+}
@@ -0,0 +1,168 @@
+// Package features converts game states into fixed-size feature planes that
+// can be used to train machine learning models on Battlesnake games.
+//
+// Each sample is encoded from the perspective of a single snake as a stack of
+// NumPlanes planes, each Width x Height cells in size. Values are stored in
+// row-major order as [plane][y][x], with y = 0 at the bottom of the board to
+// match the coordinate system used by the rules engine. Boards smaller than
+// the encoder size are padded, and PlaneOnBoard marks which cells are real.
+package features
+
+import (
+	"fmt"
+
+	"github.com/BattlesnakeOfficial/rules"
+)
+
+// Feature plane indices.
+const (
+	PlaneOnBoard     = iota // 1 for every cell that is part of the board
+	PlaneOwnHead            // 1 at the head of the perspective snake
+	PlaneOwnBody            // 1 for every body segment of the perspective snake, excluding the head
+	PlaneOwnTail            // 1 at the tail of the perspective snake
+	PlaneOwnHealth          // perspective snake health / SnakeMaxHealth, on every board cell
+	PlaneEnemyHead          // 1 at the head of every other snake
+	PlaneEnemyBody          // 1 for every body segment of every other snake, excluding heads
+	PlaneEnemyHealth        // health / SnakeMaxHealth of other snakes, at their heads
+	PlaneFood               // 1 for every food
+	PlaneHazard             // 1 for every cell containing at least one hazard
+	PlaneHazardStack        // number of hazards stacked on each cell
+
+	NumPlanes
+)
+
+// Move labels, in the order used by MoveIndex.
+var Moves = []string{rules.MoveUp, rules.MoveDown, rules.MoveLeft, rules.MoveRight}
+
+// Outcome labels for a snake at the end of a game.
+const (
+	OutcomeLoss = -1
+	OutcomeDraw = 0
+	OutcomeWin  = 1
+)
+
+// MoveIndex returns the label index of a move, or -1 if the move is not recognised.
+func MoveIndex(move string) int {
+	for i, m := range Moves {
+		if m == move {
+			return i
+		}
+	}
+	return -1
+}
+
+// Encoder encodes board states into feature planes of a fixed size.
+type Encoder struct {
+	Width  int
+	Height int
+}
+
+// NewEncoder returns an Encoder producing planes of the given size.
+func NewEncoder(width, height int) *Encoder {
+	return &Encoder{Width: width, Height: height}
+}
+
+// Size returns the number of values in a single encoded sample.
+func (e *Encoder) Size() int {
+	return NumPlanes * e.Width * e.Height
+}
+
+// Encode returns the feature planes for the given board state from the perspective of snakeID.
+// Eliminated snakes other than the perspective snake are ignored.
+func (e *Encoder) Encode(b *rules.BoardState, snakeID string) ([]float32, error) {
+	planes := make([]float32, e.Size())
+	if err := e.EncodeInto(planes, b, snakeID); err != nil {
+		return nil, err
+	}
+	return planes, nil
+}
+
+// EncodeInto writes the feature planes for the given board state into dst, which must be exactly Size() long.
+func (e *Encoder) EncodeInto(dst []float32, b *rules.BoardState, snakeID string) error {
+	if len(dst) != e.Size() {
+		return fmt.Errorf("destination has length %d, expected %d", len(dst), e.Size())
+	}
+	if b.Width > e.Width || b.Height > e.Height {
+		return fmt.Errorf("board size %dx%d is larger than encoder size %dx%d", b.Width, b.Height, e.Width, e.Height)
+	}
+
+	var you *rules.Snake
+	for i := range b.Snakes {
+		if b.Snakes[i].ID == snakeID {
+			you = &b.Snakes[i]
+			break
+		}
+	}
+	if you == nil {
+		return fmt.Errorf("snake %q not found", snakeID)
+	}
+	if len(you.Body) == 0 {
+		return rules.ErrorZeroLengthSnake
+	}
+
+	for i := range dst {
+		dst[i] = 0
+	}
+
+	ownHealth := float32(you.Health) / rules.SnakeMaxHealth
+	for x := 0; x < b.Width; x++ {
+		for y := 0; y < b.Height; y++ {
+			dst[e.index(PlaneOnBoard, x, y)] = 1
+			dst[e.index(PlaneOwnHealth, x, y)] = ownHealth
+		}
+	}
+
+	for i, p := range you.Body {
+		if !e.onBoard(b, p) {
+			continue
+		}
+		if i == 0 {
+			dst[e.index(PlaneOwnHead, p.X, p.Y)] = 1
+		} else {
+			dst[e.index(PlaneOwnBody, p.X, p.Y)] = 1
+		}
+	}
+	if tail := you.Body[len(you.Body)-1]; e.onBoard(b, tail) {
+		dst[e.index(PlaneOwnTail, tail.X, tail.Y)] = 1
+	}
+
+	for _, snake := range b.Snakes {
+		if snake.ID == snakeID || snake.EliminatedCause != rules.NotEliminated {
+			continue
+		}
+		for i, p := range snake.Body {
+			if !e.onBoard(b, p) {
+				continue
+			}
+			if i == 0 {
+				dst[e.index(PlaneEnemyHead, p.X, p.Y)] = 1
+				dst[e.index(PlaneEnemyHealth, p.X, p.Y)] = float32(snake.Health) / rules.SnakeMaxHealth
+			} else {
+				dst[e.index(PlaneEnemyBody, p.X, p.Y)] = 1
+			}
+		}
+	}
+
+	for _, p := range b.Food {
+		if e.onBoard(b, p) {
+			dst[e.index(PlaneFood, p.X, p.Y)] = 1
+		}
+	}
+
+	for _, p := range b.Hazards {
+		if e.onBoard(b, p) {
+			dst[e.index(PlaneHazard, p.X, p.Y)] = 1
+			dst[e.index(PlaneHazardStack, p.X, p.Y)] += 1
+		}
+	}
+
+	return nil
+}
+
+func (e *Encoder) index(plane, x, y int) int {
+	return (plane*e.Height+y)*e.Width + x
+}
+
+func (e *Encoder) onBoard(b *rules.BoardState, p rules.Point) bool {
+	return p.X >= 0 && p.Y >= 0 && p.X < b.Width && p.Y < b.Height
+}
@@ -0,0 +1,93 @@
+package features
+
+import (
+	"testing"
+
+	"github.com/BattlesnakeOfficial/rules"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMoveIndex(t *testing.T) {
+	require.Equal(t, 0, MoveIndex(rules.MoveUp))
+	require.Equal(t, 1, MoveIndex(rules.MoveDown))
+	require.Equal(t, 2, MoveIndex(rules.MoveLeft))
+	require.Equal(t, 3, MoveIndex(rules.MoveRight))
+	require.Equal(t, -1, MoveIndex("north"))
+}
+
+func TestEncode(t *testing.T) {
+	boardState := rules.NewBoardState(3, 2).
+		WithFood([]rules.Point{{X: 2, Y: 1}}).
+		WithHazards([]rules.Point{{X: 0, Y: 1}, {X: 0, Y: 1}, {X: 1, Y: 1}}).
+		WithSnakes([]rules.Snake{
+			{ID: "one", Health: 50, Body: []rules.Point{{X: 0, Y: 0}, {X: 1, Y: 0}, {X: 2, Y: 0}}},
+			{ID: "two", Health: 25, Body: []rules.Point{{X: 1, Y: 1}, {X: 2, Y: 1}}},
+			{ID: "dead", Health: 0, Body: []rules.Point{{X: 2, Y: 1}}, EliminatedCause: rules.EliminatedByCollision},
+		})
+
+	encoder := NewEncoder(4, 3)
+	planes, err := encoder.Encode(boardState, "one")
+	require.NoError(t, err)
+	require.Len(t, planes, NumPlanes*4*3)
+
+	// plane returns the rows of a plane from top to bottom to make the expected values readable
+	plane := func(index int) [][]float32 {
+		rows := [][]float32{}
+		for y := encoder.Height - 1; y >= 0; y-- {
+			start := (index*encoder.Height + y) * encoder.Width
+			rows = append(rows, planes[start:start+encoder.Width])
+		}
+		return rows
+	}
+
+	require.Equal(t, [][]float32{{0, 0, 0, 0}, {1, 1, 1, 0}, {1, 1, 1, 0}}, plane(PlaneOnBoard))
+	require.Equal(t, [][]float32{{0, 0, 0, 0}, {0, 0, 0, 0}, {1, 0, 0, 0}}, plane(PlaneOwnHead))
+	require.Equal(t, [][]float32{{0, 0, 0, 0}, {0, 0, 0, 0}, {0, 1, 1, 0}}, plane(PlaneOwnBody))
+	require.Equal(t, [][]float32{{0, 0, 0, 0}, {0, 0, 0, 0}, {0, 0, 1, 0}}, plane(PlaneOwnTail))
+	require.Equal(t, [][]float32{{0, 0, 0, 0}, {0.5, 0.5, 0.5, 0}, {0.5, 0.5, 0.5, 0}}, plane(PlaneOwnHealth))
+	require.Equal(t, [][]float32{{0, 0, 0, 0}, {0, 1, 0, 0}, {0, 0, 0, 0}}, plane(PlaneEnemyHead))
+	require.Equal(t, [][]float32{{0, 0, 0, 0}, {0, 0, 1, 0}, {0, 0, 0, 0}}, plane(PlaneEnemyBody))
+	require.Equal(t, [][]float32{{0, 0, 0, 0}, {0, 0.25, 0, 0}, {0, 0, 0, 0}}, plane(PlaneEnemyHealth))
+	require.Equal(t, [][]float32{{0, 0, 0, 0}, {0, 0, 1, 0}, {0, 0, 0, 0}}, plane(PlaneFood))
+	require.Equal(t, [][]float32{{0, 0, 0, 0}, {1, 1, 0, 0}, {0, 0, 0, 0}}, plane(PlaneHazard))
+	require.Equal(t, [][]float32{{0, 0, 0, 0}, {2, 1, 0, 0}, {0, 0, 0, 0}}, plane(PlaneHazardStack))
+
+	// Encoding from the other snake's perspective swaps own and enemy planes
+	planes, err = encoder.Encode(boardState, "two")
+	require.NoError(t, err)
+	require.Equal(t, [][]float32{{0, 0, 0, 0}, {0, 1, 0, 0}, {0, 0, 0, 0}}, plane(PlaneOwnHead))
+	require.Equal(t, [][]float32{{0, 0, 0, 0}, {0, 0, 0, 0}, {1, 0, 0, 0}}, plane(PlaneEnemyHead))
+}
+
+func TestEncodeErrors(t *testing.T) {
+	boardState := rules.NewBoardState(11, 11).WithSnakes([]rules.Snake{
+		{ID: "one", Body: []rules.Point{{X: 1, Y: 1}}},
+		{ID: "empty", Body: []rules.Point{}},
+	})
+
+	_, err := NewEncoder(7, 7).Encode(boardState, "one")
+	require.EqualError(t, err, "board size 11x11 is larger than encoder size 7x7")
+
+	_, err = NewEncoder(11, 11).Encode(boardState, "missing")
+	require.EqualError(t, err, `snake "missing" not found`)
+
+	_, err = NewEncoder(11, 11).Encode(boardState, "empty")
+	require.Equal(t, rules.ErrorZeroLengthSnake, err)
+}
+
+func TestDataset(t *testing.T) {
+	boardState := rules.NewBoardState(2, 2).WithSnakes([]rules.Snake{
+		{ID: "one", Health: 100, Body: []rules.Point{{X: 0, Y: 0}}},
+	})
+	dataset := NewDataset(NewEncoder(2, 2))
+
+	require.NoError(t, dataset.Add(boardState, "one", rules.MoveRight, OutcomeWin))
+	require.NoError(t, dataset.Add(boardState, "one", rules.MoveUp, OutcomeLoss))
+	require.EqualError(t, dataset.Add(boardState, "one", "north", OutcomeLoss), `invalid move "north"`)
+	require.EqualError(t, dataset.Add(boardState, "one", rules.MoveUp, 2), "invalid outcome 2")
+	require.Error(t, dataset.Add(boardState, "missing", rules.MoveUp, OutcomeDraw))
+	require.Equal(t, 2, dataset.Len())
+	require.Len(t, dataset.features, 2*NumPlanes*2*2)
+	require.Equal(t, []int8{3, 0}, dataset.moves)
+	require.Equal(t, []int8{1, -1}, dataset.outcomes)
+}
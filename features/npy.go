@@ -0,0 +1,64 @@
+package features
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// WriteNPY writes data as a version 1.0 NPY array with the given shape.
+// Supported data types are []float32 (dtype '<f4') and []int8 (dtype '|i1').
+//
+// The format is documented at https://numpy.org/doc/stable/reference/generated/numpy.lib.format.html
+func WriteNPY(w io.Writer, shape []int, data interface{}) error {
+	var descr string
+	var length int
+	switch d := data.(type) {
+	case []float32:
+		descr, length = "<f4", len(d)
+	case []int8:
+		descr, length = "|i1", len(d)
+	default:
+		return fmt.Errorf("unsupported NPY data type %T", data)
+	}
+
+	expected := 1
+	dims := make([]string, len(shape))
+	for i, s := range shape {
+		expected *= s
+		dims[i] = fmt.Sprint(s)
+	}
+	if expected != length {
+		return fmt.Errorf("shape %v requires %d values, got %d", shape, expected, length)
+	}
+
+	shapeText := strings.Join(dims, ", ")
+	if len(shape) == 1 {
+		shapeText += ","
+	}
+	header := fmt.Sprintf("{'descr': '%s', 'fortran_order': False, 'shape': (%s), }", descr, shapeText)
+
+	// The magic string, version, header length and header must be padded with spaces
+	// and a trailing newline to a multiple of 64 bytes.
+	const preambleLength = 10
+	padding := 64 - (preambleLength+len(header)+1)%64
+	if padding == 64 {
+		padding = 0
+	}
+	header += strings.Repeat(" ", padding) + "\n"
+
+	var buf bytes.Buffer
+	buf.WriteString("\x93NUMPY")
+	buf.Write([]byte{1, 0})
+	if err := binary.Write(&buf, binary.LittleEndian, uint16(len(header))); err != nil {
+		return err
+	}
+	buf.WriteString(header)
+	if _, err := w.Write(buf.Bytes()); err != nil {
+		return err
+	}
+
+	return binary.Write(w, binary.LittleEndian, data)
+}
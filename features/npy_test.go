@@ -0,0 +1,47 @@
+package features
+
+import (
+	"bytes"
+	"encoding/binary"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriteNPY(t *testing.T) {
+	var buf bytes.Buffer
+	err := WriteNPY(&buf, []int{2, 3}, []float32{1, 2, 3, 4, 5, 6})
+	require.NoError(t, err)
+
+	data := buf.Bytes()
+	require.Equal(t, "\x93NUMPY\x01\x00", string(data[:8]))
+	headerLength := int(binary.LittleEndian.Uint16(data[8:10]))
+	require.Zero(t, (10+headerLength)%64, "header should be aligned to 64 bytes")
+
+	header := string(data[10 : 10+headerLength])
+	require.Contains(t, header, "{'descr': '<f4', 'fortran_order': False, 'shape': (2, 3), }")
+	require.Equal(t, byte('\n'), header[len(header)-1])
+
+	values := make([]float32, 6)
+	require.NoError(t, binary.Read(bytes.NewReader(data[10+headerLength:]), binary.LittleEndian, values))
+	require.Equal(t, []float32{1, 2, 3, 4, 5, 6}, values)
+}
+
+func TestWriteNPYOneDimension(t *testing.T) {
+	var buf bytes.Buffer
+	err := WriteNPY(&buf, []int{3}, []int8{-1, 0, 1})
+	require.NoError(t, err)
+
+	data := buf.Bytes()
+	headerLength := int(binary.LittleEndian.Uint16(data[8:10]))
+	require.Contains(t, string(data[10:10+headerLength]), "'descr': '|i1'")
+	require.Contains(t, string(data[10:10+headerLength]), "'shape': (3,)")
+	require.Equal(t, []byte{0xff, 0, 1}, data[10+headerLength:])
+}
+
+func TestWriteNPYErrors(t *testing.T) {
+	var buf bytes.Buffer
+	require.EqualError(t, WriteNPY(&buf, []int{2}, []float32{1}), "shape [2] requires 2 values, got 1")
+	require.EqualError(t, WriteNPY(&buf, []int{1}, []int{1}), "unsupported NPY data type []int")
+	require.Zero(t, buf.Len())
+}
@@ -0,0 +1,258 @@
+package rules
+
+import (
+	"fmt"
+	"sort"
+)
+
+// FoodSpawner decides how much food spawns each turn, and where it goes. Maps and ruleset stages
+// look up the spawner chosen for a game with GetFoodSpawner, so that new ways of spawning food can
+// be tried out with any map.
+type FoodSpawner interface {
+	// SpawnFood returns the points to add food to this turn, chosen from positions, which are the
+	// free points that the caller allows food on. The positions slice may be modified.
+	SpawnFood(rand Rand, b *BoardState, settings Settings, positions []Point) []Point
+}
+
+// Food spawner names, used as the value of ParamFoodSpawner.
+const (
+	FoodSpawnerUniform   = "uniform"
+	FoodSpawnerFair      = "fair"
+	FoodSpawnerClustered = "clustered"
+	FoodSpawnerCenter    = "center"
+	FoodSpawnerWaves     = "waves"
+)
+
+var foodSpawners = map[string]FoodSpawner{
+	FoodSpawnerUniform:   UniformFoodSpawner{},
+	FoodSpawnerFair:      FairFoodSpawner{},
+	FoodSpawnerClustered: ClusteredFoodSpawner{},
+	FoodSpawnerCenter:    CenterFoodSpawner{},
+	FoodSpawnerWaves:     WaveFoodSpawner{},
+}
+
+// RegisterFoodSpawner adds a food spawner that can be chosen with ParamFoodSpawner.
+// It will panic if a spawner has already been registered with the same name.
+func RegisterFoodSpawner(name string, spawner FoodSpawner) {
+	if _, ok := foodSpawners[name]; ok {
+		panic(RulesetError(fmt.Sprintf("food spawner '%s' has already been registered", name)))
+	}
+	foodSpawners[name] = spawner
+}
+
+// FoodSpawnerNames returns the names of all the registered food spawners, in alphabetical order.
+func FoodSpawnerNames() []string {
+	names := make([]string, 0, len(foodSpawners))
+	for name := range foodSpawners {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// GetFoodSpawner returns the food spawner chosen in the settings, which is the uniform spawner
+// when none is chosen. An error is returned if the spawner is unknown.
+func GetFoodSpawner(settings Settings) (FoodSpawner, error) {
+	name := settings.String(ParamFoodSpawner, FoodSpawnerUniform)
+	if name == "" {
+		name = FoodSpawnerUniform
+	}
+	spawner, ok := foodSpawners[name]
+	if !ok {
+		return nil, RulesetError("unknown food spawner: " + name)
+	}
+	return spawner, nil
+}
+
+// FoodNeeded returns how much food should spawn this turn: enough to bring the board up to
+// ParamMinimumFood, or otherwise one food with a ParamFoodSpawnChance percent chance.
+func FoodNeeded(rand Rand, settings Settings, b *BoardState) int {
+	minFood := settings.Int(ParamMinimumFood, 0)
+	foodSpawnChance := settings.Int(ParamFoodSpawnChance, 0)
+	numCurrentFood := len(b.Food)
+
+	if numCurrentFood < minFood {
+		return minFood - numCurrentFood
+	}
+	if foodSpawnChance > 0 && (100-rand.Intn(100)) < foodSpawnChance {
+		return 1
+	}
+
+	return 0
+}
+
+// UniformFoodSpawner spawns FoodNeeded food, with every position equally likely.
+// This is how food spawns in standard games.
+type UniformFoodSpawner struct{}
+
+func (UniformFoodSpawner) SpawnFood(rand Rand, b *BoardState, settings Settings, positions []Point) []Point {
+	return pickFoodRandomly(rand, FoodNeeded(rand, settings, b), positions)
+}
+
+// FairFoodSpawner spawns FoodNeeded food on the positions that are closest to being the same
+// distance from every snake, so that no snake has a head start on reaching it.
+type FairFoodSpawner struct{}
+
+func (FairFoodSpawner) SpawnFood(rand Rand, b *BoardState, settings Settings, positions []Point) []Point {
+	return pickFoodFairly(rand, b, FoodNeeded(rand, settings, b), positions)
+}
+
+// pickFoodFairly picks n of the positions that are closest to being the same distance from every
+// snake still in the game, or picks randomly when there aren't at least two snakes.
+func pickFoodFairly(rand Rand, b *BoardState, n int, positions []Point) []Point {
+	heads := aliveHeads(b)
+	if len(heads) < 2 {
+		return pickFoodRandomly(rand, n, positions)
+	}
+
+	unfairness := func(p Point) int {
+		nearest, furthest := -1, -1
+		for _, head := range heads {
+			d := getDistanceBetweenPoints(p, head)
+			if nearest < 0 || d < nearest {
+				nearest = d
+			}
+			if d > furthest {
+				furthest = d
+			}
+		}
+		return furthest - nearest
+	}
+	return pickFoodByScore(rand, n, positions, unfairness)
+}
+
+// ClusteredFoodSpawner spawns FoodNeeded food next to food that's already on the board when it
+// can, so that food collects in clusters.
+type ClusteredFoodSpawner struct{}
+
+func (ClusteredFoodSpawner) SpawnFood(rand Rand, b *BoardState, settings Settings, positions []Point) []Point {
+	n := FoodNeeded(rand, settings, b)
+	food := append([]Point{}, b.Food...)
+	var spawned []Point
+	for i := 0; i < n && len(positions) > 0; i++ {
+		var clustered []int
+		for j, p := range positions {
+			for _, f := range food {
+				if getDistanceBetweenPoints(p, f) == 1 {
+					clustered = append(clustered, j)
+					break
+				}
+			}
+		}
+
+		var chosen int
+		if len(clustered) > 0 {
+			chosen = clustered[rand.Intn(len(clustered))]
+		} else {
+			chosen = rand.Intn(len(positions))
+		}
+		p := positions[chosen]
+		spawned = append(spawned, p)
+		food = append(food, p)
+		positions = append(positions[:chosen], positions[chosen+1:]...)
+	}
+	return spawned
+}
+
+// CenterFoodSpawner spawns FoodNeeded food anywhere, but more likely the closer a position is to
+// the center of the board. The center is about twice as likely as the points furthest from it.
+type CenterFoodSpawner struct{}
+
+func (CenterFoodSpawner) SpawnFood(rand Rand, b *BoardState, settings Settings, positions []Point) []Point {
+	n := FoodNeeded(rand, settings, b)
+	// Distances are doubled so that the center of boards with an even size is exact
+	maxDistance := (b.Width - 1) + (b.Height - 1)
+	weight := func(p Point) int {
+		return 2*maxDistance - absInt(2*p.X-(b.Width-1)) - absInt(2*p.Y-(b.Height-1)) + 1
+	}
+
+	var spawned []Point
+	for i := 0; i < n && len(positions) > 0; i++ {
+		total := 0
+		for _, p := range positions {
+			total += weight(p)
+		}
+		target := rand.Intn(total)
+		chosen := 0
+		for j, p := range positions {
+			target -= weight(p)
+			if target < 0 {
+				chosen = j
+				break
+			}
+		}
+		spawned = append(spawned, positions[chosen])
+		positions = append(positions[:chosen], positions[chosen+1:]...)
+	}
+	return spawned
+}
+
+// WaveFoodSpawner only keeps the board at ParamMinimumFood between waves, and spawns a wave of
+// ParamFoodWaveSize food every ParamFoodWaveEveryNTurns turns. Waves default to one food for
+// every snake still in the game, every 20 turns.
+type WaveFoodSpawner struct{}
+
+func (WaveFoodSpawner) SpawnFood(rand Rand, b *BoardState, settings Settings, positions []Point) []Point {
+	n := settings.Int(ParamMinimumFood, 0) - len(b.Food)
+	if n < 0 {
+		n = 0
+	}
+
+	every := settings.Int(ParamFoodWaveEveryNTurns, 20)
+	// Food spawned now is first seen on the next turn
+	if every > 0 && (b.Turn+1)%every == 0 {
+		size := settings.Int(ParamFoodWaveSize, 0)
+		if size <= 0 {
+			size = len(aliveHeads(b))
+		}
+		n += size
+	}
+	return pickFoodRandomly(rand, n, positions)
+}
+
+// pickFoodRandomly returns n positions chosen at random, or all of them if there are fewer.
+func pickFoodRandomly(rand Rand, n int, positions []Point) []Point {
+	if n <= 0 {
+		return nil
+	}
+	if len(positions) < n {
+		n = len(positions)
+	}
+	rand.Shuffle(len(positions), func(i int, j int) {
+		positions[i], positions[j] = positions[j], positions[i]
+	})
+	return append([]Point{}, positions[:n]...)
+}
+
+// pickFoodByScore returns n positions, each time choosing at random between the positions with
+// the lowest score.
+func pickFoodByScore(rand Rand, n int, positions []Point, score func(Point) int) []Point {
+	var spawned []Point
+	for i := 0; i < n && len(positions) > 0; i++ {
+		var best []int
+		bestScore := 0
+		for j, p := range positions {
+			s := score(p)
+			if len(best) == 0 || s < bestScore {
+				best, bestScore = []int{j}, s
+			} else if s == bestScore {
+				best = append(best, j)
+			}
+		}
+		chosen := best[rand.Intn(len(best))]
+		spawned = append(spawned, positions[chosen])
+		positions = append(positions[:chosen], positions[chosen+1:]...)
+	}
+	return spawned
+}
+
+// aliveHeads returns the heads of the snakes that are still in the game.
+func aliveHeads(b *BoardState) []Point {
+	var heads []Point
+	for _, snake := range b.Snakes {
+		if snake.EliminatedCause == NotEliminated && len(snake.Body) > 0 {
+			heads = append(heads, snake.Body[0])
+		}
+	}
+	return heads
+}
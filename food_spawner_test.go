@@ -0,0 +1,165 @@
+package rules
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetFoodSpawner(t *testing.T) {
+	spawner, err := GetFoodSpawner(Settings{})
+	require.NoError(t, err)
+	require.Equal(t, UniformFoodSpawner{}, spawner)
+
+	spawner, err = GetFoodSpawner(NewSettingsWithParams(ParamFoodSpawner, ""))
+	require.NoError(t, err)
+	require.Equal(t, UniformFoodSpawner{}, spawner)
+
+	spawner, err = GetFoodSpawner(NewSettingsWithParams(ParamFoodSpawner, FoodSpawnerFair))
+	require.NoError(t, err)
+	require.Equal(t, FairFoodSpawner{}, spawner)
+
+	_, err = GetFoodSpawner(NewSettingsWithParams(ParamFoodSpawner, "nope"))
+	require.EqualError(t, err, "unknown food spawner: nope")
+
+	require.Equal(t, []string{"center", "clustered", "fair", "uniform", "waves"}, FoodSpawnerNames())
+}
+
+type stubFoodSpawner []Point
+
+func (s stubFoodSpawner) SpawnFood(rand Rand, b *BoardState, settings Settings, positions []Point) []Point {
+	return s
+}
+
+func TestRegisterFoodSpawner(t *testing.T) {
+	require.Panics(t, func() {
+		RegisterFoodSpawner(FoodSpawnerUniform, UniformFoodSpawner{})
+	})
+
+	RegisterFoodSpawner(t.Name(), stubFoodSpawner{{X: 1, Y: 1}})
+	defer delete(foodSpawners, t.Name())
+
+	b := NewBoardState(3, 3)
+	_, err := SpawnFoodStandard(b, NewSettingsWithParams(ParamFoodSpawner, t.Name()), mockSnakeMoves())
+	require.NoError(t, err)
+	require.Equal(t, []Point{{X: 1, Y: 1}}, b.Food)
+}
+
+func TestFoodNeeded(t *testing.T) {
+	b := NewBoardState(3, 3).WithFood([]Point{{X: 0, Y: 0}})
+	require.Equal(t, 2, FoodNeeded(MinRand, NewSettingsWithParams(ParamMinimumFood, "3"), b))
+	require.Equal(t, 0, FoodNeeded(MinRand, NewSettingsWithParams(ParamMinimumFood, "1", ParamFoodSpawnChance, "50"), b))
+	require.Equal(t, 1, FoodNeeded(MaxRand, NewSettingsWithParams(ParamMinimumFood, "1", ParamFoodSpawnChance, "50"), b))
+	require.Equal(t, 0, FoodNeeded(MaxRand, NewSettingsWithParams(ParamMinimumFood, "1", ParamFoodSpawnChance, "0"), b))
+}
+
+func TestUniformFoodSpawner(t *testing.T) {
+	b := NewBoardState(2, 2)
+	settings := NewSettingsWithParams(ParamMinimumFood, "2")
+	positions := []Point{{X: 0, Y: 0}, {X: 0, Y: 1}, {X: 1, Y: 0}}
+	require.Equal(t, []Point{{X: 0, Y: 0}, {X: 0, Y: 1}}, UniformFoodSpawner{}.SpawnFood(MinRand, b, settings, positions))
+
+	settings = NewSettingsWithParams(ParamMinimumFood, "5")
+	positions = []Point{{X: 0, Y: 0}, {X: 0, Y: 1}}
+	require.Len(t, UniformFoodSpawner{}.SpawnFood(MinRand, b, settings, positions), 2, "no more food than positions")
+}
+
+func TestFairFoodSpawner(t *testing.T) {
+	b := NewBoardState(11, 11).WithSnakes([]Snake{
+		{ID: "one", Body: []Point{{X: 0, Y: 0}}},
+		{ID: "two", Body: []Point{{X: 10, Y: 0}}},
+		{ID: "three", Body: []Point{{X: 10, Y: 10}}, EliminatedCause: EliminatedByOutOfHealth},
+	})
+	settings := NewSettingsWithParams(ParamMinimumFood, "2")
+
+	// (5, 5) is 10 moves from both snakes, and (9, 9) is 18 and 10 moves away
+	positions := []Point{{X: 0, Y: 5}, {X: 5, Y: 5}, {X: 9, Y: 9}}
+	require.Equal(t, []Point{{X: 5, Y: 5}, {X: 9, Y: 9}}, FairFoodSpawner{}.SpawnFood(MinRand, b, settings, positions))
+
+	// Falls back to uniform placement without two snakes to be fair between
+	b.Snakes = b.Snakes[:1]
+	positions = []Point{{X: 0, Y: 5}, {X: 5, Y: 5}, {X: 9, Y: 9}}
+	require.Equal(t, []Point{{X: 0, Y: 5}, {X: 5, Y: 5}}, FairFoodSpawner{}.SpawnFood(MinRand, b, settings, positions))
+}
+
+func TestClusteredFoodSpawner(t *testing.T) {
+	b := NewBoardState(11, 11).WithFood([]Point{{X: 5, Y: 5}})
+	settings := NewSettingsWithParams(ParamMinimumFood, "3")
+
+	positions := []Point{{X: 0, Y: 0}, {X: 5, Y: 7}, {X: 5, Y: 6}, {X: 9, Y: 9}}
+	require.Equal(t, []Point{{X: 5, Y: 6}, {X: 5, Y: 7}}, ClusteredFoodSpawner{}.SpawnFood(MinRand, b, settings, positions))
+
+	b = NewBoardState(11, 11)
+	positions = []Point{{X: 0, Y: 0}, {X: 5, Y: 7}, {X: 9, Y: 9}}
+	require.Equal(t, []Point{{X: 0, Y: 0}}, ClusteredFoodSpawner{}.SpawnFood(MinRand, b, NewSettingsWithParams(ParamMinimumFood, "1"), positions), "no food to cluster around")
+}
+
+func TestCenterFoodSpawner(t *testing.T) {
+	b := NewBoardState(11, 11)
+	settings := NewSettingsWithParams(ParamMinimumFood, "1")
+	rand := NewSeedRand(42)
+
+	center := 0
+	for i := 0; i < 1000; i++ {
+		spawned := CenterFoodSpawner{}.SpawnFood(rand, b, settings, []Point{{X: 0, Y: 0}, {X: 5, Y: 5}})
+		require.Len(t, spawned, 1)
+		if spawned[0] == (Point{X: 5, Y: 5}) {
+			center++
+		}
+	}
+	// The center is weighted 41 to the corner's 21
+	require.InDelta(t, 661, center, 50)
+
+	spawned := CenterFoodSpawner{}.SpawnFood(MinRand, NewBoardState(1, 1), settings, []Point{{X: 0, Y: 0}})
+	require.Equal(t, []Point{{X: 0, Y: 0}}, spawned)
+}
+
+func TestWaveFoodSpawner(t *testing.T) {
+	b := NewBoardState(11, 11).WithSnakes([]Snake{
+		{ID: "one", Body: []Point{{X: 0, Y: 0}}},
+		{ID: "two", Body: []Point{{X: 10, Y: 0}}},
+	})
+	positions := func() []Point {
+		return GetUnoccupiedPoints(b, false, false)
+	}
+
+	tests := []struct {
+		name     string
+		turn     int
+		food     []Point
+		settings Settings
+		expected int
+	}{
+		{"between waves", 3, nil, NewSettingsWithParams(ParamFoodWaveEveryNTurns, "5", ParamFoodSpawnChance, "100"), 0},
+		{"wave of one per snake", 4, nil, NewSettingsWithParams(ParamFoodWaveEveryNTurns, "5"), 2},
+		{"wave size", 9, nil, NewSettingsWithParams(ParamFoodWaveEveryNTurns, "5", ParamFoodWaveSize, "3"), 3},
+		{"default every 20 turns", 19, nil, Settings{}, 2},
+		{"minimum food between waves", 3, []Point{{X: 5, Y: 5}}, NewSettingsWithParams(ParamMinimumFood, "3"), 2},
+		{"minimum food and wave", 19, []Point{{X: 5, Y: 5}}, NewSettingsWithParams(ParamMinimumFood, "3"), 4},
+		{"no waves", 19, nil, NewSettingsWithParams(ParamFoodWaveEveryNTurns, "0"), 0},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			b.Turn = test.turn
+			b.Food = test.food
+			require.Len(t, WaveFoodSpawner{}.SpawnFood(MinRand, b, test.settings, positions()), test.expected)
+		})
+	}
+}
+
+func TestSpawnFoodStandardSpawner(t *testing.T) {
+	b := NewBoardState(3, 3).WithSnakes([]Snake{
+		{ID: "one", Body: []Point{{X: 0, Y: 0}}},
+		{ID: "two", Body: []Point{{X: 2, Y: 2}}},
+	})
+	settings := NewSettingsWithParams(ParamFoodSpawner, FoodSpawnerFair, ParamMinimumFood, "1", ParamFoodLifetime, "5")
+	_, err := SpawnFoodStandard(b, settings, mockSnakeMoves())
+	require.NoError(t, err)
+	require.Len(t, b.Food, 1)
+	food := b.Food[0]
+	require.Equal(t, 5, food.TTL)
+	require.Equal(t, getDistanceBetweenPoints(food, Point{X: 0, Y: 0}), getDistanceBetweenPoints(food, Point{X: 2, Y: 2}))
+
+	_, err = SpawnFoodStandard(b, NewSettingsWithParams(ParamFoodSpawner, "nope"), mockSnakeMoves())
+	require.EqualError(t, err, "unknown food spawner: nope")
+}
@@ -0,0 +1,129 @@
+package rules
+
+import (
+	"fmt"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+var fuzzGameTypes = []string{GameTypeStandard, GameTypeConstrictor, GameTypeRoyale, GameTypeWrapped, GameTypeSolo}
+
+var fuzzMoves = []string{MoveUp, MoveDown, MoveLeft, MoveRight}
+
+// fuzzGame is a game built from fuzz input.
+type fuzzGame struct {
+	ruleset Ruleset
+	state   *BoardState
+	moves   []byte
+}
+
+// newFuzzGame sets up a game with a board size, number of snakes and settings derived from the
+// fuzz input. It returns nil if the snakes can't be placed on the board.
+func newFuzzGame(t *testing.T, gameType string, seed int64, width, height, snakeCount uint8, hazardDamage int8, moves []byte) *fuzzGame {
+	w, h := 3+int(width%23), 3+int(height%23)
+	snakeIDs := make([]string, 1+int(snakeCount%8))
+	for i := range snakeIDs {
+		snakeIDs[i] = fmt.Sprintf("snake_%d", i)
+	}
+
+	state, err := CreateDefaultBoardState(NewStreamRand(seed, 0, "fuzz"), w, h, snakeIDs)
+	if err != nil {
+		return nil
+	}
+
+	ruleset := NewRulesetBuilder().
+		WithSeed(seed).
+		WithParams(map[string]string{
+			ParamFoodSpawnChance:     fmt.Sprint(seed & 0x7f),
+			ParamMinimumFood:         fmt.Sprint((seed >> 8) & 0x7),
+			ParamHazardDamagePerTurn: fmt.Sprint(hazardDamage),
+			ParamShrinkEveryNTurns:   fmt.Sprint(1 + (seed>>12)&0xf),
+		}).
+		WithSolo(len(snakeIDs) == 1).
+		NamedRuleset(gameType)
+
+	_, state, err = ruleset.Execute(state, nil)
+	require.NoError(t, err)
+	require.NoError(t, state.Validate())
+
+	return &fuzzGame{ruleset: ruleset, state: state, moves: moves}
+}
+
+// nextMoves takes a move from the fuzz input for every snake still in the game.
+// It returns false once the input runs out.
+func (g *fuzzGame) nextMoves() ([]SnakeMove, bool) {
+	moves := []SnakeMove{}
+	for _, snake := range g.state.Snakes {
+		if snake.EliminatedCause != NotEliminated {
+			continue
+		}
+		if len(g.moves) == 0 {
+			return nil, false
+		}
+		moves = append(moves, SnakeMove{ID: snake.ID, Move: fuzzMoves[int(g.moves[0])%len(fuzzMoves)]})
+		g.moves = g.moves[1:]
+	}
+	return moves, true
+}
+
+// step plays the next turn, returning false once the game is over or the input runs out.
+func (g *fuzzGame) step(t *testing.T) bool {
+	moves, ok := g.nextMoves()
+	if !ok {
+		return false
+	}
+	gameOver, state, err := g.ruleset.Execute(g.state, moves)
+	require.NoError(t, err)
+	require.NoError(t, state.Validate(), "turn %d", state.Turn)
+	state.Turn++
+	g.state = state
+	return !gameOver
+}
+
+func FuzzRulesets(f *testing.F) {
+	f.Add(int64(1), uint8(0), uint8(11), uint8(11), uint8(2), int8(14), []byte{0, 1, 2, 3, 3, 2, 1, 0, 0, 0, 0, 0})
+	f.Add(int64(2), uint8(3), uint8(7), uint8(7), uint8(4), int8(100), []byte{3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3})
+	f.Add(int64(3), uint8(2), uint8(0), uint8(0), uint8(1), int8(-20), []byte{0, 0, 0, 0, 0, 0})
+
+	f.Fuzz(func(t *testing.T, seed int64, gameType, width, height, snakeCount uint8, hazardDamage int8, moves []byte) {
+		game := newFuzzGame(t, fuzzGameTypes[int(gameType)%len(fuzzGameTypes)], seed, width, height, snakeCount, hazardDamage, moves)
+		if game == nil {
+			t.Skip("snakes don't fit on the board")
+		}
+		for game.step(t) {
+		}
+	})
+}
+
+func FuzzStages(f *testing.F) {
+	f.Add(int64(1), uint8(0), uint8(11), uint8(11), uint8(2), int8(14), []byte{0, 1, 2, 3, 3, 2, 1, 0})
+	f.Add(int64(2), uint8(5), uint8(7), uint8(7), uint8(4), int8(-10), []byte{2, 2, 2, 2, 2, 2, 2, 2})
+
+	stageNames := make([]string, 0, len(globalRegistry))
+	for name := range globalRegistry {
+		stageNames = append(stageNames, name)
+	}
+	sort.Strings(stageNames)
+
+	f.Fuzz(func(t *testing.T, seed int64, stage, width, height, snakeCount uint8, hazardDamage int8, moves []byte) {
+		// play part of a game to reach a valid board state, then run a single stage on it
+		game := newFuzzGame(t, GameTypeStandard, seed, width, height, snakeCount, hazardDamage, moves)
+		if game == nil {
+			t.Skip("snakes don't fit on the board")
+		}
+		for len(game.moves) > len(moves)/2 && game.step(t) {
+		}
+		stageMoves, ok := game.nextMoves()
+		if !ok {
+			t.Skip("not enough moves")
+		}
+
+		name := stageNames[int(stage)%len(stageNames)]
+		state := game.state.Clone()
+		_, err := globalRegistry[name](state, game.ruleset.Settings(), stageMoves)
+		require.NoError(t, err, name)
+		require.NoError(t, state.validate(false), name)
+	})
+}
@@ -28,7 +28,7 @@ func (m ArcadeMazeMap) Meta() Metadata {
 }
 
 func (m ArcadeMazeMap) SetupBoard(initialBoardState *rules.BoardState, settings rules.Settings, editor Editor) error {
-	rand := settings.GetRand(0)
+	rand := settings.GetStageRand(0, m.ID())
 
 	if initialBoardState.Width != 19 || initialBoardState.Height != 21 {
 		return rules.RulesetError("This map can only be played on a 19X21 board")
@@ -76,7 +76,7 @@ func (m ArcadeMazeMap) PreUpdateBoard(lastBoardState *rules.BoardState, settings
 }
 
 func (m ArcadeMazeMap) PostUpdateBoard(lastBoardState *rules.BoardState, settings rules.Settings, editor Editor) error {
-	rand := settings.GetRand(lastBoardState.Turn)
+	rand := settings.GetStageRand(lastBoardState.Turn, m.ID())
 
 	// Respect FoodSpawnChance setting
 	foodSpawnChance := settings.Int(rules.ParamFoodSpawnChance, 0)
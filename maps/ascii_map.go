@@ -0,0 +1,183 @@
+package maps
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/BattlesnakeOfficial/rules"
+)
+
+// Cells used in ASCII map layouts.
+const (
+	ASCIIEmpty      = '.'
+	ASCIIHazard     = '#'
+	ASCIIWall       = 'X'
+	ASCIISnakeStart = 'S'
+	ASCIIFood       = 'F'
+	ASCIIFoodSpawn  = 'f'
+)
+
+// ParseASCIIMap reads a map definition drawn as ASCII art.
+//
+// The file starts with a header of "key: value" lines for the map's id, name, author,
+// description, version, minPlayers, maxPlayers and comma-separated tags. Each layout follows as a
+// grid separated from the header and other grids by a blank line, with the top row of the grid at
+// the top of the board. The size of each grid sets the board size it's used for.
+//
+//	id: arena
+//	name: Arena
+//
+//	.S...S.
+//	...f...
+//	.F.#.F.
+//	...f...
+//	.S...S.
+//
+// Cells are '.' for empty, '#' for a hazard, 'X' for a wall, 'S' for a snake start, 'F' for food placed when the
+// game starts, and 'f' for a cell new food can spawn on.
+func ParseASCIIMap(data []byte) (MapDefinition, error) {
+	// Split the file into blocks of lines separated by blank lines
+	type block struct {
+		start int
+		lines []string
+	}
+	var blocks []block
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	lineNumber := 0
+	newBlock := true
+	for scanner.Scan() {
+		lineNumber++
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			newBlock = true
+			continue
+		}
+		if newBlock {
+			blocks = append(blocks, block{start: lineNumber})
+			newBlock = false
+		}
+		blocks[len(blocks)-1].lines = append(blocks[len(blocks)-1].lines, line)
+	}
+	if err := scanner.Err(); err != nil {
+		return MapDefinition{}, err
+	}
+	if len(blocks) == 0 {
+		return MapDefinition{}, fmt.Errorf("map is empty")
+	}
+
+	definition := MapDefinition{}
+	for i, line := range blocks[0].lines {
+		if err := definition.parseHeader(line); err != nil {
+			return MapDefinition{}, fmt.Errorf("line %d: %w", blocks[0].start+i, err)
+		}
+	}
+	for _, grid := range blocks[1:] {
+		layout, err := parseASCIILayout(grid.start, grid.lines)
+		if err != nil {
+			return MapDefinition{}, err
+		}
+		definition.Layouts = append(definition.Layouts, layout)
+	}
+
+	return definition, nil
+}
+
+func (definition *MapDefinition) parseHeader(line string) error {
+	key, value, ok := strings.Cut(line, ":")
+	if !ok {
+		return fmt.Errorf("expected \"key: value\" in header, got %q", line)
+	}
+	key, value = strings.TrimSpace(key), strings.TrimSpace(value)
+
+	var err error
+	switch key {
+	case "id":
+		definition.ID = value
+	case "name":
+		definition.Name = value
+	case "author":
+		definition.Author = value
+	case "description":
+		definition.Description = value
+	case "version":
+		definition.Version, err = strconv.Atoi(value)
+	case "minPlayers":
+		definition.MinPlayers, err = strconv.Atoi(value)
+	case "maxPlayers":
+		definition.MaxPlayers, err = strconv.Atoi(value)
+	case "tags":
+		for _, tag := range strings.Split(value, ",") {
+			if tag = strings.TrimSpace(tag); tag != "" {
+				definition.Tags = append(definition.Tags, tag)
+			}
+		}
+	default:
+		return fmt.Errorf("unknown header %q", key)
+	}
+	if err != nil {
+		return fmt.Errorf("invalid %s %q", key, value)
+	}
+	return nil
+}
+
+// parseASCIILayout reads a grid of cells, where start is the line number of the first row.
+func parseASCIILayout(start int, grid []string) (MapLayout, error) {
+	layout := MapLayout{Width: len(grid[0]), Height: len(grid)}
+	for row, line := range grid {
+		if len(line) != layout.Width {
+			return MapLayout{}, fmt.Errorf("line %d: row has %d cells, expected %d", start+row, len(line), layout.Width)
+		}
+		// The first row is the top of the board
+		y := layout.Height - 1 - row
+		for x, cell := range line {
+			p := rules.Point{X: x, Y: y}
+			switch cell {
+			case ASCIIEmpty:
+			case ASCIIHazard:
+				layout.Hazards = append(layout.Hazards, p)
+			case ASCIIWall:
+				layout.Walls = append(layout.Walls, p)
+			case ASCIISnakeStart:
+				layout.SnakeStarts = append(layout.SnakeStarts, p)
+			case ASCIIFood:
+				layout.Food = append(layout.Food, p)
+			case ASCIIFoodSpawn:
+				layout.FoodSpawns = append(layout.FoodSpawns, p)
+			default:
+				return MapLayout{}, fmt.Errorf("line %d: unknown cell %q", start+row, cell)
+			}
+		}
+	}
+	return layout, nil
+}
+
+// FormatASCIILayout draws a layout as an ASCII grid, the reverse of the layouts read by
+// ParseASCIIMap. Hazard schedules can't be drawn and are left out.
+func FormatASCIILayout(layout MapLayout) string {
+	cells := make([][]byte, layout.Height)
+	for i := range cells {
+		cells[i] = bytes.Repeat([]byte{ASCIIEmpty}, layout.Width)
+	}
+	draw := func(points []rules.Point, cell byte) {
+		for _, p := range points {
+			if isOnBoard(layout.Width, layout.Height, p.X, p.Y) {
+				cells[layout.Height-1-p.Y][p.X] = cell
+			}
+		}
+	}
+	draw(layout.FoodSpawns, ASCIIFoodSpawn)
+	draw(layout.Hazards, ASCIIHazard)
+	draw(layout.Walls, ASCIIWall)
+	draw(layout.Food, ASCIIFood)
+	draw(layout.SnakeStarts, ASCIISnakeStart)
+
+	var sb strings.Builder
+	for _, row := range cells {
+		sb.Write(row)
+		sb.WriteByte('\n')
+	}
+	return sb.String()
+}
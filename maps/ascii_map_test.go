@@ -0,0 +1,96 @@
+package maps_test
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/BattlesnakeOfficial/rules"
+	"github.com/BattlesnakeOfficial/rules/maps"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseASCIIMap(t *testing.T) {
+	asciiMap, err := maps.LoadMapFile("testdata/arena.txt")
+	require.NoError(t, err)
+	yamlMap, err := maps.LoadMapFile("testdata/arena.yaml")
+	require.NoError(t, err)
+
+	definition := asciiMap.Definition()
+	require.Equal(t, "arena", definition.ID)
+	require.Equal(t, "Arena", definition.Name)
+	require.Equal(t, "Battlesnake", definition.Author)
+	require.Equal(t, "Four corner starts around a hazard pit", definition.Description)
+	require.Equal(t, []string{maps.TAG_SNAKE_PLACEMENT, maps.TAG_FOOD_PLACEMENT, maps.TAG_HAZARD_PLACEMENT}, definition.Tags)
+	require.Len(t, definition.Layouts, 2)
+
+	// the drawn layouts match the YAML version of the map, other than the hazard schedule
+	for i, layout := range definition.Layouts {
+		expected := yamlMap.Definition().Layouts[i]
+		require.Equal(t, expected.Width, layout.Width)
+		require.Equal(t, expected.Height, layout.Height)
+		require.ElementsMatch(t, expected.SnakeStarts, layout.SnakeStarts)
+		require.ElementsMatch(t, expected.Food, layout.Food)
+		require.ElementsMatch(t, expected.FoodSpawns, layout.FoodSpawns)
+		require.ElementsMatch(t, expected.Hazards, layout.Hazards)
+	}
+	require.Equal(t, yamlMap.Meta().MaxPlayers, asciiMap.Meta().MaxPlayers)
+	require.Equal(t, yamlMap.Meta().BoardSizes, asciiMap.Meta().BoardSizes)
+}
+
+func TestParseASCIIMapOrientation(t *testing.T) {
+	definition, err := maps.ParseASCIIMap([]byte("id: test\n\nS..\n...\n..#\n"))
+	require.NoError(t, err)
+	require.Equal(t, []rules.Point{{X: 0, Y: 2}}, definition.Layouts[0].SnakeStarts)
+	require.Equal(t, []rules.Point{{X: 2, Y: 0}}, definition.Layouts[0].Hazards)
+}
+
+func TestParseASCIIMapWalls(t *testing.T) {
+	definition, err := maps.ParseASCIIMap([]byte("id: test\n\nS.X\n.#X\n...\n"))
+	require.NoError(t, err)
+	layout := definition.Layouts[0]
+	require.Equal(t, []rules.Point{{X: 2, Y: 2}, {X: 2, Y: 1}}, layout.Walls)
+	require.Equal(t, []rules.Point{{X: 1, Y: 1}}, layout.Hazards)
+	require.Equal(t, "S.X\n.#X\n...\n", maps.FormatASCIILayout(layout))
+
+	gameMap, err := maps.NewFileMap(definition)
+	require.NoError(t, err)
+	boardState := rules.NewBoardState(3, 3)
+	rules.InitializeSnakes(boardState, []string{"one"})
+	require.NoError(t, gameMap.SetupBoard(boardState, rules.NewSettings(nil), maps.NewBoardStateEditor(boardState)))
+	require.Equal(t, layout.Walls, boardState.Walls)
+}
+
+func TestParseASCIIMapErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		contents string
+		expected string
+	}{
+		{"empty", "\n\n", "map is empty"},
+		{"unknown header", "id: test\nsize: 7\n\n...\n", `line 2: unknown header "size"`},
+		{"missing colon", "id test\n", `line 1: expected "key: value" in header, got "id test"`},
+		{"invalid version", "id: test\nversion: two\n", `line 2: invalid version "two"`},
+		{"ragged rows", "id: test\n\n...\n..\n", "line 4: row has 2 cells, expected 3"},
+		{"unknown cell", "id: test\n\n...\n.x.\n", `line 4: unknown cell 'x'`},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := maps.ParseASCIIMap([]byte(test.contents))
+			require.EqualError(t, err, test.expected)
+		})
+	}
+}
+
+func TestFormatASCIILayout(t *testing.T) {
+	data, err := os.ReadFile("testdata/arena.txt")
+	require.NoError(t, err)
+	definition, err := maps.ParseASCIIMap(data)
+	require.NoError(t, err)
+
+	grids := strings.Split(string(data), "\n\n")[1:]
+	for i, layout := range definition.Layouts {
+		require.Equal(t, strings.TrimSuffix(grids[i], "\n"), strings.TrimSuffix(maps.FormatASCIILayout(layout), "\n"))
+	}
+}
@@ -10,8 +10,8 @@ func init() {
 	globalRegistry.RegisterMap("hz_castle_wall_xl", CastleWallExtraLargeHazardsMap{})
 }
 
-func setupCastleWallBoard(maxPlayers int, startingPositions []rules.Point, hazards []rules.Point, initialBoardState *rules.BoardState, settings rules.Settings, editor Editor) error {
-	rand := settings.GetRand(initialBoardState.Turn)
+func setupCastleWallBoard(mapID string, maxPlayers int, startingPositions []rules.Point, hazards []rules.Point, initialBoardState *rules.BoardState, settings rules.Settings, editor Editor) error {
+	rand := settings.GetStageRand(initialBoardState.Turn, mapID)
 
 	if len(initialBoardState.Snakes) > int(maxPlayers) {
 		return rules.ErrorTooManySnakes
@@ -33,7 +33,7 @@ func setupCastleWallBoard(maxPlayers int, startingPositions []rules.Point, hazar
 	return nil
 }
 
-func updateCastleWallBoard(maxFood int, food []rules.Point, lastBoardState *rules.BoardState, settings rules.Settings, editor Editor) error {
+func updateCastleWallBoard(mapID string, maxFood int, food []rules.Point, lastBoardState *rules.BoardState, settings rules.Settings, editor Editor) error {
 	// no food spawning for first 10 turns
 	if lastBoardState.Turn < 10 {
 		return nil
@@ -44,7 +44,7 @@ func updateCastleWallBoard(maxFood int, food []rules.Point, lastBoardState *rule
 		return nil
 	}
 
-	rand := settings.GetRand(lastBoardState.Turn)
+	rand := settings.GetStageRand(lastBoardState.Turn, mapID)
 
 	rand.Shuffle(len(food), func(i int, j int) {
 		food[i], food[j] = food[j], food[i]
@@ -133,7 +133,7 @@ func (m CastleWallMediumHazardsMap) SetupBoard(initialBoardState *rules.BoardSta
 		startPositions = append(startPositions, castleWallMediumStartPositions[1]...)
 	}
 
-	return setupCastleWallBoard(m.Meta().MaxPlayers, startPositions, castleWallMediumHazards, initialBoardState, settings, editor)
+	return setupCastleWallBoard(m.ID(), m.Meta().MaxPlayers, startPositions, castleWallMediumHazards, initialBoardState, settings, editor)
 }
 
 func (m CastleWallMediumHazardsMap) PreUpdateBoard(lastBoardState *rules.BoardState, settings rules.Settings, editor Editor) error {
@@ -142,7 +142,7 @@ func (m CastleWallMediumHazardsMap) PreUpdateBoard(lastBoardState *rules.BoardSt
 
 func (m CastleWallMediumHazardsMap) PostUpdateBoard(lastBoardState *rules.BoardState, settings rules.Settings, editor Editor) error {
 	maxFood := 2
-	return updateCastleWallBoard(maxFood, castleWallMediumFood, lastBoardState, settings, editor)
+	return updateCastleWallBoard(m.ID(), maxFood, castleWallMediumFood, lastBoardState, settings, editor)
 }
 
 var castleWallMediumStartPositions = [][]rules.Point{
@@ -229,7 +229,7 @@ func (m CastleWallLargeHazardsMap) SetupBoard(initialBoardState *rules.BoardStat
 		startPositions = append(startPositions, castleWallLargeStartPositions[1]...)
 	}
 
-	return setupCastleWallBoard(m.Meta().MaxPlayers, startPositions, castleWallLargeHazards, initialBoardState, settings, editor)
+	return setupCastleWallBoard(m.ID(), m.Meta().MaxPlayers, startPositions, castleWallLargeHazards, initialBoardState, settings, editor)
 }
 
 func (m CastleWallLargeHazardsMap) PreUpdateBoard(lastBoardState *rules.BoardState, settings rules.Settings, editor Editor) error {
@@ -238,7 +238,7 @@ func (m CastleWallLargeHazardsMap) PreUpdateBoard(lastBoardState *rules.BoardSta
 
 func (m CastleWallLargeHazardsMap) PostUpdateBoard(lastBoardState *rules.BoardState, settings rules.Settings, editor Editor) error {
 	maxFood := 2
-	return updateCastleWallBoard(maxFood, castleWallLargeFood, lastBoardState, settings, editor)
+	return updateCastleWallBoard(m.ID(), maxFood, castleWallLargeFood, lastBoardState, settings, editor)
 }
 
 var castleWallLargeStartPositions = [][]rules.Point{
@@ -425,7 +425,7 @@ func (m CastleWallExtraLargeHazardsMap) SetupBoard(initialBoardState *rules.Boar
 		startPositions = append(startPositions, castleWallExtraLargeStartPositions[2]...)
 	}
 
-	return setupCastleWallBoard(m.Meta().MaxPlayers, startPositions, castleWallExtraLargeHazards, initialBoardState, settings, editor)
+	return setupCastleWallBoard(m.ID(), m.Meta().MaxPlayers, startPositions, castleWallExtraLargeHazards, initialBoardState, settings, editor)
 }
 
 func (m CastleWallExtraLargeHazardsMap) PreUpdateBoard(lastBoardState *rules.BoardState, settings rules.Settings, editor Editor) error {
@@ -434,7 +434,7 @@ func (m CastleWallExtraLargeHazardsMap) PreUpdateBoard(lastBoardState *rules.Boa
 
 func (m CastleWallExtraLargeHazardsMap) PostUpdateBoard(lastBoardState *rules.BoardState, settings rules.Settings, editor Editor) error {
 	maxFood := 4
-	return updateCastleWallBoard(maxFood, castleWallExtraLargeFood, lastBoardState, settings, editor)
+	return updateCastleWallBoard(m.ID(), maxFood, castleWallExtraLargeFood, lastBoardState, settings, editor)
 }
 
 var castleWallExtraLargeStartPositions = [][]rules.Point{
@@ -0,0 +1,206 @@
+package maps
+
+import (
+	"fmt"
+
+	"github.com/BattlesnakeOfficial/rules"
+	"github.com/BattlesnakeOfficial/rules/analysis"
+)
+
+// CavesMap generates a new layout of hazard caves for every game with cellular automata.
+type CavesMap struct{}
+
+var (
+	cavesDensity = Param{
+		Name:        "density",
+		Type:        ParamTypeInt,
+		Default:     "45",
+		Description: "Percentage of cells that start as hazards before the caves are smoothed",
+	}
+	cavesSmoothing = Param{
+		Name:        "smoothing",
+		Type:        ParamTypeInt,
+		Default:     "4",
+		Description: "Number of times the caves are smoothed. More smoothing gives rounder caves",
+	}
+)
+
+func init() {
+	globalRegistry.RegisterMap("hz_caves", CavesMap{})
+}
+
+func (m CavesMap) ID() string {
+	return "hz_caves"
+}
+
+func (m CavesMap) Meta() Metadata {
+	return Metadata{
+		Name:        "Caves",
+		Description: "Generates organic caves of hazards from the game seed. Every cell outside the hazards can be reached, and the caves are the same when the board is rotated so that every snake gets an equal start.",
+		Author:      "Battlesnake",
+		Version:     1,
+		MinPlayers:  1,
+		MaxPlayers:  4,
+		BoardSizes:  OddSizes(rules.BoardSizeSmall, rules.BoardSizeXXLarge),
+		Tags:        []string{TAG_HAZARD_PLACEMENT, TAG_SNAKE_PLACEMENT},
+		Params:      []Param{cavesDensity, cavesSmoothing},
+	}
+}
+
+func (m CavesMap) SetupBoard(initialBoardState *rules.BoardState, settings rules.Settings, editor Editor) error {
+	if err := m.Meta().Validate(initialBoardState); err != nil {
+		return err
+	}
+
+	density := cavesDensity.Int(settings)
+	if density < 0 || density > 100 {
+		return rules.RulesetError(fmt.Sprintf("map parameter %q must be between 0 and 100", cavesDensity.Name))
+	}
+	smoothing := cavesSmoothing.Int(settings)
+
+	rand := settings.GetStageRand(0, m.ID())
+	size := initialBoardState.Width
+	starts := caveStarts(size, len(initialBoardState.Snakes))
+	walls := generateCaves(rand, size, density, smoothing)
+
+	if err := editor.PlaceSnakesRandomlyAtPositions(rand, initialBoardState.Snakes, starts, rules.SnakeStartSize); err != nil {
+		return err
+	}
+	for y := 0; y < size; y++ {
+		for x := 0; x < size; x++ {
+			if walls[y*size+x] {
+				editor.AddHazard(rules.Point{X: x, Y: y})
+			}
+		}
+	}
+
+	return PlaceFoodFixed(rand, initialBoardState, editor)
+}
+
+func (m CavesMap) PreUpdateBoard(lastBoardState *rules.BoardState, settings rules.Settings, editor Editor) error {
+	return nil
+}
+
+func (m CavesMap) PostUpdateBoard(lastBoardState *rules.BoardState, settings rules.Settings, editor Editor) error {
+	// Standard food spawning already avoids hazards, so food only spawns in the caves
+	return StandardMap{}.PostUpdateBoard(lastBoardState, settings, editor)
+}
+
+// caveStarts returns the start positions for a number of snakes. The first start is turned a
+// quarter of the way around the board for each other start, and 2 player games use opposite
+// starts.
+func caveStarts(size, players int) []rules.Point {
+	first := rules.Point{X: size / 4, Y: size / 4}
+	starts := cavesOrbit(size, first)
+	if players == 2 {
+		return []rules.Point{starts[0], starts[2]}
+	}
+	return starts
+}
+
+// cavesOrbit returns a point rotated by 0, 90, 180 and 270 degrees around the center of a square
+// board.
+func cavesOrbit(size int, p rules.Point) []rules.Point {
+	orbit := make([]rules.Point, 4)
+	for i := range orbit {
+		orbit[i] = p
+		p = rules.Point{X: size - 1 - p.Y, Y: p.X}
+	}
+	return orbit
+}
+
+// generateCaves returns which cells of a square board are hazards, indexed by y*size+x. The caves
+// look the same when turned by 90 degrees, the area around each start and the center is open, and
+// every open cell can be reached from every other one.
+func generateCaves(rand rules.Rand, size, density, smoothing int) []bool {
+	walls := make([]bool, size*size)
+	setOrbit := func(p rules.Point, wall bool) {
+		for _, q := range cavesOrbit(size, p) {
+			walls[q.Y*size+q.X] = wall
+		}
+	}
+
+	// Fill randomly, choosing once for each set of rotated cells so the caves stay symmetric
+	chosen := make([]bool, size*size)
+	for y := 0; y < size; y++ {
+		for x := 0; x < size; x++ {
+			if chosen[y*size+x] {
+				continue
+			}
+			p := rules.Point{X: x, Y: y}
+			setOrbit(p, rand.Intn(100) < density)
+			for _, q := range cavesOrbit(size, p) {
+				chosen[q.Y*size+q.X] = true
+			}
+		}
+	}
+
+	// Smooth into caves. Cells with mostly hazards around them become hazards, and cells with
+	// mostly open space around them open up. The rule doesn't depend on direction, so symmetry is
+	// kept.
+	for i := 0; i < smoothing; i++ {
+		next := make([]bool, len(walls))
+		for y := 0; y < size; y++ {
+			for x := 0; x < size; x++ {
+				cells, cellWalls := 0, 0
+				for dy := -1; dy <= 1; dy++ {
+					for dx := -1; dx <= 1; dx++ {
+						if !isOnBoard(size, size, x+dx, y+dy) {
+							continue
+						}
+						cells++
+						if walls[(y+dy)*size+x+dx] {
+							cellWalls++
+						}
+					}
+				}
+				// Cells on the edges have fewer cells around them, and keep their state on a tie
+				next[y*size+x] = 2*cellWalls > cells || (2*cellWalls == cells && walls[y*size+x])
+			}
+		}
+		walls = next
+	}
+
+	// Open up the area around the starts and the center
+	center := rules.Point{X: size / 2, Y: size / 2}
+	first := caveStarts(size, 1)[0]
+	for dy := -1; dy <= 1; dy++ {
+		for dx := -1; dx <= 1; dx++ {
+			setOrbit(rules.Point{X: first.X + dx, Y: first.Y + dy}, false)
+			setOrbit(rules.Point{X: center.X + dx, Y: center.Y + dy}, false)
+		}
+	}
+
+	// Dig a tunnel from the first start to the center if they aren't connected. The tunnel is
+	// rotated along with the start, which connects every start through the center.
+	if cavesDistances(walls, size, first)[center.Y*size+center.X] < 0 {
+		for x := first.X; x <= center.X; x++ {
+			setOrbit(rules.Point{X: x, Y: first.Y}, false)
+		}
+		for y := first.Y; y <= center.Y; y++ {
+			setOrbit(rules.Point{X: center.X, Y: y}, false)
+		}
+	}
+
+	// Fill in any open cells that still can't be reached. The reachable area turns into itself
+	// when rotated, so the rest does too.
+	for i, d := range cavesDistances(walls, size, center) {
+		if d < 0 {
+			walls[i] = true
+		}
+	}
+
+	return walls
+}
+
+// cavesDistances finds the number of moves from a point to every cell, without moving through
+// hazards. Cells that can't be reached are -1.
+func cavesDistances(walls []bool, size int, from rules.Point) []int {
+	caves := &rules.BoardState{Width: size, Height: size}
+	for i, wall := range walls {
+		if wall {
+			caves.Walls = append(caves.Walls, rules.Point{X: i % size, Y: i / size})
+		}
+	}
+	return analysis.Distances(caves, from, analysis.Options{}).Moves
+}
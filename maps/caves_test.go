@@ -0,0 +1,95 @@
+package maps_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/BattlesnakeOfficial/rules"
+	"github.com/BattlesnakeOfficial/rules/maps"
+	"github.com/stretchr/testify/require"
+)
+
+func setupCaves(t *testing.T, size, snakes int, seed int64, params map[string]string) *rules.BoardState {
+	t.Helper()
+	snakeIDs := make([]string, snakes)
+	for i := range snakeIDs {
+		snakeIDs[i] = fmt.Sprintf("snake_%d", i)
+	}
+	boardState := rules.NewBoardState(size, size)
+	rules.InitializeSnakes(boardState, snakeIDs)
+	settings := rules.NewSettings(params).WithSeed(seed)
+	err := maps.CavesMap{}.SetupBoard(boardState, settings, maps.NewBoardStateEditor(boardState))
+	require.NoError(t, err)
+	return boardState
+}
+
+func TestCavesMapDeterministic(t *testing.T) {
+	first := setupCaves(t, 11, 2, 123, nil)
+	second := setupCaves(t, 11, 2, 123, nil)
+	require.Equal(t, first.Hazards, second.Hazards)
+	require.NotEmpty(t, first.Hazards)
+
+	other := setupCaves(t, 11, 2, 456, nil)
+	require.NotEqual(t, first.Hazards, other.Hazards)
+}
+
+func TestCavesMapSymmetric(t *testing.T) {
+	for _, size := range []int{7, 11, 19, 25} {
+		for seed := int64(0); seed < 10; seed++ {
+			boardState := setupCaves(t, size, 4, seed, nil)
+			hazards := map[rules.Point]bool{}
+			for _, p := range boardState.Hazards {
+				hazards[p] = true
+			}
+			for p := range hazards {
+				rotated := rules.Point{X: size - 1 - p.Y, Y: p.X}
+				require.True(t, hazards[rotated], "size %d seed %d: %v is a hazard but %v isn't", size, seed, p, rotated)
+			}
+			for _, snake := range boardState.Snakes {
+				require.False(t, hazards[snake.Body[0]], "snake starts in a hazard")
+			}
+		}
+	}
+}
+
+func TestCavesMapConnected(t *testing.T) {
+	for _, result := range maps.LintMap(maps.CavesMap{}, 20) {
+		require.True(t, result.OK(), "%dx%d with %d players: %v", result.Width, result.Height, result.Players, result.Problems)
+	}
+}
+
+func TestCavesMapDensity(t *testing.T) {
+	sparse := setupCaves(t, 19, 2, 1, map[string]string{"map.density": "20"})
+	dense := setupCaves(t, 19, 2, 1, map[string]string{"map.density": "60"})
+	require.Less(t, len(sparse.Hazards), len(dense.Hazards))
+
+	empty := setupCaves(t, 19, 2, 1, map[string]string{"map.density": "0"})
+	require.Empty(t, empty.Hazards)
+
+	boardState := rules.NewBoardState(11, 11)
+	settings := rules.NewSettings(map[string]string{"map.density": "101"})
+	err := maps.CavesMap{}.SetupBoard(boardState, settings, maps.NewBoardStateEditor(boardState))
+	require.Error(t, err)
+}
+
+func TestCavesMapFoodAvoidsHazards(t *testing.T) {
+	boardState := setupCaves(t, 11, 2, 7, nil)
+	settings := rules.NewSettings(map[string]string{
+		rules.ParamFoodSpawnChance: "100",
+		rules.ParamMinimumFood:     "1",
+	}).WithSeed(7)
+
+	hazards := map[rules.Point]bool{}
+	for _, p := range boardState.Hazards {
+		hazards[p] = true
+	}
+	for turn := 0; turn < 20; turn++ {
+		boardState.Turn = turn
+		var err error
+		boardState, err = maps.PostUpdateBoard(maps.CavesMap{}, boardState, settings)
+		require.NoError(t, err)
+	}
+	for _, food := range boardState.Food {
+		require.False(t, hazards[food], "food %v spawned in a hazard", food)
+	}
+}
@@ -0,0 +1,313 @@
+package maps
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/BattlesnakeOfficial/rules"
+)
+
+// CompositeMapSeparator joins the IDs of the layers in a composite map ID, e.g. "hz_rings+royale".
+const CompositeMapSeparator = "+"
+
+// compositeHazardsState is the name of the state used to remember how many of the hazards on the
+// board belong to each layer of a composite map.
+const compositeHazardsState = "hazards"
+
+// CompositeMap stacks several game maps, running the setup and update hooks of each layer in
+// order.
+//
+// Layers can't all place snakes and food without getting in each other's way, so:
+//   - snakes are placed by the only layer tagged TAG_SNAKE_PLACEMENT, or by the first layer
+//   - food is placed by the only layer tagged TAG_FOOD_PLACEMENT, or by the first layer
+//   - hazards from every layer are added together
+//
+// Changes that a layer isn't responsible for are ignored. Each layer only sees its own hazards, so
+// a layer that clears and regenerates its hazards every turn (like royale) leaves the hazards of
+// the other layers alone. Hazards changed outside the map, for example by a ruleset stage, are kept
+// but don't belong to any layer.
+type CompositeMap struct {
+	layers     []GameMap
+	snakeLayer int
+	foodLayer  int
+}
+
+// NewCompositeMap stacks two or more game maps. It returns an error if more than one layer places
+// snakes or food, or if the layers have no board size or player count in common.
+func NewCompositeMap(layers ...GameMap) (*CompositeMap, error) {
+	if len(layers) < 2 {
+		return nil, rules.RulesetError("a composite map needs at least 2 maps")
+	}
+
+	m := &CompositeMap{layers: layers, snakeLayer: -1, foodLayer: -1}
+	for i, layer := range layers {
+		for _, tag := range layer.Meta().Tags {
+			switch tag {
+			case TAG_SNAKE_PLACEMENT:
+				if m.snakeLayer >= 0 {
+					return nil, rules.RulesetError(fmt.Sprintf("maps '%s' and '%s' both place snakes", layers[m.snakeLayer].ID(), layer.ID()))
+				}
+				m.snakeLayer = i
+			case TAG_FOOD_PLACEMENT:
+				if m.foodLayer >= 0 {
+					return nil, rules.RulesetError(fmt.Sprintf("maps '%s' and '%s' both place food", layers[m.foodLayer].ID(), layer.ID()))
+				}
+				m.foodLayer = i
+			}
+		}
+	}
+	if m.snakeLayer < 0 {
+		m.snakeLayer = 0
+	}
+	if m.foodLayer < 0 {
+		m.foodLayer = 0
+	}
+
+	meta := m.Meta()
+	if len(meta.BoardSizes) == 0 {
+		return nil, rules.RulesetError(fmt.Sprintf("maps in '%s' have no board sizes in common", m.ID()))
+	}
+	if meta.MaxPlayers != 0 && meta.MinPlayers > meta.MaxPlayers {
+		return nil, rules.RulesetError(fmt.Sprintf("maps in '%s' have no player counts in common", m.ID()))
+	}
+
+	return m, nil
+}
+
+// Layers returns the maps in the order they're applied.
+func (m *CompositeMap) Layers() []GameMap {
+	return append([]GameMap(nil), m.layers...)
+}
+
+func (m *CompositeMap) ID() string {
+	ids := make([]string, len(m.layers))
+	for i, layer := range m.layers {
+		ids[i] = layer.ID()
+	}
+	return strings.Join(ids, CompositeMapSeparator)
+}
+
+func (m *CompositeMap) Meta() Metadata {
+	meta := Metadata{BoardSizes: AnySize(), Tags: []string{}}
+	var names, authors, descriptions []string
+	seenAuthors, seenTags, seenParams := map[string]bool{}, map[string]bool{}, map[string]bool{}
+	for _, layer := range m.layers {
+		layerMeta := layer.Meta()
+		names = append(names, layerMeta.Name)
+		if layerMeta.Author != "" && !seenAuthors[layerMeta.Author] {
+			seenAuthors[layerMeta.Author] = true
+			authors = append(authors, layerMeta.Author)
+		}
+		if layerMeta.Description != "" {
+			descriptions = append(descriptions, layerMeta.Description)
+		}
+
+		// A change to any layer changes the composite
+		meta.Version += layerMeta.Version
+
+		if layerMeta.MinPlayers > meta.MinPlayers {
+			meta.MinPlayers = layerMeta.MinPlayers
+		}
+		if layerMeta.MaxPlayers != 0 && (meta.MaxPlayers == 0 || layerMeta.MaxPlayers < meta.MaxPlayers) {
+			meta.MaxPlayers = layerMeta.MaxPlayers
+		}
+		meta.BoardSizes = intersectSizes(meta.BoardSizes, layerMeta.BoardSizes)
+
+		for _, tag := range layerMeta.Tags {
+			if !seenTags[tag] {
+				seenTags[tag] = true
+				meta.Tags = append(meta.Tags, tag)
+			}
+		}
+
+		// Layers with a parameter of the same name share its value
+		for _, param := range layerMeta.Params {
+			if !seenParams[param.Name] {
+				seenParams[param.Name] = true
+				meta.Params = append(meta.Params, param)
+			}
+		}
+	}
+	meta.Name = strings.Join(names, " + ")
+	meta.Author = strings.Join(authors, ", ")
+	meta.Description = strings.Join(descriptions, " + ")
+	return meta
+}
+
+// intersectSizes returns the board sizes supported by both a and b.
+func intersectSizes(a, b sizes) sizes {
+	if a.IsUnlimited() {
+		return b
+	}
+	if b.IsUnlimited() {
+		return a
+	}
+	result := sizes{}
+	for _, size := range a {
+		if b.IsAllowable(size.Width, size.Height) {
+			result = append(result, size)
+		}
+	}
+	return result
+}
+
+func (m *CompositeMap) SetupBoard(initialBoardState *rules.BoardState, settings rules.Settings, editor Editor) error {
+	if err := m.Meta().Validate(initialBoardState); err != nil {
+		return err
+	}
+
+	working := initialBoardState.Clone()
+	working.GameState = editor.GameState()
+	working.PointState = editor.PointState()
+	layerHazards := make([][]rules.Point, len(m.layers))
+
+	for i, layer := range m.layers {
+		// As with SetupBoard, the layer's board is both the initial state and the one being edited
+		layerBoard := m.layerBoard(working, nil)
+		if err := layer.SetupBoard(layerBoard, settings, NewBoardStateEditor(layerBoard)); err != nil {
+			return err
+		}
+		m.collect(i, working, layerBoard, layerHazards)
+	}
+
+	return m.apply(working, layerHazards, nil, editor)
+}
+
+func (m *CompositeMap) PreUpdateBoard(lastBoardState *rules.BoardState, settings rules.Settings, editor Editor) error {
+	return m.update(lastBoardState, editor, func(layer GameMap, layerLastBoard *rules.BoardState, layerEditor Editor) error {
+		return layer.PreUpdateBoard(layerLastBoard, settings, layerEditor)
+	})
+}
+
+func (m *CompositeMap) PostUpdateBoard(lastBoardState *rules.BoardState, settings rules.Settings, editor Editor) error {
+	return m.update(lastBoardState, editor, func(layer GameMap, layerLastBoard *rules.BoardState, layerEditor Editor) error {
+		return layer.PostUpdateBoard(layerLastBoard, settings, layerEditor)
+	})
+}
+
+// update runs a hook for each layer, showing each layer only its own hazards.
+func (m *CompositeMap) update(lastBoardState *rules.BoardState, editor Editor, hook func(GameMap, *rules.BoardState, Editor) error) error {
+	lastHazards, unowned, err := m.splitHazards(lastBoardState)
+	if err != nil {
+		return err
+	}
+
+	working := lastBoardState.Clone()
+	working.GameState = editor.GameState()
+	working.PointState = editor.PointState()
+	layerHazards := make([][]rules.Point, len(m.layers))
+
+	for i, layer := range m.layers {
+		layerLastBoard := lastBoardState.Clone()
+		layerLastBoard.Hazards = append([]rules.Point{}, lastHazards[i]...)
+
+		layerBoard := m.layerBoard(working, lastHazards[i])
+		if err := hook(layer, layerLastBoard, NewBoardStateEditor(layerBoard)); err != nil {
+			return err
+		}
+		m.collect(i, working, layerBoard, layerHazards)
+	}
+
+	return m.apply(working, layerHazards, unowned, editor)
+}
+
+// layerBoard builds the board a layer edits, sharing the GameState and PointState of the board.
+func (m *CompositeMap) layerBoard(working *rules.BoardState, hazards []rules.Point) *rules.BoardState {
+	layerBoard := working.Clone()
+	layerBoard.Hazards = append([]rules.Point{}, hazards...)
+	layerBoard.GameState = working.GameState
+	layerBoard.PointState = working.PointState
+	return layerBoard
+}
+
+// collect keeps the changes a layer is responsible for.
+func (m *CompositeMap) collect(i int, working, layerBoard *rules.BoardState, layerHazards [][]rules.Point) {
+	if i == m.snakeLayer {
+		working.Snakes = layerBoard.Snakes
+	}
+	if i == m.foodLayer {
+		working.Food = layerBoard.Food
+	}
+	// Walls are shared by every layer, so each layer sees the walls placed by the layers before it
+	working.Walls = layerBoard.Walls
+	layerHazards[i] = layerBoard.Hazards
+}
+
+// apply writes the combined changes of all layers to the editor, remembering which hazards belong
+// to which layer.
+func (m *CompositeMap) apply(working *rules.BoardState, layerHazards [][]rules.Point, unowned []rules.Point, editor Editor) error {
+	for _, snake := range working.Snakes {
+		editor.PlaceSnake(snake.ID, snake.Body, snake.Health)
+	}
+
+	editor.ClearFood()
+	for _, p := range working.Food {
+		editor.AddFood(p)
+	}
+
+	if walls, err := AsWallEditor(editor); err == nil {
+		walls.ClearWalls()
+		for _, p := range working.Walls {
+			walls.AddWall(p)
+		}
+	} else if len(working.Walls) > 0 {
+		return err
+	}
+
+	editor.ClearHazards()
+	counts := make([]int, len(layerHazards))
+	for i, hazards := range layerHazards {
+		for _, p := range hazards {
+			editor.AddHazard(p)
+		}
+		counts[i] = len(hazards)
+	}
+	for _, p := range unowned {
+		editor.AddHazard(p)
+	}
+
+	if gameState := editor.GameState(); gameState != nil {
+		return rules.SetState(gameState, m.ID(), compositeHazardsState, counts)
+	}
+	return nil
+}
+
+// splitHazards divides the hazards on the board between the layers that placed them. Hazards
+// left over once every layer has its share don't belong to any layer.
+func (m *CompositeMap) splitHazards(boardState *rules.BoardState) ([][]rules.Point, []rules.Point, error) {
+	layerHazards := make([][]rules.Point, len(m.layers))
+	hazards := boardState.Hazards
+
+	var counts []int
+	if _, err := rules.GetState(boardState.GameState, m.ID(), compositeHazardsState, &counts); err != nil {
+		return nil, nil, err
+	}
+	if len(counts) != len(m.layers) {
+		return layerHazards, hazards, nil
+	}
+	for i, n := range counts {
+		if n < 0 {
+			return make([][]rules.Point, len(m.layers)), boardState.Hazards, nil
+		}
+		if n > len(hazards) {
+			n = len(hazards)
+		}
+		layerHazards[i] = hazards[:n]
+		hazards = hazards[n:]
+	}
+	return layerHazards, hazards, nil
+}
+
+// getCompositeMap builds a composite map from an ID made of registered map IDs joined with
+// CompositeMapSeparator.
+func (registry MapRegistry) getCompositeMap(id string) (GameMap, error) {
+	var layers []GameMap
+	for _, layerID := range strings.Split(id, CompositeMapSeparator) {
+		layer, ok := registry[layerID]
+		if !ok {
+			return nil, rules.ErrorMapNotFound
+		}
+		layers = append(layers, layer)
+	}
+	return NewCompositeMap(layers...)
+}
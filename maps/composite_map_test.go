@@ -0,0 +1,186 @@
+package maps_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/BattlesnakeOfficial/rules"
+	"github.com/BattlesnakeOfficial/rules/maps"
+	"github.com/stretchr/testify/require"
+)
+
+var compositeSettings = rules.NewSettings(map[string]string{
+	rules.ParamFoodSpawnChance: "25",
+	rules.ParamMinimumFood:     "1",
+})
+
+func TestGetCompositeMap(t *testing.T) {
+	gameMap, err := maps.GetMap("hz_rings+royale")
+	require.NoError(t, err)
+	require.IsType(t, &maps.CompositeMap{}, gameMap)
+	require.Equal(t, "hz_rings+royale", gameMap.ID())
+
+	rings, royale := maps.ConcentricRingsHazardsMap{}.Meta(), maps.RoyaleHazardsMap{}.Meta()
+	meta := gameMap.Meta()
+	require.Equal(t, rings.Name+" + "+royale.Name, meta.Name)
+	require.Equal(t, rings.Version+royale.Version, meta.Version)
+	require.Equal(t, []string{maps.TAG_HAZARD_PLACEMENT}, meta.Tags)
+	require.Equal(t, rings.BoardSizes, meta.BoardSizes)
+
+	_, err = maps.GetMap("hz_rings+not_a_map")
+	require.Equal(t, rules.ErrorMapNotFound, err)
+}
+
+func TestNewCompositeMapErrors(t *testing.T) {
+	_, err := maps.NewCompositeMap(maps.StandardMap{})
+	require.EqualError(t, err, "a composite map needs at least 2 maps")
+
+	_, err = maps.NewCompositeMap(maps.ArcadeMazeMap{}, maps.HazardPitsMap{})
+	require.EqualError(t, err, "maps 'arcade_maze' and 'hz_hazard_pits' both place food")
+
+	_, err = maps.NewCompositeMap(maps.HealingPoolsMap{}, maps.ArcadeMazeMap{})
+	require.EqualError(t, err, "maps in 'healing_pools+arcade_maze' have no board sizes in common")
+}
+
+func TestCompositeMapPlacement(t *testing.T) {
+	// The empty map is the first layer, so it places snakes and food (none)
+	gameMap, err := maps.NewCompositeMap(maps.EmptyMap{}, maps.ConcentricRingsHazardsMap{})
+	require.NoError(t, err)
+	boardState := setupCompositeMap(t, gameMap, 11)
+	require.Empty(t, boardState.Food)
+	require.NotEmpty(t, boardState.Hazards)
+	for _, snake := range boardState.Snakes {
+		require.Len(t, snake.Body, rules.SnakeStartSize)
+	}
+
+	boardState, err = maps.PostUpdateBoard(gameMap, boardState, compositeSettings)
+	require.NoError(t, err)
+	require.Empty(t, boardState.Food)
+
+	// A layer tagged for food placement places food even when it isn't first
+	gameMap, err = maps.NewCompositeMap(maps.EmptyMap{}, maps.HazardPitsMap{})
+	require.NoError(t, err)
+	boardState = setupCompositeMap(t, gameMap, 11)
+	require.NotEmpty(t, boardState.Food)
+}
+
+func TestCompositeMapLayersKeepTheirHazards(t *testing.T) {
+	settings := rules.NewSettings(map[string]string{
+		rules.ParamShrinkEveryNTurns: "1",
+		rules.ParamMinimumFood:       "1",
+	})
+
+	rings := maps.ConcentricRingsHazardsMap{}
+	ringsState, err := maps.SetupBoard(rings.ID(), settings, 11, 11, []string{"a", "b"})
+	require.NoError(t, err)
+	ringHazards := ringsState.Hazards
+	require.NotEmpty(t, ringHazards)
+
+	gameMap, err := maps.GetMap("hz_rings+royale")
+	require.NoError(t, err)
+	boardState, err := maps.SetupBoard(gameMap.ID(), settings, 11, 11, []string{"a", "b"})
+	require.NoError(t, err)
+	require.Equal(t, ringHazards, boardState.Hazards)
+
+	// The number of hazards each layer owns is kept in the composite map's state
+	var counts []int
+	found, err := rules.GetState(boardState.GameState, gameMap.ID(), "hazards", &counts)
+	require.NoError(t, err)
+	require.True(t, found)
+	require.Equal(t, []int{len(ringHazards), len(boardState.Hazards) - len(ringHazards)}, counts)
+
+	// Royale clears and regenerates its hazards every turn, which must not remove the rings
+	for turn := 0; turn < 5; turn++ {
+		boardState, err = maps.PreUpdateBoard(gameMap, boardState, settings)
+		require.NoError(t, err)
+		boardState, err = maps.PostUpdateBoard(gameMap, boardState, settings)
+		require.NoError(t, err)
+		boardState.Turn++
+
+		require.Equal(t, ringHazards, boardState.Hazards[:len(ringHazards)], "turn %d", boardState.Turn)
+		royaleHazards := boardState.Hazards[len(ringHazards):]
+		require.NotEmpty(t, royaleHazards, "turn %d", boardState.Turn)
+		for _, p := range royaleHazards {
+			// royale only covers the edges it has shrunk from
+			require.True(t, p.X < boardState.Turn || p.Y < boardState.Turn || p.X >= 11-boardState.Turn || p.Y >= 11-boardState.Turn)
+		}
+	}
+}
+
+func TestCompositeMapKeepsUnownedHazards(t *testing.T) {
+	gameMap, err := maps.GetMap("hz_rings+healing_pools")
+	require.NoError(t, err)
+	boardState := setupCompositeMap(t, gameMap, 11)
+	ownedHazards := len(boardState.Hazards)
+
+	// e.g. a hazard added by a ruleset stage
+	extra := rules.Point{X: 0, Y: 0}
+	boardState.Hazards = append(boardState.Hazards, extra)
+
+	boardState, err = maps.PostUpdateBoard(gameMap, boardState, rules.Settings{})
+	require.NoError(t, err)
+	require.Len(t, boardState.Hazards, ownedHazards+1)
+	require.Equal(t, extra, boardState.Hazards[ownedHazards])
+}
+
+func TestCompositeMapWalls(t *testing.T) {
+	definition, err := maps.ParseASCIIMap([]byte("id: walls\n\n" +
+		"S.........S\n" + strings.Repeat("...........\n", 4) +
+		"XXXX...XXXX\n" + strings.Repeat("...........\n", 4) +
+		"S.........S\n"))
+	require.NoError(t, err)
+	walls, err := maps.NewFileMap(definition)
+	require.NoError(t, err)
+	gameMap, err := maps.NewCompositeMap(walls, maps.RoyaleHazardsMap{})
+	require.NoError(t, err)
+
+	// Walls are kept by every layer, even one that clears its hazards every turn
+	boardState := setupCompositeMap(t, gameMap, 11)
+	require.Len(t, boardState.Walls, 8)
+	for turn := 0; turn < 5; turn++ {
+		boardState, err = maps.PostUpdateBoard(gameMap, boardState, compositeSettings.WithSeed(1))
+		require.NoError(t, err)
+		boardState.Turn++
+	}
+	require.ElementsMatch(t, definition.Layouts[0].Walls, boardState.Walls)
+}
+
+// editorWithoutWalls is an Editor that can't place walls, like one implemented outside this package.
+type editorWithoutWalls struct {
+	maps.Editor
+}
+
+func TestCompositeMapWallsNeedWallEditor(t *testing.T) {
+	definition, err := maps.ParseASCIIMap([]byte("id: walls\n\n" +
+		"S.........S\n" + strings.Repeat("...........\n", 4) +
+		"XXXX...XXXX\n" + strings.Repeat("...........\n", 4) +
+		"S.........S\n"))
+	require.NoError(t, err)
+	walls, err := maps.NewFileMap(definition)
+	require.NoError(t, err)
+	gameMap, err := maps.NewCompositeMap(walls, maps.RoyaleHazardsMap{})
+	require.NoError(t, err)
+
+	boardState := rules.NewBoardState(11, 11)
+	rules.InitializeSnakes(boardState, []string{"a", "b"})
+	err = gameMap.SetupBoard(boardState, compositeSettings, editorWithoutWalls{maps.NewBoardStateEditor(boardState)})
+	require.Equal(t, maps.ErrorWallsNotSupported, err)
+
+	// maps without walls don't need one
+	hazards, err := maps.GetMap("hz_rings+healing_pools")
+	require.NoError(t, err)
+	boardState = rules.NewBoardState(11, 11)
+	rules.InitializeSnakes(boardState, []string{"a", "b"})
+	require.NoError(t, hazards.SetupBoard(boardState, compositeSettings, editorWithoutWalls{maps.NewBoardStateEditor(boardState)}))
+	require.NotEmpty(t, boardState.Hazards)
+}
+
+func setupCompositeMap(t *testing.T, gameMap maps.GameMap, size int) *rules.BoardState {
+	t.Helper()
+
+	boardState := rules.NewBoardState(size, size)
+	rules.InitializeSnakes(boardState, []string{"a", "b"})
+	err := gameMap.SetupBoard(boardState, compositeSettings, maps.NewBoardStateEditor(boardState))
+	require.NoError(t, err)
+	return boardState
+}
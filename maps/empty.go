@@ -28,7 +28,7 @@ func (m EmptyMap) Meta() Metadata {
 }
 
 func (m EmptyMap) SetupBoard(initialBoardState *rules.BoardState, settings rules.Settings, editor Editor) error {
-	rand := settings.GetRand(0)
+	rand := settings.GetStageRand(0, m.ID())
 
 	if len(initialBoardState.Snakes) > int(m.Meta().MaxPlayers) {
 		return rules.ErrorTooManySnakes
@@ -0,0 +1,328 @@
+package maps
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/BattlesnakeOfficial/rules"
+)
+
+// MapDefinition describes a map in a JSON, YAML or ASCII art file.
+//
+// A map provides a layout for each board size it supports. Layouts list where snakes start, the
+// food placed when the game starts, the cells new food can spawn on, the hazards placed when the
+// game starts, and a schedule of hazards added or removed as the game goes on. Snakes are placed
+// automatically when a layout has no start positions, starting food is placed next to the snakes
+// when a layout has no food, and food can spawn anywhere when a layout has no food spawns.
+type MapDefinition struct {
+	ID          string   `json:"id" yaml:"id"`
+	Name        string   `json:"name" yaml:"name"`
+	Author      string   `json:"author" yaml:"author"`
+	Description string   `json:"description" yaml:"description"`
+	Version     int      `json:"version" yaml:"version"`
+	MinPlayers  int      `json:"minPlayers" yaml:"minPlayers"`
+	MaxPlayers  int      `json:"maxPlayers" yaml:"maxPlayers"`
+	Tags        []string `json:"tags" yaml:"tags"`
+
+	Layouts []MapLayout `json:"layouts" yaml:"layouts"`
+}
+
+// MapLayout is the layout of a map for one board size.
+type MapLayout struct {
+	Width          int           `json:"width" yaml:"width"`
+	Height         int           `json:"height" yaml:"height"`
+	SnakeStarts    []rules.Point `json:"snakeStarts" yaml:"snakeStarts"`
+	Food           []rules.Point `json:"food" yaml:"food"`
+	FoodSpawns     []rules.Point `json:"foodSpawns" yaml:"foodSpawns"`
+	Hazards        []rules.Point `json:"hazards" yaml:"hazards"`
+	HazardSchedule []HazardEvent `json:"hazardSchedule" yaml:"hazardSchedule"`
+	Walls          []rules.Point `json:"walls,omitempty" yaml:"walls,omitempty"`
+}
+
+// HazardEvent adds and removes hazards once the game reaches a turn, and optionally repeats
+// every few turns after that. Hazards are removed before new hazards are added.
+type HazardEvent struct {
+	// Turn is the first turn the changes are visible on.
+	Turn int `json:"turn" yaml:"turn"`
+	// Every repeats the changes after this many turns. 0 applies them only once.
+	Every  int           `json:"every" yaml:"every"`
+	Add    []rules.Point `json:"add" yaml:"add"`
+	Remove []rules.Point `json:"remove" yaml:"remove"`
+}
+
+// isDue checks whether the event applies to the given turn.
+func (event HazardEvent) isDue(turn int) bool {
+	if turn < event.Turn {
+		return false
+	}
+	if event.Every == 0 {
+		return turn == event.Turn
+	}
+	return (turn-event.Turn)%event.Every == 0
+}
+
+// FileMap is a GameMap built from a MapDefinition. It can be registered into a MapRegistry like
+// any other map.
+type FileMap struct {
+	definition MapDefinition
+	layouts    map[Dimensions]MapLayout
+}
+
+// NewFileMap checks a map definition and builds a game map from it.
+func NewFileMap(definition MapDefinition) (*FileMap, error) {
+	if definition.ID == "" {
+		return nil, fmt.Errorf("map is missing an id")
+	}
+	if len(definition.Layouts) == 0 {
+		return nil, fmt.Errorf("map %q has no layouts", definition.ID)
+	}
+	if definition.MinPlayers < 0 || definition.MaxPlayers < 0 || (definition.MaxPlayers > 0 && definition.MinPlayers > definition.MaxPlayers) {
+		return nil, fmt.Errorf("map %q has invalid player limits %d-%d", definition.ID, definition.MinPlayers, definition.MaxPlayers)
+	}
+
+	m := &FileMap{
+		definition: definition,
+		layouts:    make(map[Dimensions]MapLayout, len(definition.Layouts)),
+	}
+	for _, layout := range definition.Layouts {
+		size := Dimensions{Width: layout.Width, Height: layout.Height}
+		if _, ok := m.layouts[size]; ok {
+			return nil, fmt.Errorf("map %q has more than one %dx%d layout", definition.ID, size.Width, size.Height)
+		}
+		if err := layout.validate(); err != nil {
+			return nil, fmt.Errorf("map %q, %dx%d layout: %w", definition.ID, size.Width, size.Height, err)
+		}
+		m.layouts[size] = layout
+	}
+
+	return m, nil
+}
+
+func (layout MapLayout) validate() error {
+	if layout.Width <= 0 || layout.Height <= 0 {
+		return fmt.Errorf("invalid size")
+	}
+
+	checkPoints := func(name string, points []rules.Point) error {
+		for _, p := range points {
+			if !isOnBoard(layout.Width, layout.Height, p.X, p.Y) {
+				return fmt.Errorf("%s %#v is off the board", name, p)
+			}
+		}
+		return nil
+	}
+	if err := checkPoints("snake start", layout.SnakeStarts); err != nil {
+		return err
+	}
+	if err := checkPoints("food", layout.Food); err != nil {
+		return err
+	}
+	if err := checkPoints("food spawn", layout.FoodSpawns); err != nil {
+		return err
+	}
+	if err := checkPoints("hazard", layout.Hazards); err != nil {
+		return err
+	}
+	if err := checkPoints("wall", layout.Walls); err != nil {
+		return err
+	}
+	for _, event := range layout.HazardSchedule {
+		if event.Turn < 1 || event.Every < 0 {
+			return fmt.Errorf("hazard event on turn %d has an invalid schedule", event.Turn)
+		}
+		if err := checkPoints("hazard", event.Add); err != nil {
+			return err
+		}
+		if err := checkPoints("hazard", event.Remove); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ParseMapDefinition reads a map definition in the given format: "json", "yaml", or "ascii"
+// for maps drawn with ParseASCIIMap. Unknown fields are rejected to catch typos.
+func ParseMapDefinition(data []byte, format string) (MapDefinition, error) {
+	var definition MapDefinition
+	switch format {
+	case "json":
+		decoder := json.NewDecoder(bytes.NewReader(data))
+		decoder.DisallowUnknownFields()
+		if err := decoder.Decode(&definition); err != nil {
+			return MapDefinition{}, err
+		}
+	case "yaml":
+		decoder := yaml.NewDecoder(bytes.NewReader(data))
+		decoder.KnownFields(true)
+		if err := decoder.Decode(&definition); err != nil {
+			return MapDefinition{}, err
+		}
+	case "ascii":
+		return ParseASCIIMap(data)
+	default:
+		return MapDefinition{}, fmt.Errorf("unknown map format %q", format)
+	}
+	return definition, nil
+}
+
+// LoadMapFile reads a map from a JSON, YAML or ASCII art (.txt) file, chosen by its extension.
+func LoadMapFile(path string) (*FileMap, error) {
+	var format string
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		format = "json"
+	case ".yaml", ".yml":
+		format = "yaml"
+	case ".txt":
+		format = "ascii"
+	default:
+		return nil, fmt.Errorf("map file %s must have a .json, .yaml, .yml or .txt extension", path)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	definition, err := ParseMapDefinition(data, format)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse map file %s: %w", path, err)
+	}
+	return NewFileMap(definition)
+}
+
+// Definition returns the definition the map was built from.
+func (m *FileMap) Definition() MapDefinition {
+	return m.definition
+}
+
+func (m *FileMap) ID() string {
+	return m.definition.ID
+}
+
+func (m *FileMap) Meta() Metadata {
+	definition := m.definition
+	meta := Metadata{
+		Name:        definition.Name,
+		Author:      definition.Author,
+		Description: definition.Description,
+		Version:     definition.Version,
+		MinPlayers:  definition.MinPlayers,
+		MaxPlayers:  definition.MaxPlayers,
+		BoardSizes:  make(sizes, 0, len(definition.Layouts)),
+		Tags:        append([]string{}, definition.Tags...),
+	}
+	if meta.Name == "" {
+		meta.Name = definition.ID
+	}
+	if meta.Version == 0 {
+		meta.Version = 1
+	}
+
+	// Default to as many players as every layout has room for
+	maxPlayers := StandardMap{}.Meta().MaxPlayers
+	for _, layout := range definition.Layouts {
+		meta.BoardSizes = append(meta.BoardSizes, Dimensions{Width: layout.Width, Height: layout.Height})
+		if len(layout.SnakeStarts) > 0 && len(layout.SnakeStarts) < maxPlayers {
+			maxPlayers = len(layout.SnakeStarts)
+		}
+	}
+	if meta.MaxPlayers == 0 {
+		meta.MaxPlayers = maxPlayers
+	}
+
+	return meta
+}
+
+func (m *FileMap) SetupBoard(initialBoardState *rules.BoardState, settings rules.Settings, editor Editor) error {
+	if err := m.Meta().Validate(initialBoardState); err != nil {
+		return err
+	}
+	layout := m.layouts[Dimensions{Width: initialBoardState.Width, Height: initialBoardState.Height}]
+
+	rand := settings.GetStageRand(0, m.ID())
+
+	if len(layout.SnakeStarts) > 0 {
+		heads := append([]rules.Point{}, layout.SnakeStarts...)
+		if err := editor.PlaceSnakesRandomlyAtPositions(rand, initialBoardState.Snakes, heads, rules.SnakeStartSize); err != nil {
+			return err
+		}
+	} else {
+		snakeIDs := make([]string, 0, len(initialBoardState.Snakes))
+		for _, snake := range initialBoardState.Snakes {
+			snakeIDs = append(snakeIDs, snake.ID)
+		}
+		tempBoardState := rules.NewBoardState(initialBoardState.Width, initialBoardState.Height)
+		if err := rules.PlaceSnakesAutomatically(rand, tempBoardState, snakeIDs); err != nil {
+			return err
+		}
+		for _, snake := range tempBoardState.Snakes {
+			editor.PlaceSnake(snake.ID, snake.Body, snake.Health)
+		}
+	}
+
+	for _, p := range layout.Hazards {
+		editor.AddHazard(p)
+	}
+	if len(layout.Walls) > 0 {
+		walls, err := AsWallEditor(editor)
+		if err != nil {
+			return err
+		}
+		for _, p := range layout.Walls {
+			walls.AddWall(p)
+		}
+	}
+
+	if len(layout.Food) > 0 {
+		for _, p := range layout.Food {
+			editor.AddFood(p)
+		}
+		return nil
+	}
+	return PlaceFoodFixed(rand, initialBoardState, editor)
+}
+
+func (m *FileMap) PreUpdateBoard(lastBoardState *rules.BoardState, settings rules.Settings, editor Editor) error {
+	return nil
+}
+
+func (m *FileMap) PostUpdateBoard(lastBoardState *rules.BoardState, settings rules.Settings, editor Editor) error {
+	layout, ok := m.layouts[Dimensions{Width: lastBoardState.Width, Height: lastBoardState.Height}]
+	if !ok {
+		return rules.RulesetError("board size is not supported by this map")
+	}
+
+	rand := settings.GetStageRand(lastBoardState.Turn, m.ID())
+
+	var positions []rules.Point
+	if len(layout.FoodSpawns) > 0 {
+		positions = editor.FilterUnoccupiedPoints(layout.FoodSpawns, true, false, true)
+	} else {
+		positions = rules.GetUnoccupiedPoints(lastBoardState, false, false)
+	}
+	if err := spawnFood(rand, settings, lastBoardState, editor, positions); err != nil {
+		return err
+	}
+
+	// The board being updated is shown on the next turn
+	nextTurn := lastBoardState.Turn + 1
+	for _, event := range layout.HazardSchedule {
+		if !event.isDue(nextTurn) {
+			continue
+		}
+		for _, p := range event.Remove {
+			editor.RemoveHazard(p)
+		}
+		for _, p := range event.Add {
+			editor.AddHazard(p)
+		}
+	}
+
+	return nil
+}
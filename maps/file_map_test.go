@@ -0,0 +1,192 @@
+package maps_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/BattlesnakeOfficial/rules"
+	"github.com/BattlesnakeOfficial/rules/maps"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadMapFile(t *testing.T) {
+	yamlMap, err := maps.LoadMapFile("testdata/arena.yaml")
+	require.NoError(t, err)
+	jsonMap, err := maps.LoadMapFile("testdata/arena.json")
+	require.NoError(t, err)
+	require.Equal(t, yamlMap.Definition(), jsonMap.Definition())
+
+	require.Equal(t, "arena", yamlMap.ID())
+	meta := yamlMap.Meta()
+	require.Equal(t, "Arena", meta.Name)
+	require.Equal(t, 1, meta.Version)
+	require.Equal(t, 1, meta.MinPlayers)
+	require.Equal(t, 4, meta.MaxPlayers, "max players should default to the fewest start positions")
+	require.True(t, meta.BoardSizes.IsAllowable(7, 7))
+	require.True(t, meta.BoardSizes.IsAllowable(11, 11))
+	require.False(t, meta.BoardSizes.IsAllowable(19, 19))
+	require.Equal(t, []string{maps.TAG_SNAKE_PLACEMENT, maps.TAG_FOOD_PLACEMENT, maps.TAG_HAZARD_PLACEMENT}, meta.Tags)
+
+	layout := yamlMap.Definition().Layouts[0]
+	require.Equal(t, []maps.HazardEvent{
+		{Turn: 5, Every: 10, Add: []rules.Point{{X: 2, Y: 3}, {X: 4, Y: 3}}},
+		{Turn: 10, Every: 10, Remove: []rules.Point{{X: 2, Y: 3}, {X: 4, Y: 3}}},
+	}, layout.HazardSchedule)
+}
+
+func TestLoadMapFileErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		file     string
+		contents string
+		expected string
+	}{
+		{
+			name:     "unknown extension",
+			file:     "map.toml",
+			contents: "id: test",
+			expected: "must have a .json, .yaml, .yml or .txt extension",
+		},
+		{
+			name:     "unknown field",
+			file:     "map.yml",
+			contents: "id: test\nlayouts:\n  - width: 7\n    height: 7\n    snakeStart: [{x: 1, y: 1}]\n",
+			expected: "field snakeStart not found",
+		},
+		{
+			name:     "unknown JSON field",
+			file:     "map.json",
+			contents: `{"id": "test", "size": 7}`,
+			expected: `unknown field "size"`,
+		},
+		{
+			name:     "missing id",
+			file:     "map.yaml",
+			contents: "layouts:\n  - width: 7\n    height: 7\n",
+			expected: "map is missing an id",
+		},
+		{
+			name:     "no layouts",
+			file:     "map.yaml",
+			contents: "id: test\n",
+			expected: `map "test" has no layouts`,
+		},
+		{
+			name:     "duplicate layout",
+			file:     "map.yaml",
+			contents: "id: test\nlayouts:\n  - {width: 7, height: 7}\n  - {width: 7, height: 7}\n",
+			expected: `map "test" has more than one 7x7 layout`,
+		},
+		{
+			name:     "off the board",
+			file:     "map.yaml",
+			contents: "id: test\nlayouts:\n  - width: 7\n    height: 7\n    food: [{x: 7, y: 0}]\n",
+			expected: `map "test", 7x7 layout: food {X:7, Y:0} is off the board`,
+		},
+		{
+			name:     "invalid schedule",
+			file:     "map.yaml",
+			contents: "id: test\nlayouts:\n  - width: 7\n    height: 7\n    hazardSchedule: [{turn: 0, add: [{x: 1, y: 1}]}]\n",
+			expected: `map "test", 7x7 layout: hazard event on turn 0 has an invalid schedule`,
+		},
+		{
+			name:     "invalid players",
+			file:     "map.yaml",
+			contents: "id: test\nminPlayers: 4\nmaxPlayers: 2\nlayouts:\n  - {width: 7, height: 7}\n",
+			expected: `map "test" has invalid player limits 4-2`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), test.file)
+			require.NoError(t, os.WriteFile(path, []byte(test.contents), 0644))
+
+			_, err := maps.LoadMapFile(path)
+			require.ErrorContains(t, err, test.expected)
+		})
+	}
+}
+
+func TestFileMapSetupBoard(t *testing.T) {
+	gameMap, err := maps.LoadMapFile("testdata/arena.yaml")
+	require.NoError(t, err)
+	settings := rules.NewSettingsWithParams(rules.ParamFoodSpawnChance, "0", rules.ParamMinimumFood, "0").WithSeed(3)
+
+	boardState := rules.NewBoardState(7, 7)
+	rules.InitializeSnakes(boardState, []string{"1", "2", "3", "4"})
+	require.NoError(t, gameMap.SetupBoard(boardState, settings, maps.NewBoardStateEditor(boardState)))
+
+	heads := []rules.Point{}
+	for _, snake := range boardState.Snakes {
+		require.Len(t, snake.Body, rules.SnakeStartSize)
+		require.Equal(t, rules.SnakeMaxHealth, snake.Health)
+		heads = append(heads, snake.Body[0])
+	}
+	require.ElementsMatch(t, []rules.Point{{X: 1, Y: 1}, {X: 5, Y: 1}, {X: 1, Y: 5}, {X: 5, Y: 5}}, heads)
+	require.Equal(t, []rules.Point{{X: 0, Y: 3}, {X: 6, Y: 3}}, boardState.Food)
+	require.Equal(t, []rules.Point{{X: 3, Y: 3}}, boardState.Hazards)
+
+	// layouts without start positions or food use standard placement
+	boardState = rules.NewBoardState(11, 11)
+	rules.InitializeSnakes(boardState, []string{"1", "2"})
+	require.NoError(t, gameMap.SetupBoard(boardState, settings, maps.NewBoardStateEditor(boardState)))
+	require.NoError(t, boardState.Validate())
+	require.Len(t, boardState.Food, 2)
+	require.Equal(t, []rules.Point{{X: 5, Y: 5}}, boardState.Hazards)
+
+	boardState = rules.NewBoardState(7, 7)
+	rules.InitializeSnakes(boardState, []string{"1", "2", "3", "4", "5"})
+	require.Error(t, gameMap.SetupBoard(boardState, settings, maps.NewBoardStateEditor(boardState)))
+
+	boardState = rules.NewBoardState(9, 9)
+	rules.InitializeSnakes(boardState, []string{"1"})
+	require.Error(t, gameMap.SetupBoard(boardState, settings, maps.NewBoardStateEditor(boardState)))
+}
+
+func TestFileMapPostUpdateBoard(t *testing.T) {
+	gameMap, err := maps.LoadMapFile("testdata/arena.yaml")
+	require.NoError(t, err)
+	settings := rules.NewSettingsWithParams(rules.ParamFoodSpawnChance, "0", rules.ParamMinimumFood, "4").WithSeed(3)
+
+	boardState := rules.NewBoardState(7, 7).
+		WithFood([]rules.Point{{X: 0, Y: 3}}).
+		WithHazards([]rules.Point{{X: 3, Y: 3}}).
+		WithSnakes([]rules.Snake{{ID: "1", Body: []rules.Point{{X: 3, Y: 6}, {X: 2, Y: 6}, {X: 1, Y: 6}}, Health: 100}})
+
+	// food only spawns on free spawn cells
+	next, err := maps.PostUpdateBoard(gameMap, boardState.WithTurn(1), settings)
+	require.NoError(t, err)
+	require.Equal(t, []rules.Point{{X: 0, Y: 3}, {X: 3, Y: 0}}, next.Food)
+	require.Equal(t, []rules.Point{{X: 3, Y: 3}}, next.Hazards)
+
+	// the hazard schedule fills in the pit on turn 5 and drains it on turn 10, every 10 turns
+	pit := []rules.Point{{X: 3, Y: 3}}
+	filled := []rules.Point{{X: 3, Y: 3}, {X: 2, Y: 3}, {X: 4, Y: 3}}
+	state := boardState.Clone()
+	for turn := 1; turn <= 15; turn++ {
+		state, err = maps.PostUpdateBoard(gameMap, state.WithTurn(turn-1), settings)
+		require.NoError(t, err)
+		if (turn >= 5 && turn < 10) || turn == 15 {
+			require.Equal(t, filled, state.Hazards, "turn %d", turn)
+		} else {
+			require.Equal(t, pit, state.Hazards, "turn %d", turn)
+		}
+	}
+}
+
+func TestRegisterMapError(t *testing.T) {
+	gameMap, err := maps.LoadMapFile("testdata/arena.yaml")
+	require.NoError(t, err)
+
+	registry := maps.MapRegistry{}
+	require.NoError(t, registry.RegisterMapError(gameMap.ID(), gameMap))
+	require.EqualError(t, registry.RegisterMapError(gameMap.ID(), gameMap), "map 'arena' has already been registered")
+
+	registered, err := registry.GetMap("arena")
+	require.NoError(t, err)
+	require.Equal(t, gameMap, registered)
+
+	require.Error(t, maps.RegisterMapError("standard", gameMap))
+}
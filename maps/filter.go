@@ -0,0 +1,80 @@
+package maps
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Filter selects maps by their metadata. The zero value matches every map.
+type Filter struct {
+	// Tags that a map must have all of.
+	Tags []string
+	// Players is a number of players the map must support, or 0 for any.
+	Players int
+	// Size is a board size the map must support. A zero size matches any map.
+	Size Dimensions
+	// ExcludeExperimental leaves out maps tagged TAG_EXPERIMENTAL.
+	ExcludeExperimental bool
+}
+
+// Matches checks whether a map's metadata passes the filter.
+func (filter Filter) Matches(meta Metadata) bool {
+	tags := make(map[string]bool, len(meta.Tags))
+	for _, tag := range meta.Tags {
+		tags[tag] = true
+	}
+	for _, tag := range filter.Tags {
+		if !tags[tag] {
+			return false
+		}
+	}
+	if filter.ExcludeExperimental && tags[TAG_EXPERIMENTAL] {
+		return false
+	}
+
+	if filter.Players > 0 {
+		if meta.MinPlayers != 0 && filter.Players < meta.MinPlayers {
+			return false
+		}
+		if meta.MaxPlayers != 0 && filter.Players > meta.MaxPlayers {
+			return false
+		}
+	}
+
+	if filter.Size != (Dimensions{}) && !meta.BoardSizes.IsAllowable(filter.Size.Width, filter.Size.Height) {
+		return false
+	}
+
+	return true
+}
+
+// Filter returns the IDs of registered maps that pass the filter, in alphabetical order.
+func (registry MapRegistry) Filter(filter Filter) []string {
+	var ids []string
+	for _, id := range registry.List() {
+		if filter.Matches(registry[id].Meta()) {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+// FilterMaps returns the IDs of maps in the global registry that pass the filter.
+func FilterMaps(filter Filter) []string {
+	return globalRegistry.Filter(filter)
+}
+
+// ParseDimensions reads a board size written as "WxH", e.g. "11x11".
+func ParseDimensions(s string) (Dimensions, error) {
+	w, h, ok := strings.Cut(strings.ToLower(s), "x")
+	if !ok {
+		return Dimensions{}, fmt.Errorf("board size %q must be in the form WxH", s)
+	}
+	width, errW := strconv.Atoi(w)
+	height, errH := strconv.Atoi(h)
+	if errW != nil || errH != nil || width < 1 || height < 1 {
+		return Dimensions{}, fmt.Errorf("board size %q must be in the form WxH", s)
+	}
+	return Dimensions{Width: width, Height: height}, nil
+}
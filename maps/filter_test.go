@@ -0,0 +1,67 @@
+package maps
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFilterMatches(t *testing.T) {
+	meta := Metadata{
+		MinPlayers: 2,
+		MaxPlayers: 4,
+		BoardSizes: FixedSizes(Dimensions{11, 11}, Dimensions{19, 19}),
+		Tags:       []string{TAG_HAZARD_PLACEMENT, TAG_EXPERIMENTAL},
+	}
+
+	tests := []struct {
+		name    string
+		filter  Filter
+		matches bool
+	}{
+		{"empty", Filter{}, true},
+		{"tag", Filter{Tags: []string{TAG_HAZARD_PLACEMENT}}, true},
+		{"all tags", Filter{Tags: []string{TAG_HAZARD_PLACEMENT, TAG_SNAKE_PLACEMENT}}, false},
+		{"players", Filter{Players: 3}, true},
+		{"too few players", Filter{Players: 1}, false},
+		{"too many players", Filter{Players: 5}, false},
+		{"size", Filter{Size: Dimensions{19, 19}}, true},
+		{"unsupported size", Filter{Size: Dimensions{7, 7}}, false},
+		{"experimental", Filter{ExcludeExperimental: true}, false},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require.Equal(t, test.matches, test.filter.Matches(meta))
+		})
+	}
+
+	// maps without player limits or fixed sizes match any number of players and size
+	require.True(t, Filter{Players: 100, Size: Dimensions{99, 3}}.Matches(Metadata{BoardSizes: AnySize()}))
+}
+
+func TestRegistryFilter(t *testing.T) {
+	registry := MapRegistry{
+		"standard": StandardMap{},
+		"maze":     ArcadeMazeMap{},
+		"solo":     SoloMazeMap{},
+	}
+	require.Equal(t, []string{"maze", "solo", "standard"}, registry.Filter(Filter{}))
+	require.Equal(t, []string{"maze", "solo"}, registry.Filter(Filter{Tags: []string{TAG_SNAKE_PLACEMENT}}))
+	require.Equal(t, []string{"maze", "standard"}, registry.Filter(Filter{Players: 2, ExcludeExperimental: true}))
+	require.Equal(t, []string{"maze"}, registry.Filter(Filter{Size: Dimensions{19, 21}, ExcludeExperimental: true}))
+}
+
+func TestParseDimensions(t *testing.T) {
+	size, err := ParseDimensions("19x21")
+	require.NoError(t, err)
+	require.Equal(t, Dimensions{Width: 19, Height: 21}, size)
+
+	size, err = ParseDimensions("7X7")
+	require.NoError(t, err)
+	require.Equal(t, Dimensions{Width: 7, Height: 7}, size)
+
+	for _, invalid := range []string{"", "11", "x11", "0x11", "11x-1", "axb"} {
+		_, err = ParseDimensions(invalid)
+		require.EqualError(t, err, `board size "`+invalid+`" must be in the form WxH`)
+	}
+}
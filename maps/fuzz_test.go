@@ -0,0 +1,89 @@
+package maps_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/BattlesnakeOfficial/rules"
+	"github.com/BattlesnakeOfficial/rules/maps"
+	"github.com/stretchr/testify/require"
+)
+
+func FuzzMaps(f *testing.F) {
+	f.Add(int64(1), uint8(0), uint8(0), uint8(11), uint8(11), uint8(2), []byte{0, 1, 2, 3, 3, 2, 1, 0, 0, 0, 0, 0})
+	f.Add(int64(2), uint8(5), uint8(3), uint8(19), uint8(19), uint8(4), []byte{3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3})
+
+	gameTypes := []string{rules.GameTypeStandard, rules.GameTypeConstrictor, rules.GameTypeRoyale, rules.GameTypeWrapped}
+	moves := []string{rules.MoveUp, rules.MoveDown, rules.MoveLeft, rules.MoveRight}
+	mapIDs := maps.List()
+
+	f.Fuzz(func(t *testing.T, seed int64, mapIndex, gameType, width, height, snakeCount uint8, input []byte) {
+		gameMap, err := maps.GetMap(mapIDs[int(mapIndex)%len(mapIDs)])
+		require.NoError(t, err)
+		meta := gameMap.Meta()
+
+		// pick a board size and number of snakes the map supports
+		w, h := 3+int(width%23), 3+int(height%23)
+		if !meta.BoardSizes.IsUnlimited() {
+			size := meta.BoardSizes[(int(width)+int(height))%len(meta.BoardSizes)]
+			w, h = size.Width, size.Height
+		}
+		n := 1 + int(snakeCount%8)
+		if meta.MinPlayers > 0 && n < meta.MinPlayers {
+			n = meta.MinPlayers
+		}
+		if meta.MaxPlayers > 0 && n > meta.MaxPlayers {
+			n = meta.MaxPlayers
+		}
+		snakeIDs := make([]string, n)
+		for i := range snakeIDs {
+			snakeIDs[i] = fmt.Sprintf("snake_%d", i)
+		}
+
+		ruleset := rules.NewRulesetBuilder().
+			WithSeed(seed).
+			WithParams(map[string]string{
+				rules.ParamFoodSpawnChance:     fmt.Sprint(seed & 0x7f),
+				rules.ParamMinimumFood:         fmt.Sprint((seed >> 8) & 0x7),
+				rules.ParamHazardDamagePerTurn: fmt.Sprint((seed >> 12) & 0x1f),
+				rules.ParamShrinkEveryNTurns:   fmt.Sprint(1 + (seed>>20)&0xf),
+			}).
+			WithSolo(n == 1).
+			NamedRuleset(gameTypes[int(gameType)%len(gameTypes)])
+
+		boardState, err := maps.SetupBoard(gameMap.ID(), ruleset.Settings(), w, h, snakeIDs)
+		if err != nil {
+			t.Skipf("map can't be set up: %v", err)
+		}
+		gameOver, boardState, err := ruleset.Execute(boardState, nil)
+		require.NoError(t, err)
+		require.NoError(t, boardState.Validate())
+
+		for !gameOver {
+			snakeMoves := []rules.SnakeMove{}
+			for _, snake := range boardState.Snakes {
+				if snake.EliminatedCause != rules.NotEliminated {
+					continue
+				}
+				if len(input) == 0 {
+					return
+				}
+				snakeMoves = append(snakeMoves, rules.SnakeMove{ID: snake.ID, Move: moves[int(input[0])%len(moves)]})
+				input = input[1:]
+			}
+
+			boardState, err = maps.PreUpdateBoard(gameMap, boardState, ruleset.Settings())
+			require.NoError(t, err)
+			require.NoError(t, boardState.Validate(), "turn %d before moves", boardState.Turn)
+
+			gameOver, boardState, err = ruleset.Execute(boardState, snakeMoves)
+			require.NoError(t, err)
+			require.NoError(t, boardState.Validate(), "turn %d after moves", boardState.Turn)
+
+			boardState, err = maps.PostUpdateBoard(gameMap, boardState, ruleset.Settings())
+			require.NoError(t, err)
+			boardState.Turn++
+			require.NoError(t, boardState.Validate(), "turn %d", boardState.Turn)
+		}
+	})
+}
@@ -59,6 +59,8 @@ type Metadata struct {
 	BoardSizes sizes
 	// Tags is a list of strings use to categorize the map.
 	Tags []string
+	// Params is a list of parameters that can be set to tune the map.
+	Params []Param
 }
 
 func (meta Metadata) Validate(boardState *rules.BoardState) error {
@@ -150,6 +152,7 @@ type Editor interface {
 	ClearFood()
 
 	// Adds a food to the board. Does not check for duplicates.
+	// The point's TTL and Value are the food's lifetime and nutrition, see rules.NewFood.
 	AddFood(rules.Point)
 
 	// Removes all food from a specific tile on the board.
@@ -163,6 +166,7 @@ type Editor interface {
 	ClearHazards()
 
 	// Adds a hazard to the board. Does not check for duplicates.
+	// The point's Value is the damage the hazard deals, see rules.Point.HazardDamage.
 	AddHazard(rules.Point)
 
 	// Removes all hazards from a specific tile on the board.
@@ -191,19 +195,55 @@ type Editor interface {
 	PlaceSnakesRandomlyAtPositions(rand rules.Rand, snakes []rules.Snake, heads []rules.Point, bodyLength int) error
 
 	// Returns true if the provided point on the board is occupied by a snake body, food, and/or hazard.
+	// Walls always count as occupied.
 	IsOccupied(point rules.Point, snakes, hazards, food bool) bool
 
 	// Get a set of all points on the board the are occupied by snake bodies, food, and/or hazards.
 	// The value for each point will be set to true in the return value if that point is occupied by one of the selected objects.
+	// Walls are always included.
 	OccupiedPoints(snakes, hazards, food bool) map[rules.Point]bool
 
 	// Given a list of points, return only those that are unoccupied by snake bodies, food, and/or hazards.
+	// Walls are always filtered out.
 	FilterUnoccupiedPoints(targets []rules.Point, snakes, hazards, food bool) []rules.Point
 
 	// Shuffle the provided slice of points randomly using the provided rules.Rand
 	ShufflePoints(rules.Rand, []rules.Point)
 }
 
+// A WallEditor is an Editor that can also place impassable walls. It's kept separate from Editor
+// so that existing Editor implementations don't have to support walls. Maps that place walls get
+// one with AsWallEditor.
+type WallEditor interface {
+	Editor
+
+	// Clears all walls from the board.
+	ClearWalls()
+
+	// Adds an impassable wall to the board. Snakes that move into a wall are eliminated.
+	// Does not check for duplicates.
+	AddWall(rules.Point)
+
+	// Removes all walls from a specific tile on the board.
+	RemoveWall(rules.Point)
+
+	// Get the locations of walls currently on the board.
+	// Note: the return value is a copy and modifying it won't affect the board.
+	Walls() []rules.Point
+}
+
+// ErrorWallsNotSupported is returned by maps that place walls when their editor isn't a WallEditor.
+const ErrorWallsNotSupported = rules.RulesetError("editor does not support walls")
+
+// AsWallEditor returns the editor as a WallEditor, or ErrorWallsNotSupported if it can't place walls.
+func AsWallEditor(editor Editor) (WallEditor, error) {
+	walls, ok := editor.(WallEditor)
+	if !ok {
+		return nil, ErrorWallsNotSupported
+	}
+	return walls, nil
+}
+
 // An Editor backed by a BoardState.
 type BoardStateEditor struct {
 	boardState *rules.BoardState
@@ -220,7 +260,7 @@ func (editor *BoardStateEditor) ClearFood() {
 }
 
 func (editor *BoardStateEditor) AddFood(p rules.Point) {
-	editor.boardState.Food = append(editor.boardState.Food, rules.Point{X: p.X, Y: p.Y})
+	editor.boardState.Food = append(editor.boardState.Food, rules.Point{X: p.X, Y: p.Y, TTL: p.TTL, Value: p.Value})
 }
 
 func (editor *BoardStateEditor) RemoveFood(p rules.Point) {
@@ -243,7 +283,7 @@ func (editor *BoardStateEditor) ClearHazards() {
 }
 
 func (editor *BoardStateEditor) AddHazard(p rules.Point) {
-	editor.boardState.Hazards = append(editor.boardState.Hazards, rules.Point{X: p.X, Y: p.Y})
+	editor.boardState.Hazards = append(editor.boardState.Hazards, rules.Point{X: p.X, Y: p.Y, Value: p.Value})
 }
 
 func (editor *BoardStateEditor) RemoveHazard(p rules.Point) {
@@ -261,6 +301,30 @@ func (editor *BoardStateEditor) Hazards() []rules.Point {
 	return append([]rules.Point(nil), editor.boardState.Hazards...)
 }
 
+func (editor *BoardStateEditor) ClearWalls() {
+	editor.boardState.Walls = []rules.Point{}
+}
+
+func (editor *BoardStateEditor) AddWall(p rules.Point) {
+	editor.boardState.Walls = append(editor.boardState.Walls, rules.Point{X: p.X, Y: p.Y})
+}
+
+func (editor *BoardStateEditor) RemoveWall(p rules.Point) {
+	walls := editor.boardState.Walls[:0]
+	for _, wall := range editor.boardState.Walls {
+		if wall.X != p.X || wall.Y != p.Y {
+			walls = append(walls, wall)
+		}
+	}
+	editor.boardState.Walls = walls
+}
+
+// Get the locations of walls currently on the board.
+// Note: the return value is read-only.
+func (editor *BoardStateEditor) Walls() []rules.Point {
+	return append([]rules.Point(nil), editor.boardState.Walls...)
+}
+
 func (editor *BoardStateEditor) PlaceSnake(id string, body []rules.Point, health int) {
 	for index, snake := range editor.boardState.Snakes {
 		if snake.ID == id {
@@ -324,17 +388,23 @@ func (editor *BoardStateEditor) PlaceSnakesRandomlyAtPositions(rand rules.Rand,
 }
 
 // Returns true if the provided point on the board is occupied by a snake body, food, and/or hazard.
+// Walls always count as occupied.
 func (editor *BoardStateEditor) IsOccupied(point rules.Point, snakes, hazards, food bool) bool {
+	for _, wall := range editor.boardState.Walls {
+		if wall.X == point.X && wall.Y == point.Y {
+			return true
+		}
+	}
 	if food {
 		for _, food := range editor.boardState.Food {
-			if food == point {
+			if food.X == point.X && food.Y == point.Y {
 				return true
 			}
 		}
 	}
 	if hazards {
 		for _, hazard := range editor.boardState.Hazards {
-			if hazard == point {
+			if hazard.X == point.X && hazard.Y == point.Y {
 				return true
 			}
 		}
@@ -342,7 +412,7 @@ func (editor *BoardStateEditor) IsOccupied(point rules.Point, snakes, hazards, f
 	if snakes {
 		for _, snake := range editor.boardState.Snakes {
 			for _, body := range snake.Body {
-				if body == point {
+				if body.X == point.X && body.Y == point.Y {
 					return true
 				}
 			}
@@ -355,22 +425,26 @@ func (editor *BoardStateEditor) IsOccupied(point rules.Point, snakes, hazards, f
 // The value for each point will be set to true in the return value if that point is occupied by one of the selected objects.
 func (editor *BoardStateEditor) OccupiedPoints(snakes, hazards, food bool) map[rules.Point]bool {
 	boardState := editor.boardState
-	result := make(map[rules.Point]bool, len(boardState.Food)+len(boardState.Hazards)+len(boardState.Snakes)*3)
+	result := make(map[rules.Point]bool, len(boardState.Food)+len(boardState.Hazards)+len(boardState.Walls)+len(boardState.Snakes)*3)
+
+	for _, wall := range editor.boardState.Walls {
+		result[rules.Point{X: wall.X, Y: wall.Y}] = true
+	}
 
 	if food {
 		for _, food := range editor.boardState.Food {
-			result[food] = true
+			result[rules.Point{X: food.X, Y: food.Y}] = true
 		}
 	}
 	if hazards {
 		for _, hazard := range editor.boardState.Hazards {
-			result[hazard] = true
+			result[rules.Point{X: hazard.X, Y: hazard.Y}] = true
 		}
 	}
 	if snakes {
 		for _, snake := range editor.boardState.Snakes {
 			for _, body := range snake.Body {
-				result[body] = true
+				result[rules.Point{X: body.X, Y: body.Y}] = true
 			}
 		}
 	}
@@ -379,21 +453,27 @@ func (editor *BoardStateEditor) OccupiedPoints(snakes, hazards, food bool) map[r
 }
 
 // Given a list of points, return only those that are unoccupied by snake bodies, food, and/or hazards.
+// Walls are always filtered out.
 func (editor *BoardStateEditor) FilterUnoccupiedPoints(targets []rules.Point, snakes, hazards, food bool) []rules.Point {
 	result := make([]rules.Point, 0, len(targets))
 
 targetLoop:
 	for _, point := range targets {
+		for _, wall := range editor.boardState.Walls {
+			if wall.X == point.X && wall.Y == point.Y {
+				continue targetLoop
+			}
+		}
 		if food {
 			for _, food := range editor.boardState.Food {
-				if food == point {
+				if food.X == point.X && food.Y == point.Y {
 					continue targetLoop
 				}
 			}
 		}
 		if hazards {
 			for _, hazard := range editor.boardState.Hazards {
-				if hazard == point {
+				if hazard.X == point.X && hazard.Y == point.Y {
 					continue targetLoop
 				}
 			}
@@ -401,7 +481,7 @@ targetLoop:
 		if snakes {
 			for _, snake := range editor.boardState.Snakes {
 				for _, body := range snake.Body {
-					if body == point {
+					if body.X == point.X && body.Y == point.Y {
 						continue targetLoop
 					}
 				}
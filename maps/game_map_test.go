@@ -113,6 +113,7 @@ func TestMapSizes(t *testing.T) {
 
 func TestBoardStateEditorInterface(t *testing.T) {
 	var _ Editor = (*BoardStateEditor)(nil)
+	var _ WallEditor = (*BoardStateEditor)(nil)
 }
 
 func TestBoardStateEditor(t *testing.T) {
@@ -185,6 +186,45 @@ func TestBoardStateEditor(t *testing.T) {
 	require.Equal(t, []rules.Point{}, boardState.Hazards)
 }
 
+func TestBoardStateEditorFoodAndHazardValues(t *testing.T) {
+	boardState := rules.NewBoardState(11, 11)
+	editor := NewBoardStateEditor(boardState)
+
+	editor.AddFood(rules.Point{X: 1, Y: 3, TTL: 5, Value: 20})
+	editor.AddHazard(rules.Point{X: 2, Y: 3, Value: -10})
+	require.Equal(t, []rules.Point{{X: 1, Y: 3, TTL: 5, Value: 20}}, boardState.Food)
+
+	// Points are occupied whatever their values
+	require.True(t, editor.IsOccupied(rules.Point{X: 1, Y: 3}, false, false, true))
+	require.True(t, editor.IsOccupied(rules.Point{X: 2, Y: 3}, false, true, false))
+	require.Equal(t, map[rules.Point]bool{{X: 1, Y: 3}: true, {X: 2, Y: 3}: true}, editor.OccupiedPoints(false, true, true))
+	require.Empty(t, editor.FilterUnoccupiedPoints([]rules.Point{{X: 1, Y: 3}, {X: 2, Y: 3}}, false, true, true))
+
+	editor.RemoveFood(rules.Point{X: 1, Y: 3})
+	require.Empty(t, boardState.Food)
+}
+
+func TestBoardStateEditorWalls(t *testing.T) {
+	boardState := rules.NewBoardState(11, 11)
+	editor := NewBoardStateEditor(boardState)
+
+	editor.AddWall(rules.Point{X: 1, Y: 3, Value: 5})
+	editor.AddWall(rules.Point{X: 3, Y: 6})
+	editor.AddWall(rules.Point{X: 3, Y: 6})
+	editor.AddWall(rules.Point{X: 3, Y: 7})
+	editor.RemoveWall(rules.Point{X: 3, Y: 6})
+	require.Equal(t, []rules.Point{{X: 1, Y: 3}, {X: 3, Y: 7}}, boardState.Walls)
+	require.Equal(t, []rules.Point{{X: 1, Y: 3}, {X: 3, Y: 7}}, editor.Walls())
+
+	// Walls are always occupied
+	require.True(t, editor.IsOccupied(rules.Point{X: 1, Y: 3}, false, false, false))
+	require.Equal(t, map[rules.Point]bool{{X: 1, Y: 3}: true, {X: 3, Y: 7}: true}, editor.OccupiedPoints(false, false, false))
+	require.Equal(t, []rules.Point{{X: 2, Y: 3}}, editor.FilterUnoccupiedPoints([]rules.Point{{X: 1, Y: 3}, {X: 2, Y: 3}}, false, false, false))
+
+	editor.ClearWalls()
+	require.Equal(t, []rules.Point{}, boardState.Walls)
+}
+
 func TestBoardStateEditorPlaceSnakesRandomlyAtPositions(t *testing.T) {
 	for label, test := range map[string]struct {
 		rand           rules.Rand
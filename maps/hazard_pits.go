@@ -51,7 +51,7 @@ func (m HazardPitsMap) SetupBoard(initialBoardState *rules.BoardState, settings
 		return err
 	}
 
-	rand := settings.GetRand(0)
+	rand := settings.GetStageRand(0, m.ID())
 
 	rand.Shuffle(len(hazardPitStartPositions), func(i int, j int) {
 		hazardPitStartPositions[i], hazardPitStartPositions[j] = hazardPitStartPositions[j], hazardPitStartPositions[i]
@@ -153,6 +153,13 @@ func (m ColumnsHazardsMap) PostUpdateBoard(lastBoardState *rules.BoardState, set
 
 type SpiralHazardsMap struct{}
 
+var spiralSpawnEveryNTurns = Param{
+	Name:        "spawnEveryNTurns",
+	Type:        ParamTypeInt,
+	Default:     "3",
+	Description: "Number of turns between each new hazard in the spiral",
+}
+
 func (m SpiralHazardsMap) ID() string {
 	return "hz_spiral"
 }
@@ -168,6 +175,7 @@ func (m SpiralHazardsMap) Meta() Metadata {
 		MaxPlayers: 16,
 		BoardSizes: OddSizes(rules.BoardSizeSmall, rules.BoardSizeXXLarge),
 		Tags:       []string{TAG_HAZARD_PLACEMENT},
+		Params:     []Param{spiralSpawnEveryNTurns},
 	}
 }
 
@@ -186,14 +194,17 @@ func (m SpiralHazardsMap) PostUpdateBoard(lastBoardState *rules.BoardState, sett
 	}
 
 	currentTurn := lastBoardState.Turn + 1
-	spawnEveryNTurns := 3
+	spawnEveryNTurns, err := spiralSpawnEveryNTurns.positiveInt(settings)
+	if err != nil {
+		return err
+	}
 
 	// no-op if we're not on a turn that spawns hazards
 	if currentTurn < spawnEveryNTurns || currentTurn%spawnEveryNTurns != 0 {
 		return nil
 	}
 
-	rand := settings.GetRand(0)
+	rand := settings.GetStageRand(0, m.ID())
 	spawnArea := 0.3 // Center spiral in the middle 0.6 of the board
 
 	// randomly choose a location between the start point and the edge of the board
@@ -252,6 +263,13 @@ func (m SpiralHazardsMap) PostUpdateBoard(lastBoardState *rules.BoardState, sett
 
 type ScatterFillMap struct{}
 
+var scatterSpawnEveryNTurns = Param{
+	Name:        "spawnEveryNTurns",
+	Type:        ParamTypeInt,
+	Default:     "2",
+	Description: "Number of turns between each new hazard",
+}
+
 func (m ScatterFillMap) ID() string {
 	return "hz_scatter"
 }
@@ -265,6 +283,7 @@ func (m ScatterFillMap) Meta() Metadata {
 		MaxPlayers:  16,
 		BoardSizes:  OddSizes(rules.BoardSizeSmall, rules.BoardSizeXXLarge),
 		Tags:        []string{TAG_HAZARD_PLACEMENT},
+		Params:      []Param{scatterSpawnEveryNTurns},
 	}
 }
 
@@ -283,13 +302,21 @@ func (m ScatterFillMap) PostUpdateBoard(lastBoardState *rules.BoardState, settin
 	}
 
 	currentTurn := lastBoardState.Turn + 1
-	spawnEveryNTurns := 2
+	spawnEveryNTurns, err := scatterSpawnEveryNTurns.positiveInt(settings)
+	if err != nil {
+		return err
+	}
 
 	// no-op if we're not on a turn that spawns hazards
 	if currentTurn < spawnEveryNTurns || currentTurn%spawnEveryNTurns != 0 {
 		return nil
 	}
 
+	// no-op once every square has been filled
+	if currentTurn/spawnEveryNTurns > lastBoardState.Width*lastBoardState.Height {
+		return nil
+	}
+
 	positions := make([]rules.Point, 0, lastBoardState.Width*lastBoardState.Height)
 	for x := 0; x < lastBoardState.Width; x++ {
 		for y := 0; y < lastBoardState.Height; y++ {
@@ -297,17 +324,24 @@ func (m ScatterFillMap) PostUpdateBoard(lastBoardState *rules.BoardState, settin
 		}
 	}
 
-	rand := settings.GetRand(0)
+	rand := settings.GetStageRand(0, m.ID())
 	rand.Shuffle(len(positions), func(i, j int) {
 		positions[i], positions[j] = positions[j], positions[i]
 	})
 
-	editor.AddHazard(positions[(currentTurn-2)/2])
+	editor.AddHazard(positions[currentTurn/spawnEveryNTurns-1])
 	return nil
 }
 
 type DirectionalExpandingBoxMap struct{}
 
+var growBoxSpawnEveryNTurns = Param{
+	Name:        "spawnEveryNTurns",
+	Type:        ParamTypeInt,
+	Default:     "12",
+	Description: "Number of turns between each growth of the box",
+}
+
 func (m DirectionalExpandingBoxMap) ID() string {
 	return "hz_grow_box"
 }
@@ -321,6 +355,7 @@ func (m DirectionalExpandingBoxMap) Meta() Metadata {
 		MaxPlayers:  16,
 		BoardSizes:  OddSizes(rules.BoardSizeSmall, rules.BoardSizeXXLarge),
 		Tags:        []string{TAG_HAZARD_PLACEMENT},
+		Params:      []Param{growBoxSpawnEveryNTurns},
 	}
 }
 
@@ -340,7 +375,10 @@ func (m DirectionalExpandingBoxMap) PostUpdateBoard(lastBoardState *rules.BoardS
 
 	currentTurn := lastBoardState.Turn + 1
 	startTurn := 1
-	spawnEveryNTurns := 12
+	spawnEveryNTurns, err := growBoxSpawnEveryNTurns.positiveInt(settings)
+	if err != nil {
+		return err
+	}
 
 	// no-op if we're not on a turn that spawns hazards
 	if (currentTurn-startTurn)%spawnEveryNTurns != 0 {
@@ -352,7 +390,7 @@ func (m DirectionalExpandingBoxMap) PostUpdateBoard(lastBoardState *rules.BoardS
 		return nil
 	}
 
-	rand := settings.GetRand(0)
+	rand := settings.GetStageRand(0, m.ID())
 	startX := rand.Range(2, lastBoardState.Width-2)
 	startY := rand.Range(2, lastBoardState.Height-2)
 
@@ -427,6 +465,13 @@ func (m DirectionalExpandingBoxMap) PostUpdateBoard(lastBoardState *rules.BoardS
 
 type ExpandingBoxMap struct{}
 
+var expandBoxSpawnEveryNTurns = Param{
+	Name:        "spawnEveryNTurns",
+	Type:        ParamTypeInt,
+	Default:     "20",
+	Description: "Number of turns between each new ring of the box",
+}
+
 func (m ExpandingBoxMap) ID() string {
 	return "hz_expand_box"
 }
@@ -440,6 +485,7 @@ func (m ExpandingBoxMap) Meta() Metadata {
 		MaxPlayers:  16,
 		BoardSizes:  OddSizes(rules.BoardSizeSmall, rules.BoardSizeXXLarge),
 		Tags:        []string{TAG_HAZARD_PLACEMENT},
+		Params:      []Param{expandBoxSpawnEveryNTurns},
 	}
 }
 
@@ -459,7 +505,10 @@ func (m ExpandingBoxMap) PostUpdateBoard(lastBoardState *rules.BoardState, setti
 
 	currentTurn := lastBoardState.Turn + 1
 	startTurn := 1 // first hazard appears on turn 1
-	spawnEveryNTurns := 20
+	spawnEveryNTurns, err := expandBoxSpawnEveryNTurns.positiveInt(settings)
+	if err != nil {
+		return err
+	}
 
 	// no-op if we're not on a turn that spawns hazards
 	if (currentTurn-startTurn)%spawnEveryNTurns != 0 {
@@ -471,7 +520,7 @@ func (m ExpandingBoxMap) PostUpdateBoard(lastBoardState *rules.BoardState, setti
 		return nil
 	}
 
-	rand := settings.GetRand(0)
+	rand := settings.GetStageRand(0, m.ID())
 
 	startX := rand.Range(2, lastBoardState.Width-2)
 	startY := rand.Range(2, lastBoardState.Width-2)
@@ -507,6 +556,13 @@ func (m ExpandingBoxMap) PostUpdateBoard(lastBoardState *rules.BoardState, setti
 
 type ExpandingScatterMap struct{}
 
+var expandScatterSpawnEveryNTurns = Param{
+	Name:        "spawnEveryNTurns",
+	Type:        ParamTypeInt,
+	Default:     "2",
+	Description: "Number of turns between each new hazard",
+}
+
 func (m ExpandingScatterMap) ID() string {
 	return "hz_expand_scatter"
 }
@@ -520,6 +576,7 @@ func (m ExpandingScatterMap) Meta() Metadata {
 		MaxPlayers:  16,
 		BoardSizes:  OddSizes(rules.BoardSizeSmall, rules.BoardSizeXXLarge),
 		Tags:        []string{TAG_HAZARD_PLACEMENT},
+		Params:      []Param{expandScatterSpawnEveryNTurns},
 	}
 }
 
@@ -539,7 +596,10 @@ func (m ExpandingScatterMap) PostUpdateBoard(lastBoardState *rules.BoardState, s
 
 	currentTurn := lastBoardState.Turn + 1
 	startTurn := 1 // first hazard appears on turn 1
-	spawnEveryNTurns := 2
+	spawnEveryNTurns, err := expandScatterSpawnEveryNTurns.positiveInt(settings)
+	if err != nil {
+		return err
+	}
 
 	// no-op if we're not on a turn that spawns hazards
 	if (currentTurn-startTurn)%spawnEveryNTurns != 0 {
@@ -551,7 +611,7 @@ func (m ExpandingScatterMap) PostUpdateBoard(lastBoardState *rules.BoardState, s
 		return nil
 	}
 
-	rand := settings.GetRand(0)
+	rand := settings.GetStageRand(0, m.ID())
 
 	startX := rand.Range(1, lastBoardState.Width-1)
 	startY := rand.Range(1, lastBoardState.Width-1)
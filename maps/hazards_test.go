@@ -130,6 +130,24 @@ func TestScatterFillMap(t *testing.T) {
 	require.Equal(t, 11*11, len(state.Hazards), "hazards should eventually fill the entire map")
 }
 
+func TestScatterFillMapAfterFilled(t *testing.T) {
+	// spawning every turn fills the board before the game ends
+	m := maps.ScatterFillMap{}
+	settings := rules.NewSettings(map[string]string{"map.spawnEveryNTurns": "1"}).WithSeed(10)
+
+	state := rules.NewBoardState(7, 7)
+	editor := maps.NewBoardStateEditor(state)
+	err := m.SetupBoard(state, settings, editor)
+	require.NoError(t, err)
+
+	for i := 0; i < 7*7*2; i++ {
+		state.Turn = i
+		err = m.PostUpdateBoard(state, settings, editor)
+		require.NoError(t, err)
+	}
+	require.Equal(t, 7*7, len(state.Hazards), "hazards should stop once the map is full")
+}
+
 func TestDirectionalExpandingBoxMap(t *testing.T) {
 	// check error handling
 	m := maps.DirectionalExpandingBoxMap{}
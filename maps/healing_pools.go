@@ -1,15 +1,20 @@
 package maps
 
 import (
-	"math/rand"
-
 	"github.com/BattlesnakeOfficial/rules"
 )
 
 type HealingPoolsMap struct{}
 
+var healingPoolsRemoveEveryNTurns = Param{
+	Name:        "removeEveryNTurns",
+	Type:        ParamTypeInt,
+	Description: "Number of turns between removing each healing pool, or 0 to keep them all game. Defaults to shrinkEveryNTurns",
+}
+
 func init() {
 	globalRegistry.RegisterMap("healing_pools", HealingPoolsMap{})
+	globalRegistry.RegisterMap("healing_pools_heal", HealingPoolsHealMap{})
 }
 
 func (m HealingPoolsMap) ID() string {
@@ -26,6 +31,7 @@ func (m HealingPoolsMap) Meta() Metadata {
 		MaxPlayers:  8,
 		BoardSizes:  FixedSizes(Dimensions{7, 7}, Dimensions{11, 11}, Dimensions{19, 19}),
 		Tags:        []string{TAG_HAZARD_PLACEMENT},
+		Params:      []Param{healingPoolsRemoveEveryNTurns},
 	}
 }
 
@@ -34,8 +40,12 @@ func (m HealingPoolsMap) SetupBoard(initialBoardState *rules.BoardState, setting
 		return err
 	}
 
-	rand := settings.GetRand(0)
+	return placeHealingPools(settings.GetStageRand(0, m.ID()), initialBoardState, editor, 0)
+}
 
+// placeHealingPools adds one of the sets of pools for the board size, with each pool dealing
+// damage, or the ruleset's hazard damage when damage is 0.
+func placeHealingPools(rand rules.Rand, initialBoardState *rules.BoardState, editor Editor, damage int) error {
 	options, ok := poolLocationOptions[rules.Point{X: initialBoardState.Width, Y: initialBoardState.Height}]
 	if !ok {
 		return rules.RulesetError("board size is not supported by this map")
@@ -44,7 +54,7 @@ func (m HealingPoolsMap) SetupBoard(initialBoardState *rules.BoardState, setting
 	i := rand.Intn(len(options))
 
 	for _, p := range options[i] {
-		editor.AddHazard(p)
+		editor.AddHazard(rules.Point{X: p.X, Y: p.Y, Value: damage})
 	}
 
 	return nil
@@ -59,13 +69,69 @@ func (m HealingPoolsMap) PostUpdateBoard(lastBoardState *rules.BoardState, setti
 		return err
 	}
 
+	removeHealingPool(m.ID(), lastBoardState, settings, editor)
+	return nil
+}
+
+// removeHealingPool removes a random pool every removeEveryNTurns turns.
+func removeHealingPool(mapID string, lastBoardState *rules.BoardState, settings rules.Settings, editor Editor) {
 	shrinkEveryNTurns := settings.Int(rules.ParamShrinkEveryNTurns, 0)
+	if healingPoolsRemoveEveryNTurns.IsSet(settings) {
+		shrinkEveryNTurns = healingPoolsRemoveEveryNTurns.Int(settings)
+	}
 	if lastBoardState.Turn > 0 && shrinkEveryNTurns > 0 && len(lastBoardState.Hazards) > 0 && lastBoardState.Turn%shrinkEveryNTurns == 0 {
 		// Attempt to remove a healing pool every ShrinkEveryNTurns until there are none remaining
+		rand := settings.GetStageRand(lastBoardState.Turn, mapID)
 		i := rand.Intn(len(lastBoardState.Hazards))
 		editor.RemoveHazard(lastBoardState.Hazards[i])
 	}
+}
+
+// HealingPoolsHealMap is a variant of HealingPoolsMap where the pools heal snakes that sit in
+// them, whatever the ruleset's hazard damage is.
+type HealingPoolsHealMap struct{}
+
+var healingPoolsHealPerTurn = Param{
+	Name:        "healPerTurn",
+	Type:        ParamTypeInt,
+	Default:     "10",
+	Description: "Health restored each turn to a snake in a pool",
+}
+
+func (m HealingPoolsHealMap) ID() string {
+	return "healing_pools_heal"
+}
+
+func (m HealingPoolsHealMap) Meta() Metadata {
+	meta := HealingPoolsMap{}.Meta()
+	meta.Name = "Healing Pools (Healing)"
+	meta.Description = "Spawns fixed single cell pools based on the map size, which heal snakes that sit in them."
+	meta.Params = append([]Param{healingPoolsHealPerTurn}, meta.Params...)
+	return meta
+}
+
+func (m HealingPoolsHealMap) SetupBoard(initialBoardState *rules.BoardState, settings rules.Settings, editor Editor) error {
+	if err := (StandardMap{}).SetupBoard(initialBoardState, settings, editor); err != nil {
+		return err
+	}
+
+	heal, err := healingPoolsHealPerTurn.positiveInt(settings)
+	if err != nil {
+		return err
+	}
+	return placeHealingPools(settings.GetStageRand(0, m.ID()), initialBoardState, editor, -heal)
+}
+
+func (m HealingPoolsHealMap) PreUpdateBoard(lastBoardState *rules.BoardState, settings rules.Settings, editor Editor) error {
+	return nil
+}
+
+func (m HealingPoolsHealMap) PostUpdateBoard(lastBoardState *rules.BoardState, settings rules.Settings, editor Editor) error {
+	if err := (StandardMap{}).PostUpdateBoard(lastBoardState, settings, editor); err != nil {
+		return err
+	}
 
+	removeHealingPool(m.ID(), lastBoardState, settings, editor)
 	return nil
 }
 
@@ -67,3 +67,62 @@ func TestHealingPoolsMap(t *testing.T) {
 		})
 	}
 }
+
+func TestHealingPoolsMapDeterministic(t *testing.T) {
+	m := maps.HealingPoolsMap{}
+	settings := rules.NewSettingsWithParams(rules.ParamShrinkEveryNTurns, "1").WithSeed(1234)
+
+	// play out the same game twice and make sure pools are removed in the same order
+	removalOrder := func() [][]rules.Point {
+		state := rules.NewBoardState(19, 19)
+		editor := maps.NewBoardStateEditor(state)
+		require.NoError(t, m.SetupBoard(state, settings, editor))
+
+		history := [][]rules.Point{}
+		for turn := 1; len(state.Hazards) > 0; turn++ {
+			state.Turn = turn
+			require.NoError(t, m.PostUpdateBoard(state, settings, editor))
+			history = append(history, append([]rules.Point{}, state.Hazards...))
+		}
+		return history
+	}
+
+	require.Equal(t, removalOrder(), removalOrder())
+}
+
+func TestHealingPoolsHealMap(t *testing.T) {
+	m := maps.HealingPoolsHealMap{}
+	settings := rules.NewSettings(map[string]string{
+		rules.ParamHazardDamagePerTurn: "14",
+		rules.ParamShrinkEveryNTurns:   "10",
+		"map.healPerTurn":              "7",
+	})
+
+	state := rules.NewBoardState(11, 11)
+	rules.InitializeSnakes(state, []string{"one"})
+	require.NoError(t, m.SetupBoard(state, settings, maps.NewBoardStateEditor(state)))
+	require.Len(t, state.Hazards, 2)
+	for _, p := range state.Hazards {
+		require.Equal(t, -7, p.Value)
+	}
+
+	// A snake in a pool is healed instead of damaged
+	pool := state.Hazards[0]
+	state.Food = nil
+	state.Snakes[0].Body = []rules.Point{{X: pool.X, Y: pool.Y}, {X: pool.X, Y: pool.Y - 1}}
+	state.Snakes[0].Health = 50
+	_, err := rules.DamageHazardsStandard(state, settings, []rules.SnakeMove{{ID: "one", Move: rules.MoveUp}})
+	require.NoError(t, err)
+	require.Equal(t, 57, state.Snakes[0].Health)
+
+	// Pools keep healing after a turn of map updates
+	state, err = maps.PostUpdateBoard(m, state, settings)
+	require.NoError(t, err)
+	for _, p := range state.Hazards {
+		require.Equal(t, -7, p.Value)
+	}
+
+	settings = rules.NewSettings(map[string]string{"map.healPerTurn": "0"})
+	state = rules.NewBoardState(11, 11)
+	require.Error(t, m.SetupBoard(state, settings, maps.NewBoardStateEditor(state)))
+}
@@ -25,7 +25,8 @@ func SetupBoard(mapID string, settings rules.Settings, width, height int, snakeI
 	return boardState, nil
 }
 
-// PreUpdateBoard updates a board state with a map.
+// PreUpdateBoard updates a board state with a map. Food the map spawns is given the lifetime and
+// nutrition set for the game.
 func PreUpdateBoard(gameMap GameMap, previousBoardState *rules.BoardState, settings rules.Settings) (*rules.BoardState, error) {
 	nextBoardState := previousBoardState.Clone()
 	editor := NewBoardStateEditor(nextBoardState)
@@ -34,10 +35,12 @@ func PreUpdateBoard(gameMap GameMap, previousBoardState *rules.BoardState, setti
 	if err != nil {
 		return nil, err
 	}
+	applyFoodSettings(settings, previousBoardState, nextBoardState)
 
 	return nextBoardState, nil
 }
 
+// PostUpdateBoard updates a board state with a map, the same way as PreUpdateBoard.
 func PostUpdateBoard(gameMap GameMap, previousBoardState *rules.BoardState, settings rules.Settings) (*rules.BoardState, error) {
 	nextBoardState := previousBoardState.Clone()
 	editor := NewBoardStateEditor(nextBoardState)
@@ -46,10 +49,26 @@ func PostUpdateBoard(gameMap GameMap, previousBoardState *rules.BoardState, sett
 	if err != nil {
 		return nil, err
 	}
+	applyFoodSettings(settings, previousBoardState, nextBoardState)
 
 	return nextBoardState, nil
 }
 
+// applyFoodSettings gives the food a map spawned the lifetime and nutrition set for the game, see
+// rules.NewFood. Food that was already on the board, or that the map gave its own lifetime or
+// nutrition, is left alone.
+func applyFoodSettings(settings rules.Settings, previousBoardState, nextBoardState *rules.BoardState) {
+	existing := make(map[rules.Point]bool, len(previousBoardState.Food))
+	for _, p := range previousBoardState.Food {
+		existing[rules.Point{X: p.X, Y: p.Y}] = true
+	}
+	for i, p := range nextBoardState.Food {
+		if !existing[rules.Point{X: p.X, Y: p.Y}] && p.TTL == 0 && p.Value == 0 {
+			nextBoardState.Food[i] = rules.NewFood(settings, p)
+		}
+	}
+}
+
 // An implementation of GameMap that just does predetermined placements, for testing.
 type StubMap struct {
 	Id             string
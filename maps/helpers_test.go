@@ -142,3 +142,29 @@ func TestPlaceFoodFixed(t *testing.T) {
 	require.Contains(t, food, rules.Point{X: 3, Y: 10})
 	require.Contains(t, food, rules.Point{X: 7, Y: 7})
 }
+
+func TestPostUpdateBoardFoodSettings(t *testing.T) {
+	settings := rules.NewSettings(map[string]string{
+		rules.ParamMinimumFood:   "3",
+		rules.ParamFoodLifetime:  "8",
+		rules.ParamFoodNutrition: "40",
+	}).WithSeed(1)
+	boardState := rules.NewBoardState(11, 11).WithFood([]rules.Point{{X: 1, Y: 1}, {X: 2, Y: 2, TTL: 3}})
+
+	next, err := maps.PostUpdateBoard(maps.StandardMap{}, boardState, settings)
+	require.NoError(t, err)
+	require.Len(t, next.Food, 3)
+	// Food that was already on the board keeps its own lifetime and nutrition
+	require.Equal(t, rules.Point{X: 1, Y: 1}, next.Food[0])
+	require.Equal(t, rules.Point{X: 2, Y: 2, TTL: 3}, next.Food[1])
+	require.Equal(t, 8, next.Food[2].TTL)
+	require.Equal(t, 40, next.Food[2].Value)
+
+	// Food keeps its lifetime and nutrition when maps are stacked
+	gameMap, err := maps.GetMap("standard+hz_rings")
+	require.NoError(t, err)
+	next, err = maps.PostUpdateBoard(gameMap, next, settings)
+	require.NoError(t, err)
+	require.Equal(t, 8, next.Food[2].TTL)
+	require.Equal(t, 40, next.Food[2].Value)
+}
@@ -0,0 +1,308 @@
+package maps
+
+import (
+	"fmt"
+
+	"github.com/BattlesnakeOfficial/rules"
+	"github.com/BattlesnakeOfficial/rules/analysis"
+)
+
+// lintSizes are the board sizes checked for maps that support any size.
+var lintSizes = []Dimensions{
+	{Width: rules.BoardSizeSmall, Height: rules.BoardSizeSmall},
+	{Width: rules.BoardSizeMedium, Height: rules.BoardSizeMedium},
+	{Width: rules.BoardSizeLarge, Height: rules.BoardSizeLarge},
+}
+
+// LintResult is the outcome of setting up a map many times for one board size and number of
+// players.
+type LintResult struct {
+	Width   int
+	Height  int
+	Players int
+	// Games is the number of seeds the board was set up with.
+	Games    int
+	Problems []LintProblem
+
+	// FoodDistance is the number of moves from each snake's head to the nearest food.
+	FoodDistance LintStat
+	// ReachableArea is the number of cells each snake can reach before any other snake.
+	ReachableArea LintStat
+}
+
+// OK reports whether no problems were found.
+func (result LintResult) OK() bool {
+	return len(result.Problems) == 0
+}
+
+// LintProblem is a problem found when setting up a map. The same problem is only reported once,
+// with the first seed it was found with and the number of games it was found in.
+type LintProblem struct {
+	Message string
+	Seed    int64
+	Count   int
+}
+
+// LintStat summarizes a fairness metric over every snake in every game.
+type LintStat struct {
+	Min int
+	Max int
+	// Spread is the largest difference between two snakes in the same game. A fair map keeps
+	// this small.
+	Spread int
+
+	sum   int
+	count int
+}
+
+// Mean is the average value over every snake in every game.
+func (stat LintStat) Mean() float64 {
+	if stat.count == 0 {
+		return 0
+	}
+	return float64(stat.sum) / float64(stat.count)
+}
+
+// add records the values for every snake in one game.
+func (stat *LintStat) add(values []int) {
+	if len(values) == 0 {
+		return
+	}
+	min, max := values[0], values[0]
+	for _, v := range values {
+		if v < min {
+			min = v
+		}
+		if v > max {
+			max = v
+		}
+		stat.sum += v
+	}
+	if stat.count == 0 || min < stat.Min {
+		stat.Min = min
+	}
+	if stat.count == 0 || max > stat.Max {
+		stat.Max = max
+	}
+	if max-min > stat.Spread {
+		stat.Spread = max - min
+	}
+	stat.count += len(values)
+}
+
+// LintMap sets up a map with seeds 1 to seeds for every board size and number of players it
+// supports, checking that:
+//   - SetupBoard succeeds
+//   - every snake is placed on the board, away from the other snakes
+//   - food is placed on the board
+//   - no snake starts in a hazard
+//   - every cell without a hazard can be reached from a snake without crossing a hazard
+//
+// Maps that support any board size are checked on the small, medium and large board sizes.
+//
+// It also measures how fair the starting positions are. Snakes' bodies block movement when
+// measuring distances, but hazards don't.
+func LintMap(gameMap GameMap, seeds int) []LintResult {
+	meta := gameMap.Meta()
+
+	boardSizes := []Dimensions(meta.BoardSizes)
+	if meta.BoardSizes.IsUnlimited() {
+		boardSizes = lintSizes
+	}
+	minPlayers, maxPlayers := meta.MinPlayers, meta.MaxPlayers
+	if minPlayers < 1 {
+		minPlayers = 1
+	}
+	if maxPlayers < minPlayers {
+		maxPlayers = minPlayers
+	}
+
+	var results []LintResult
+	for _, size := range boardSizes {
+		for players := minPlayers; players <= maxPlayers; players++ {
+			results = append(results, lintBoard(gameMap, size.Width, size.Height, players, seeds))
+		}
+	}
+	return results
+}
+
+func lintBoard(gameMap GameMap, width, height, players, seeds int) LintResult {
+	result := LintResult{Width: width, Height: height, Players: players}
+	problems := map[string]int{}
+	lastSeen := map[string]int64{}
+	report := func(seed int64, format string, args ...interface{}) {
+		message := fmt.Sprintf(format, args...)
+		// Count each problem once per game
+		if lastSeen[message] == seed {
+			return
+		}
+		lastSeen[message] = seed
+		if i, ok := problems[message]; ok {
+			result.Problems[i].Count++
+			return
+		}
+		problems[message] = len(result.Problems)
+		result.Problems = append(result.Problems, LintProblem{Message: message, Seed: seed, Count: 1})
+	}
+
+	snakeIDs := make([]string, players)
+	for i := range snakeIDs {
+		snakeIDs[i] = fmt.Sprintf("snake_%d", i)
+	}
+
+	for seed := int64(1); seed <= int64(seeds); seed++ {
+		result.Games++
+
+		boardState := rules.NewBoardState(width, height)
+		rules.InitializeSnakes(boardState, snakeIDs)
+		settings := rules.NewSettings(nil).WithSeed(seed)
+		if err := gameMap.SetupBoard(boardState, settings, NewBoardStateEditor(boardState)); err != nil {
+			report(seed, "setup failed: %v", err)
+			continue
+		}
+
+		if !lintSnakes(boardState, func(format string, args ...interface{}) { report(seed, format, args...) }) {
+			continue
+		}
+		for _, p := range boardState.Food {
+			if !isOnBoard(width, height, p.X, p.Y) {
+				report(seed, "food was placed off the board")
+			}
+			for _, wall := range boardState.Walls {
+				if wall.X == p.X && wall.Y == p.Y {
+					report(seed, "food was placed in a wall")
+				}
+			}
+		}
+		if unreachable := countUnreachable(boardState); unreachable > 0 {
+			report(seed, "%d cells without hazards can't be reached without crossing a hazard", unreachable)
+		}
+
+		foodDistances, areas := measureFairness(boardState)
+		result.FoodDistance.add(foodDistances)
+		result.ReachableArea.add(areas)
+	}
+
+	return result
+}
+
+// lintSnakes checks where the snakes were placed, returning false if a snake isn't on the board.
+func lintSnakes(boardState *rules.BoardState, report func(format string, args ...interface{})) bool {
+	hazards := make(map[rules.Point]bool, len(boardState.Hazards))
+	for _, p := range boardState.Hazards {
+		hazards[rules.Point{X: p.X, Y: p.Y}] = true
+	}
+	walls := make(map[rules.Point]bool, len(boardState.Walls))
+	for _, p := range boardState.Walls {
+		walls[rules.Point{X: p.X, Y: p.Y}] = true
+	}
+
+	placed := true
+	occupied := map[rules.Point]string{}
+	for _, snake := range boardState.Snakes {
+		if len(snake.Body) == 0 {
+			report("snake was not placed")
+			placed = false
+			continue
+		}
+		head := snake.Body[0]
+		if !isOnBoard(boardState.Width, boardState.Height, head.X, head.Y) {
+			report("snake was placed off the board")
+			placed = false
+			continue
+		}
+		if hazards[rules.Point{X: head.X, Y: head.Y}] {
+			report("snake starts in a hazard")
+		}
+		if walls[rules.Point{X: head.X, Y: head.Y}] {
+			report("snake starts in a wall")
+		}
+		for _, p := range snake.Body {
+			p = rules.Point{X: p.X, Y: p.Y}
+			if other, ok := occupied[p]; ok && other != snake.ID {
+				report("snakes start on top of each other")
+				break
+			}
+			occupied[p] = snake.ID
+		}
+	}
+	return placed
+}
+
+// countUnreachable counts the cells without hazards or walls that can't be reached from any
+// snake's head without crossing a hazard or wall.
+func countUnreachable(boardState *rules.BoardState) int {
+	// Hazards block the way like walls, but snake bodies don't
+	walled := &rules.BoardState{Width: boardState.Width, Height: boardState.Height}
+	walled.Walls = append(walled.Walls, boardState.Walls...)
+	walled.Walls = append(walled.Walls, boardState.Hazards...)
+
+	reachable := make([]bool, boardState.Width*boardState.Height)
+	for _, p := range walled.Walls {
+		if isOnBoard(boardState.Width, boardState.Height, p.X, p.Y) {
+			reachable[p.Y*boardState.Width+p.X] = true
+		}
+	}
+	for _, snake := range boardState.Snakes {
+		for i, moves := range analysis.Distances(walled, snake.Body[0], analysis.Options{}).Moves {
+			if moves != analysis.Unreachable {
+				reachable[i] = true
+			}
+		}
+	}
+
+	unreachable := 0
+	for _, ok := range reachable {
+		if !ok {
+			unreachable++
+		}
+	}
+	return unreachable
+}
+
+// measureFairness returns the distance from each snake to the nearest food, and the number of
+// cells each snake can reach before any other snake. Snakes that can't reach any food are left
+// out of the food distances.
+func measureFairness(boardState *rules.BoardState) ([]int, []int) {
+	snakeDistances := make([][]int, len(boardState.Snakes))
+	var foodDistances []int
+	for i, snake := range boardState.Snakes {
+		// Snake bodies and walls are in the way, but hazards aren't
+		snakeDistances[i] = analysis.Distances(boardState, snake.Body[0], analysis.Options{StaticBodies: true}).Moves
+
+		nearest := -1
+		for _, food := range boardState.Food {
+			if !isOnBoard(boardState.Width, boardState.Height, food.X, food.Y) {
+				continue
+			}
+			d := snakeDistances[i][food.Y*boardState.Width+food.X]
+			if d >= 0 && (nearest < 0 || d < nearest) {
+				nearest = d
+			}
+		}
+		if nearest >= 0 {
+			foodDistances = append(foodDistances, nearest)
+		}
+	}
+
+	areas := make([]int, len(boardState.Snakes))
+	for cell := 0; cell < boardState.Width*boardState.Height; cell++ {
+		closest, tied := -1, false
+		for i := range boardState.Snakes {
+			d := snakeDistances[i][cell]
+			if d < 0 {
+				continue
+			}
+			if closest < 0 || d < snakeDistances[closest][cell] {
+				closest, tied = i, false
+			} else if d == snakeDistances[closest][cell] {
+				tied = true
+			}
+		}
+		if closest >= 0 && !tied {
+			areas[closest]++
+		}
+	}
+
+	return foodDistances, areas
+}
@@ -0,0 +1,84 @@
+package maps_test
+
+import (
+	"testing"
+
+	"github.com/BattlesnakeOfficial/rules"
+	"github.com/BattlesnakeOfficial/rules/maps"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLintMapStandard(t *testing.T) {
+	results := maps.LintMap(maps.StandardMap{}, 5)
+
+	meta := maps.StandardMap{}.Meta()
+	require.Len(t, results, len(meta.BoardSizes)*(meta.MaxPlayers-meta.MinPlayers+1))
+	for _, result := range results {
+		if result.Width == 11 && result.Players == 4 {
+			require.True(t, result.OK(), "%#v", result.Problems)
+			require.Equal(t, 5, result.Games)
+			// standard placement puts food next to every snake
+			require.Equal(t, 2, result.FoodDistance.Min)
+			require.Equal(t, 2, result.FoodDistance.Max)
+			require.Equal(t, 2.0, result.FoodDistance.Mean())
+			require.Greater(t, result.ReachableArea.Min, 0)
+		}
+	}
+}
+
+func TestLintMapProblems(t *testing.T) {
+	definition, err := maps.ParseASCIIMap([]byte(`
+id: lint_test
+maxPlayers: 2
+
+S..#.
+....#
+..F..
+.....
+....S
+`))
+	require.NoError(t, err)
+	gameMap, err := maps.NewFileMap(definition)
+	require.NoError(t, err)
+
+	// put a hazard on one of the starts
+	definition.Layouts[0].Hazards = append(definition.Layouts[0].Hazards, definition.Layouts[0].SnakeStarts[0])
+	hazardStart, err := maps.NewFileMap(definition)
+	require.NoError(t, err)
+
+	results := maps.LintMap(gameMap, 3)
+	require.Len(t, results, 2)
+	require.Equal(t, 1, results[0].Players)
+	require.Equal(t, 2, results[1].Players)
+	for _, result := range results {
+		// the top right corner is walled off by hazards
+		require.Equal(t, []maps.LintProblem{
+			{Message: "1 cells without hazards can't be reached without crossing a hazard", Seed: 1, Count: 3},
+		}, result.Problems)
+	}
+
+	results = maps.LintMap(hazardStart, 3)
+	require.Len(t, results, 2)
+	var messages []string
+	for _, problem := range results[1].Problems {
+		messages = append(messages, problem.Message)
+		require.Equal(t, int64(1), problem.Seed)
+	}
+	require.Contains(t, messages, "snake starts in a hazard")
+
+	// walls block snakes just like hazards, and snakes can't start in them
+	definition.Layouts[0].Hazards = nil
+	definition.Layouts[0].Walls = []rules.Point{{X: 3, Y: 4}, {X: 4, Y: 3}, definition.Layouts[0].SnakeStarts[1]}
+	wallStart, err := maps.NewFileMap(definition)
+	require.NoError(t, err)
+
+	results = maps.LintMap(wallStart, 1)
+	messages = nil
+	for _, problem := range results[1].Problems {
+		messages = append(messages, problem.Message)
+	}
+	require.ElementsMatch(t, []string{
+		"snake starts in a wall",
+		"1 cells without hazards can't be reached without crossing a hazard",
+	}, messages)
+}
@@ -0,0 +1,248 @@
+package maps
+
+import (
+	"fmt"
+
+	"github.com/BattlesnakeOfficial/rules"
+	"github.com/BattlesnakeOfficial/rules/analysis"
+)
+
+// MazeMap generates a new maze of walls for every game, on any board size.
+type MazeMap struct{}
+
+// mazeMinSize is the smallest board a maze can be generated on.
+const mazeMinSize = 7
+
+var mazeOpenEveryNTurns = Param{
+	Name:        "openEveryNTurns",
+	Type:        ParamTypeInt,
+	Default:     "0",
+	Description: "Number of turns between removing a random wall from the maze. Walls are never removed when 0",
+}
+
+func init() {
+	globalRegistry.RegisterMap("maze", MazeMap{})
+}
+
+func (m MazeMap) ID() string {
+	return "maze"
+}
+
+func (m MazeMap) Meta() Metadata {
+	return Metadata{
+		Name:        "Maze",
+		Description: "Generates a new maze of walls from the game seed. Every snake starts in a room of the same size, and food spawns in the maze's dead ends.",
+		Author:      "Battlesnake",
+		Version:     1,
+		MinPlayers:  1,
+		MaxPlayers:  8,
+		BoardSizes:  AnySize(),
+		Tags:        []string{TAG_FOOD_PLACEMENT, TAG_SNAKE_PLACEMENT},
+		Params:      []Param{mazeOpenEveryNTurns},
+	}
+}
+
+func (m MazeMap) SetupBoard(initialBoardState *rules.BoardState, settings rules.Settings, editor Editor) error {
+	if err := m.Meta().Validate(initialBoardState); err != nil {
+		return err
+	}
+	width, height := initialBoardState.Width, initialBoardState.Height
+	if width < mazeMinSize || height < mazeMinSize {
+		return rules.RulesetError(fmt.Sprintf("This map requires a board size of at least %dx%d", mazeMinSize, mazeMinSize))
+	}
+	wallEditor, err := AsWallEditor(editor)
+	if err != nil {
+		return err
+	}
+
+	rand := settings.GetStageRand(0, m.ID())
+	starts := mazeStarts(width, height, len(initialBoardState.Snakes))
+	walls := generateMaze(rand, width, height, starts)
+
+	if err := editor.PlaceSnakesRandomlyAtPositions(rand, initialBoardState.Snakes, starts, rules.SnakeStartSize); err != nil {
+		return err
+	}
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			if walls[y*width+x] {
+				wallEditor.AddWall(rules.Point{X: x, Y: y})
+			}
+		}
+	}
+
+	// Give every snake the food in the dead end closest to it. Small boards can be all rooms
+	// with no dead ends, so use the closest open cell outside the room instead.
+	blocked := make(map[rules.Point]bool)
+	maze := &rules.BoardState{Width: width, Height: height}
+	var open []rules.Point
+	for i, wall := range walls {
+		p := rules.Point{X: i % width, Y: i / width}
+		if wall {
+			blocked[p] = true
+			maze.Walls = append(maze.Walls, p)
+		} else {
+			open = append(open, p)
+		}
+	}
+	deadEnds := mazeDeadEnds(width, height, blocked)
+	taken := make(map[rules.Point]bool)
+	bodies := editor.SnakeBodies()
+	for _, body := range bodies {
+		taken[body[0]] = true
+	}
+	for _, snake := range initialBoardState.Snakes {
+		head := bodies[snake.ID][0]
+		distances := analysis.Distances(maze, head, analysis.Options{}).Moves
+		for _, candidates := range [][]rules.Point{deadEnds, open} {
+			closest, closestDistance := rules.Point{}, -1
+			for _, p := range candidates {
+				d := distances[p.Y*width+p.X]
+				inRoom := abs(p.X-head.X) <= 1 && abs(p.Y-head.Y) <= 1
+				if d > 0 && !inRoom && !taken[p] && (closestDistance < 0 || d < closestDistance) {
+					closest, closestDistance = p, d
+				}
+			}
+			if closestDistance > 0 {
+				editor.AddFood(closest)
+				taken[closest] = true
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+func (m MazeMap) PreUpdateBoard(lastBoardState *rules.BoardState, settings rules.Settings, editor Editor) error {
+	return nil
+}
+
+func (m MazeMap) PostUpdateBoard(lastBoardState *rules.BoardState, settings rules.Settings, editor Editor) error {
+	rand := settings.GetStageRand(lastBoardState.Turn, m.ID())
+	width, height := lastBoardState.Width, lastBoardState.Height
+	blocked := make(map[rules.Point]bool, len(lastBoardState.Walls))
+	for _, p := range lastBoardState.Walls {
+		blocked[rules.Point{X: p.X, Y: p.Y}] = true
+	}
+
+	foodNeeded := rules.FoodNeeded(rand, settings, lastBoardState)
+	if foodNeeded > 0 {
+		positions := editor.FilterUnoccupiedPoints(mazeDeadEnds(width, height, blocked), true, true, true)
+		if len(positions) < foodNeeded {
+			// Fall back to anywhere in the maze once the dead ends are used up
+			positions = rules.GetUnoccupiedPoints(lastBoardState, false, false)
+		}
+		placeFoodRandomlyAtPositions(rand, lastBoardState, editor, foodNeeded, positions)
+	}
+
+	openEveryNTurns := mazeOpenEveryNTurns.Int(settings)
+	currentTurn := lastBoardState.Turn + 1
+	if openEveryNTurns > 0 && currentTurn%openEveryNTurns == 0 {
+		// Only remove walls that sit between two open cells, so that removing one joins two
+		// passages together
+		open := func(x, y int) bool {
+			return isOnBoard(width, height, x, y) && !blocked[rules.Point{X: x, Y: y}]
+		}
+		var removable []rules.Point
+		for _, p := range lastBoardState.Walls {
+			if (open(p.X-1, p.Y) && open(p.X+1, p.Y)) || (open(p.X, p.Y-1) && open(p.X, p.Y+1)) {
+				removable = append(removable, rules.Point{X: p.X, Y: p.Y})
+			}
+		}
+		if len(removable) > 0 {
+			wallEditor, err := AsWallEditor(editor)
+			if err != nil {
+				return err
+			}
+			wallEditor.RemoveWall(removable[rand.Intn(len(removable))])
+		}
+	}
+
+	return nil
+}
+
+// mazeStarts returns a start position for each snake. Snakes start inset from the corners
+// first, with opposite corners used for 2 snakes, and then inset from the middle of each edge.
+func mazeStarts(width, height, players int) []rules.Point {
+	left, right, bottom, top := 1, width-2, 1, height-2
+	starts := []rules.Point{
+		{X: left, Y: bottom},
+		{X: right, Y: top},
+		{X: left, Y: top},
+		{X: right, Y: bottom},
+		{X: width / 2, Y: bottom},
+		{X: width / 2, Y: top},
+		{X: left, Y: height / 2},
+		{X: right, Y: height / 2},
+	}
+	if players < len(starts) {
+		return starts[:players]
+	}
+	return starts
+}
+
+// generateMaze returns which cells of the board are walls, indexed by y*width+x. Passages run
+// through the cells with even coordinates, and every passage can be reached from every other
+// one. A 3x3 room is opened around each start.
+func generateMaze(rand rules.Rand, width, height int, starts []rules.Point) []bool {
+	walls := make([]bool, width*height)
+	for i := range walls {
+		walls[i] = true
+	}
+
+	// Carve passages with a randomised depth-first search
+	walls[0] = false
+	stack := []rules.Point{{X: 0, Y: 0}}
+	for len(stack) > 0 {
+		p := stack[len(stack)-1]
+		var unvisited []rules.Point
+		for _, next := range []rules.Point{{X: p.X, Y: p.Y + 2}, {X: p.X, Y: p.Y - 2}, {X: p.X - 2, Y: p.Y}, {X: p.X + 2, Y: p.Y}} {
+			if isOnBoard(width, height, next.X, next.Y) && walls[next.Y*width+next.X] {
+				unvisited = append(unvisited, next)
+			}
+		}
+		if len(unvisited) == 0 {
+			stack = stack[:len(stack)-1]
+			continue
+		}
+		next := unvisited[rand.Intn(len(unvisited))]
+		walls[(p.Y+next.Y)/2*width+(p.X+next.X)/2] = false
+		walls[next.Y*width+next.X] = false
+		stack = append(stack, next)
+	}
+
+	// Every 3x3 room contains a passage cell, so rooms stay connected to the maze
+	for _, start := range starts {
+		for dy := -1; dy <= 1; dy++ {
+			for dx := -1; dx <= 1; dx++ {
+				if isOnBoard(width, height, start.X+dx, start.Y+dy) {
+					walls[(start.Y+dy)*width+start.X+dx] = false
+				}
+			}
+		}
+	}
+
+	return walls
+}
+
+// mazeDeadEnds returns the open cells that have only one open neighbour, in a fixed order.
+func mazeDeadEnds(width, height int, blocked map[rules.Point]bool) []rules.Point {
+	var deadEnds []rules.Point
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			if blocked[rules.Point{X: x, Y: y}] {
+				continue
+			}
+			openNeighbours := 0
+			for _, n := range []rules.Point{{X: x, Y: y + 1}, {X: x, Y: y - 1}, {X: x - 1, Y: y}, {X: x + 1, Y: y}} {
+				if isOnBoard(width, height, n.X, n.Y) && !blocked[n] {
+					openNeighbours++
+				}
+			}
+			if openNeighbours == 1 {
+				deadEnds = append(deadEnds, rules.Point{X: x, Y: y})
+			}
+		}
+	}
+	return deadEnds
+}
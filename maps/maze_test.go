@@ -0,0 +1,120 @@
+package maps_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/BattlesnakeOfficial/rules"
+	"github.com/BattlesnakeOfficial/rules/maps"
+	"github.com/stretchr/testify/require"
+)
+
+func setupMaze(t *testing.T, width, height, snakes int, seed int64) *rules.BoardState {
+	t.Helper()
+	snakeIDs := make([]string, snakes)
+	for i := range snakeIDs {
+		snakeIDs[i] = fmt.Sprintf("snake_%d", i)
+	}
+	boardState := rules.NewBoardState(width, height)
+	rules.InitializeSnakes(boardState, snakeIDs)
+	err := maps.MazeMap{}.SetupBoard(boardState, rules.NewSettings(nil).WithSeed(seed), maps.NewBoardStateEditor(boardState))
+	require.NoError(t, err)
+	return boardState
+}
+
+func TestMazeMapSetup(t *testing.T) {
+	for _, size := range []maps.Dimensions{{7, 7}, {11, 11}, {12, 9}, {19, 21}} {
+		for snakes := 1; snakes <= 8; snakes++ {
+			boardState := setupMaze(t, size.Width, size.Height, snakes, 42)
+			require.NotEmpty(t, boardState.Walls)
+			require.Empty(t, boardState.Hazards)
+
+			walls := map[rules.Point]bool{}
+			for _, p := range boardState.Walls {
+				walls[p] = true
+			}
+			for _, snake := range boardState.Snakes {
+				require.Len(t, snake.Body, rules.SnakeStartSize)
+				require.False(t, walls[snake.Body[0]], "snake starts in a wall")
+			}
+			require.Len(t, boardState.Food, snakes)
+			for _, food := range boardState.Food {
+				require.False(t, walls[food], "food placed in a wall")
+			}
+		}
+	}
+
+	// Same seed, same maze
+	require.Equal(t, setupMaze(t, 11, 11, 2, 1).Walls, setupMaze(t, 11, 11, 2, 1).Walls)
+	require.NotEqual(t, setupMaze(t, 11, 11, 2, 1).Walls, setupMaze(t, 11, 11, 2, 2).Walls)
+}
+
+func TestMazeMapTwoPlayersStartOpposite(t *testing.T) {
+	boardState := setupMaze(t, 11, 11, 2, 1)
+	heads := []rules.Point{boardState.Snakes[0].Body[0], boardState.Snakes[1].Body[0]}
+	require.ElementsMatch(t, []rules.Point{{X: 1, Y: 1}, {X: 9, Y: 9}}, heads)
+}
+
+func TestMazeMapConnected(t *testing.T) {
+	for _, result := range maps.LintMap(maps.MazeMap{}, 10) {
+		require.True(t, result.OK(), "%dx%d with %d players: %v", result.Width, result.Height, result.Players, result.Problems)
+	}
+}
+
+func TestMazeMapTooSmall(t *testing.T) {
+	boardState := rules.NewBoardState(5, 5)
+	rules.InitializeSnakes(boardState, []string{"one"})
+	err := maps.MazeMap{}.SetupBoard(boardState, rules.Settings{}, maps.NewBoardStateEditor(boardState))
+	require.Error(t, err)
+}
+
+func TestMazeMapNeedsWallEditor(t *testing.T) {
+	boardState := rules.NewBoardState(11, 11)
+	rules.InitializeSnakes(boardState, []string{"one"})
+	err := maps.MazeMap{}.SetupBoard(boardState, rules.NewSettings(nil), editorWithoutWalls{maps.NewBoardStateEditor(boardState)})
+	require.Equal(t, maps.ErrorWallsNotSupported, err)
+}
+
+func TestMazeMapFoodInDeadEnds(t *testing.T) {
+	boardState := setupMaze(t, 11, 11, 1, 3)
+	settings := rules.NewSettings(map[string]string{
+		rules.ParamFoodSpawnChance: "100",
+		rules.ParamMinimumFood:     "1",
+	}).WithSeed(3)
+
+	walls := map[rules.Point]bool{}
+	for _, p := range boardState.Walls {
+		walls[p] = true
+	}
+	boardState, err := maps.PostUpdateBoard(maps.MazeMap{}, boardState, settings)
+	require.NoError(t, err)
+	require.Len(t, boardState.Food, 2)
+
+	food := boardState.Food[1]
+	openNeighbours := 0
+	for _, n := range []rules.Point{{X: food.X, Y: food.Y + 1}, {X: food.X, Y: food.Y - 1}, {X: food.X - 1, Y: food.Y}, {X: food.X + 1, Y: food.Y}} {
+		if n.X >= 0 && n.Y >= 0 && n.X < 11 && n.Y < 11 && !walls[n] {
+			openNeighbours++
+		}
+	}
+	require.Equal(t, 1, openNeighbours, "food %v should spawn in a dead end", food)
+}
+
+func TestMazeMapOpensWalls(t *testing.T) {
+	boardState := setupMaze(t, 11, 11, 2, 5)
+	walls := len(boardState.Walls)
+
+	// Walls stay put by default
+	next, err := maps.PostUpdateBoard(maps.MazeMap{}, boardState, rules.NewSettings(nil).WithSeed(5))
+	require.NoError(t, err)
+	require.Len(t, next.Walls, walls)
+
+	settings := rules.NewSettings(map[string]string{"map.openEveryNTurns": "3"}).WithSeed(5)
+	for turn := 0; turn < 9; turn++ {
+		boardState.Turn = turn
+		boardState, err = maps.PostUpdateBoard(maps.MazeMap{}, boardState, settings)
+		require.NoError(t, err)
+	}
+	require.Len(t, boardState.Walls, walls-3)
+	require.Empty(t, boardState.Hazards)
+}
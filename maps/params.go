@@ -0,0 +1,124 @@
+package maps
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/BattlesnakeOfficial/rules"
+)
+
+// Types of map parameters.
+const (
+	ParamTypeInt  = "int"
+	ParamTypeBool = "bool"
+)
+
+// mapParamPrefix keeps map parameters apart from ruleset parameters in the game settings.
+const mapParamPrefix = "map."
+
+// Param is a tunable parameter that a map declares in its Metadata and reads from the game
+// settings.
+type Param struct {
+	Name string
+	// Type is ParamTypeInt or ParamTypeBool.
+	Type string
+	// Default is the value used when the parameter isn't set. It's empty when the default
+	// depends on something else, like the board size, which the description should explain.
+	Default     string
+	Description string
+}
+
+// SettingsKey is the key the parameter's value is stored under in the game settings.
+func (p Param) SettingsKey() string {
+	return mapParamPrefix + p.Name
+}
+
+// IsSet checks whether the parameter has been given a valid value in the game settings.
+func (p Param) IsSet(settings rules.Settings) bool {
+	return p.validate(settings.String(p.SettingsKey(), "")) == nil
+}
+
+// Int returns the value of an int parameter, or its default if it isn't set.
+func (p Param) Int(settings rules.Settings) int {
+	defaultValue, _ := strconv.Atoi(p.Default)
+	return settings.Int(p.SettingsKey(), defaultValue)
+}
+
+// positiveInt returns the value of an int parameter, or an error if it's less than 1.
+func (p Param) positiveInt(settings rules.Settings) (int, error) {
+	value := p.Int(settings)
+	if value < 1 {
+		return 0, rules.RulesetError(fmt.Sprintf("map parameter %q must be at least 1", p.Name))
+	}
+	return value, nil
+}
+
+// Bool returns the value of a bool parameter, or its default if it isn't set.
+func (p Param) Bool(settings rules.Settings) bool {
+	return settings.Bool(p.SettingsKey(), p.Default == "true")
+}
+
+// validate checks that a value is valid for the parameter's type.
+func (p Param) validate(value string) error {
+	var err error
+	switch p.Type {
+	case ParamTypeInt:
+		_, err = strconv.Atoi(value)
+	case ParamTypeBool:
+		_, err = strconv.ParseBool(value)
+	default:
+		return fmt.Errorf("parameter %q has unknown type %q", p.Name, p.Type)
+	}
+	if err != nil {
+		return fmt.Errorf("parameter %q must be %s %s, got %q", p.Name, article(p.Type), p.Type, value)
+	}
+	return nil
+}
+
+// article returns the indefinite article for a parameter type.
+func article(paramType string) string {
+	if paramType == ParamTypeInt {
+		return "an"
+	}
+	return "a"
+}
+
+// ParamSettings checks values given for a map's parameters by name, and returns them keyed for
+// the game settings. It returns an error if the map doesn't declare a parameter or a value is
+// invalid.
+func (meta Metadata) ParamSettings(values map[string]string) (map[string]string, error) {
+	params := make(map[string]Param, len(meta.Params))
+	for _, p := range meta.Params {
+		params[p.Name] = p
+	}
+
+	result := make(map[string]string, len(values))
+	for name, value := range values {
+		p, ok := params[name]
+		if !ok {
+			return nil, fmt.Errorf("map %q has no parameter %q", meta.Name, name)
+		}
+		if err := p.validate(value); err != nil {
+			return nil, err
+		}
+		if p.Type == ParamTypeBool {
+			// Settings only treat "true" as true
+			b, _ := strconv.ParseBool(value)
+			value = strconv.FormatBool(b)
+		}
+		result[p.SettingsKey()] = value
+	}
+	return result, nil
+}
+
+// ParamValues is the reverse of ParamSettings, returning the values of a map's parameters by name
+// from the game settings. Parameters that aren't set are left out.
+func (meta Metadata) ParamValues(settings rules.Settings) map[string]string {
+	values := make(map[string]string)
+	for _, p := range meta.Params {
+		if value := settings.String(p.SettingsKey(), ""); value != "" {
+			values[p.Name] = value
+		}
+	}
+	return values
+}
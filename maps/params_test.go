@@ -0,0 +1,77 @@
+package maps_test
+
+import (
+	"testing"
+
+	"github.com/BattlesnakeOfficial/rules"
+	"github.com/BattlesnakeOfficial/rules/maps"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParam(t *testing.T) {
+	count := maps.Param{Name: "count", Type: maps.ParamTypeInt, Default: "3"}
+	enabled := maps.Param{Name: "enabled", Type: maps.ParamTypeBool, Default: "true"}
+	require.Equal(t, "map.count", count.SettingsKey())
+
+	settings := rules.NewSettings(nil)
+	require.False(t, count.IsSet(settings))
+	require.Equal(t, 3, count.Int(settings))
+	require.True(t, enabled.Bool(settings))
+
+	settings = rules.NewSettings(map[string]string{"map.count": "7", "map.enabled": "false"})
+	require.True(t, count.IsSet(settings))
+	require.Equal(t, 7, count.Int(settings))
+	require.False(t, enabled.Bool(settings))
+
+	// invalid values fall back to the default
+	settings = rules.NewSettings(map[string]string{"map.count": "x"})
+	require.False(t, count.IsSet(settings))
+	require.Equal(t, 3, count.Int(settings))
+}
+
+func TestMetadataParamSettings(t *testing.T) {
+	meta := maps.Metadata{
+		Name: "Test",
+		Params: []maps.Param{
+			{Name: "count", Type: maps.ParamTypeInt, Default: "3"},
+			{Name: "enabled", Type: maps.ParamTypeBool},
+		},
+	}
+
+	settings, err := meta.ParamSettings(map[string]string{"count": "5", "enabled": "1"})
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{"map.count": "5", "map.enabled": "true"}, settings)
+
+	_, err = meta.ParamSettings(map[string]string{"size": "5"})
+	require.EqualError(t, err, `map "Test" has no parameter "size"`)
+
+	_, err = meta.ParamSettings(map[string]string{"count": "five"})
+	require.EqualError(t, err, `parameter "count" must be an int, got "five"`)
+
+	_, err = meta.ParamSettings(map[string]string{"enabled": "maybe"})
+	require.EqualError(t, err, `parameter "enabled" must be a bool, got "maybe"`)
+}
+
+func TestMapParams(t *testing.T) {
+	// sinkholes grows every turn, and stops growing on turn spawnEveryNTurns * maxRings
+	settings := rules.NewSettings(map[string]string{"map.spawnEveryNTurns": "1", "map.maxRings": "2"})
+	boardState, err := maps.SetupBoard(maps.SinkholesMap{}.ID(), settings, 11, 11, []string{"a"})
+	require.NoError(t, err)
+	for i := 0; i < 5; i++ {
+		boardState, err = maps.PostUpdateBoard(maps.SinkholesMap{}, boardState, settings)
+		require.NoError(t, err)
+		boardState.Turn++
+	}
+	// the center, then one ring without its corners layered over it
+	require.Len(t, boardState.Hazards, 1+5)
+
+	// hazards can't spawn more often than every turn
+	settings = rules.NewSettings(map[string]string{"map.spawnEveryNTurns": "0"})
+	_, err = maps.PostUpdateBoard(maps.SpiralHazardsMap{}, boardState, settings)
+	require.EqualError(t, err, `map parameter "spawnEveryNTurns" must be at least 1`)
+
+	// layers of a composite map share parameters with the same name
+	gameMap, err := maps.GetMap("hz_spiral+hz_scatter")
+	require.NoError(t, err)
+	require.Len(t, gameMap.Meta().Params, 1)
+}
@@ -3,6 +3,7 @@ package maps
 import (
 	"fmt"
 	"sort"
+	"strings"
 
 	"github.com/BattlesnakeOfficial/rules"
 )
@@ -15,11 +16,20 @@ var globalRegistry = MapRegistry{}
 // RegisterMap adds a stage to the registry.
 // If a map has already been registered this will panic.
 func (registry MapRegistry) RegisterMap(id string, m GameMap) {
+	if err := registry.RegisterMapError(id, m); err != nil {
+		panic(err.Error())
+	}
+}
+
+// RegisterMapError adds a map to the registry.
+// If a map has already been registered with the same ID an error will be returned.
+func (registry MapRegistry) RegisterMapError(id string, m GameMap) error {
 	if _, ok := registry[id]; ok {
-		panic(fmt.Sprintf("map '%s' has already been registered", id))
+		return rules.RulesetError(fmt.Sprintf("map '%s' has already been registered", id))
 	}
 
 	registry[id] = m
+	return nil
 }
 
 // List returns all registered map IDs in alphabetical order
@@ -33,10 +43,14 @@ func (registry MapRegistry) List() []string {
 }
 
 // GetMap returns the map associated with the given ID.
+// IDs of registered maps joined with CompositeMapSeparator return a CompositeMap stacking them.
 func (registry MapRegistry) GetMap(id string) (GameMap, error) {
 	if m, ok := registry[id]; ok {
 		return m, nil
 	}
+	if strings.Contains(id, CompositeMapSeparator) {
+		return registry.getCompositeMap(id)
+	}
 	return nil, rules.ErrorMapNotFound
 }
 
@@ -55,6 +69,12 @@ func RegisterMap(id string, m GameMap) {
 	globalRegistry.RegisterMap(id, m)
 }
 
+// RegisterMapError adds a map to the global registry, returning an error if the ID is already taken.
+// This allows maps loaded at runtime, such as a FileMap, to be used anywhere a built-in map can.
+func RegisterMapError(id string, m GameMap) error {
+	return globalRegistry.RegisterMapError(id, m)
+}
+
 func TestMap(id string, m GameMap, callback func()) {
 	globalRegistry[id] = m
 	callback()
@@ -27,6 +27,16 @@ func TestRegisteredMaps(t *testing.T) {
 			require.LessOrEqual(t, meta.MaxPlayers, meta.MaxPlayers, "max players should always be >= min players")
 			require.NotEmpty(t, meta.BoardSizes, "registered maps must have at least one supported size declared")
 			require.NotNil(t, meta.Tags)
+			paramNames := map[string]bool{}
+			for _, param := range meta.Params {
+				require.False(t, paramNames[param.Name], "parameter %q is declared more than once", param.Name)
+				paramNames[param.Name] = true
+				require.Contains(t, []string{ParamTypeInt, ParamTypeBool}, param.Type)
+				require.NotEmpty(t, param.Description)
+				if param.Default != "" {
+					require.NoError(t, param.validate(param.Default))
+				}
+			}
 			var setupBoardState *rules.BoardState
 
 			// "fuzz test" supported players
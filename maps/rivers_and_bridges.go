@@ -12,8 +12,8 @@ func init() {
 	globalRegistry.RegisterMap("hz_islands_bridges_lg", IslandsAndBridgesLargeHazardsMap{})
 }
 
-func setupRiverAndBridgesBoard(startingPositions [][]rules.Point, hazards []rules.Point, initialBoardState *rules.BoardState, settings rules.Settings, editor Editor) error {
-	rand := settings.GetRand(0)
+func setupRiverAndBridgesBoard(mapID string, startingPositions [][]rules.Point, hazards []rules.Point, initialBoardState *rules.BoardState, settings rules.Settings, editor Editor) error {
+	rand := settings.GetStageRand(0, mapID)
 
 	err := PlaceSnakesInQuadrants(rand, editor, initialBoardState.Snakes, startingPositions)
 	if err != nil {
@@ -32,16 +32,10 @@ func setupRiverAndBridgesBoard(startingPositions [][]rules.Point, hazards []rule
 	return nil
 }
 
-func placeRiverAndBridgesFood(lastBoardState *rules.BoardState, settings rules.Settings, editor Editor) error {
-	rand := settings.GetRand(lastBoardState.Turn)
+func placeRiverAndBridgesFood(mapID string, lastBoardState *rules.BoardState, settings rules.Settings, editor Editor) error {
+	rand := settings.GetStageRand(lastBoardState.Turn, mapID)
 
-	foodNeeded := checkFoodNeedingPlacement(rand, settings, lastBoardState)
-	if foodNeeded > 0 {
-		pts := rules.GetUnoccupiedPoints(lastBoardState, false, true)
-		placeFoodRandomlyAtPositions(rand, lastBoardState, editor, foodNeeded, pts)
-	}
-
-	return nil
+	return spawnFood(rand, settings, lastBoardState, editor, rules.GetUnoccupiedPoints(lastBoardState, false, true))
 }
 
 type RiverAndBridgesMediumHazardsMap struct{}
@@ -68,7 +62,7 @@ func (m RiverAndBridgesMediumHazardsMap) SetupBoard(initialBoardState *rules.Boa
 	if err := m.Meta().Validate(initialBoardState); err != nil {
 		return err
 	}
-	return setupRiverAndBridgesBoard(riversAndBridgesMediumStartPositions, riversAndBridgesMediumHazards, initialBoardState, settings, editor)
+	return setupRiverAndBridgesBoard(m.ID(), riversAndBridgesMediumStartPositions, riversAndBridgesMediumHazards, initialBoardState, settings, editor)
 }
 
 func (m RiverAndBridgesMediumHazardsMap) PreUpdateBoard(lastBoardState *rules.BoardState, settings rules.Settings, editor Editor) error {
@@ -76,7 +70,7 @@ func (m RiverAndBridgesMediumHazardsMap) PreUpdateBoard(lastBoardState *rules.Bo
 }
 
 func (m RiverAndBridgesMediumHazardsMap) PostUpdateBoard(lastBoardState *rules.BoardState, settings rules.Settings, editor Editor) error {
-	return placeRiverAndBridgesFood(lastBoardState, settings, editor)
+	return placeRiverAndBridgesFood(m.ID(), lastBoardState, settings, editor)
 }
 
 var riversAndBridgesMediumStartPositions = [][]rules.Point{
@@ -143,7 +137,7 @@ func (m RiverAndBridgesLargeHazardsMap) SetupBoard(initialBoardState *rules.Boar
 		return err
 	}
 
-	return setupRiverAndBridgesBoard(riversAndBridgesLargeStartPositions, riversAndBridgesLargeHazards, initialBoardState, settings, editor)
+	return setupRiverAndBridgesBoard(m.ID(), riversAndBridgesLargeStartPositions, riversAndBridgesLargeHazards, initialBoardState, settings, editor)
 }
 
 func (m RiverAndBridgesLargeHazardsMap) PreUpdateBoard(lastBoardState *rules.BoardState, settings rules.Settings, editor Editor) error {
@@ -151,7 +145,7 @@ func (m RiverAndBridgesLargeHazardsMap) PreUpdateBoard(lastBoardState *rules.Boa
 }
 
 func (m RiverAndBridgesLargeHazardsMap) PostUpdateBoard(lastBoardState *rules.BoardState, settings rules.Settings, editor Editor) error {
-	return placeRiverAndBridgesFood(lastBoardState, settings, editor)
+	return placeRiverAndBridgesFood(m.ID(), lastBoardState, settings, editor)
 }
 
 var riversAndBridgesLargeStartPositions = [][]rules.Point{
@@ -246,7 +240,7 @@ func (m RiverAndBridgesExtraLargeHazardsMap) SetupBoard(initialBoardState *rules
 		return err
 	}
 
-	return setupRiverAndBridgesBoard(riversAndBridgesExtraLargeStartPositions, riversAndBridgesExtraLargeHazards, initialBoardState, settings, editor)
+	return setupRiverAndBridgesBoard(m.ID(), riversAndBridgesExtraLargeStartPositions, riversAndBridgesExtraLargeHazards, initialBoardState, settings, editor)
 }
 
 func (m RiverAndBridgesExtraLargeHazardsMap) PreUpdateBoard(lastBoardState *rules.BoardState, settings rules.Settings, editor Editor) error {
@@ -254,7 +248,7 @@ func (m RiverAndBridgesExtraLargeHazardsMap) PreUpdateBoard(lastBoardState *rule
 }
 
 func (m RiverAndBridgesExtraLargeHazardsMap) PostUpdateBoard(lastBoardState *rules.BoardState, settings rules.Settings, editor Editor) error {
-	return placeRiverAndBridgesFood(lastBoardState, settings, editor)
+	return placeRiverAndBridgesFood(m.ID(), lastBoardState, settings, editor)
 }
 
 var riversAndBridgesExtraLargeStartPositions = [][]rules.Point{
@@ -364,7 +358,7 @@ func (m IslandsAndBridgesMediumHazardsMap) SetupBoard(initialBoardState *rules.B
 		return err
 	}
 
-	return setupRiverAndBridgesBoard(islandsAndBridgesMediumStartPositions, islandsAndBridgesMediumHazards, initialBoardState, settings, editor)
+	return setupRiverAndBridgesBoard(m.ID(), islandsAndBridgesMediumStartPositions, islandsAndBridgesMediumHazards, initialBoardState, settings, editor)
 }
 
 func (m IslandsAndBridgesMediumHazardsMap) PreUpdateBoard(lastBoardState *rules.BoardState, settings rules.Settings, editor Editor) error {
@@ -372,7 +366,7 @@ func (m IslandsAndBridgesMediumHazardsMap) PreUpdateBoard(lastBoardState *rules.
 }
 
 func (m IslandsAndBridgesMediumHazardsMap) PostUpdateBoard(lastBoardState *rules.BoardState, settings rules.Settings, editor Editor) error {
-	return placeRiverAndBridgesFood(lastBoardState, settings, editor)
+	return placeRiverAndBridgesFood(m.ID(), lastBoardState, settings, editor)
 }
 
 var islandsAndBridgesMediumStartPositions = [][]rules.Point{
@@ -454,7 +448,7 @@ func (m IslandsAndBridgesLargeHazardsMap) SetupBoard(initialBoardState *rules.Bo
 		return err
 	}
 
-	return setupRiverAndBridgesBoard(islandsAndBridgesLargeStartPositions, islandsAndBridgesLargeHazards, initialBoardState, settings, editor)
+	return setupRiverAndBridgesBoard(m.ID(), islandsAndBridgesLargeStartPositions, islandsAndBridgesLargeHazards, initialBoardState, settings, editor)
 }
 
 func (m IslandsAndBridgesLargeHazardsMap) PreUpdateBoard(lastBoardState *rules.BoardState, settings rules.Settings, editor Editor) error {
@@ -462,7 +456,7 @@ func (m IslandsAndBridgesLargeHazardsMap) PreUpdateBoard(lastBoardState *rules.B
 }
 
 func (m IslandsAndBridgesLargeHazardsMap) PostUpdateBoard(lastBoardState *rules.BoardState, settings rules.Settings, editor Editor) error {
-	return placeRiverAndBridgesFood(lastBoardState, settings, editor)
+	return placeRiverAndBridgesFood(m.ID(), lastBoardState, settings, editor)
 }
 
 var islandsAndBridgesLargeStartPositions = [][]rules.Point{
@@ -59,7 +59,7 @@ func (m RoyaleHazardsMap) PostUpdateBoard(lastBoardState *rules.BoardState, sett
 	editor.ClearHazards()
 
 	// Get random generator for turn zero, because we're regenerating all hazards every time.
-	randGenerator := settings.GetRand(0)
+	randGenerator := settings.GetStageRand(0, m.ID())
 
 	numShrinks := turn / shrinkEveryNTurns
 	minX, maxX := 0, lastBoardState.Width-1
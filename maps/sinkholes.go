@@ -8,6 +8,20 @@ import (
 
 type SinkholesMap struct{}
 
+var (
+	sinkholesSpawnEveryNTurns = Param{
+		Name:        "spawnEveryNTurns",
+		Type:        ParamTypeInt,
+		Default:     "10",
+		Description: "Number of turns between each growth of the sinkhole. Uses shrinkEveryNTurns instead of the default when that's set",
+	}
+	sinkholesMaxRings = Param{
+		Name:        "maxRings",
+		Type:        ParamTypeInt,
+		Description: "Number of times the sinkhole grows. Defaults to 3 on 7x7 boards, 7 on 19x19 boards and 5 on other sizes",
+	}
+)
+
 func init() {
 	globalRegistry.RegisterMap("sinkholes", SinkholesMap{})
 }
@@ -26,6 +40,7 @@ func (m SinkholesMap) Meta() Metadata {
 		MaxPlayers:  8,
 		BoardSizes:  FixedSizes(Dimensions{7, 7}, Dimensions{11, 11}, Dimensions{19, 19}),
 		Tags:        []string{TAG_HAZARD_PLACEMENT},
+		Params:      []Param{sinkholesSpawnEveryNTurns, sinkholesMaxRings},
 	}
 }
 
@@ -45,9 +60,12 @@ func (m SinkholesMap) PostUpdateBoard(lastBoardState *rules.BoardState, settings
 
 	currentTurn := lastBoardState.Turn
 	startTurn := 1
-	spawnEveryNTurns := 10
+	spawnEveryNTurns, err := sinkholesSpawnEveryNTurns.positiveInt(settings)
+	if err != nil {
+		return err
+	}
 	shrinkEveryNTurns := settings.Int(rules.ParamShrinkEveryNTurns, 0)
-	if shrinkEveryNTurns > 0 {
+	if shrinkEveryNTurns > 0 && !sinkholesSpawnEveryNTurns.IsSet(settings) {
 		spawnEveryNTurns = shrinkEveryNTurns
 	}
 	maxRings := 5
@@ -56,6 +74,9 @@ func (m SinkholesMap) PostUpdateBoard(lastBoardState *rules.BoardState, settings
 	} else if lastBoardState.Width == 19 {
 		maxRings = 7
 	}
+	if sinkholesMaxRings.IsSet(settings) {
+		maxRings = sinkholesMaxRings.Int(settings)
+	}
 
 	spawnLocation := rules.Point{X: lastBoardState.Width / 2, Y: lastBoardState.Height / 2}
 
@@ -36,22 +36,9 @@ func (m SnailModeMap) SetupBoard(initialBoardState *rules.BoardState, settings r
 	return StandardMap{}.SetupBoard(initialBoardState, settings, editor)
 }
 
-// storeTailLocation returns an offboard point that corresponds to the given point.
-// This is useful for storing state that can be accessed next turn.
-func storeTailLocation(point rules.Point, height int) rules.Point {
-	return rules.Point{X: point.X, Y: point.Y + height}
-}
-
-// getPrevTailLocation returns the onboard point that corresponds to an offboard point.
-// This is useful for restoring state that was stored last turn.
-func getPrevTailLocation(point rules.Point, height int) rules.Point {
-	return rules.Point{X: point.X, Y: point.Y - height}
-}
-
-// outOfBounds determines if the given point is out of bounds for the current board size
-func outOfBounds(p rules.Point, w, h int) bool {
-	return p.X < 0 || p.Y < 0 || p.X >= w || p.Y >= h
-}
+// snailTailsState is the name of the private state that holds the tails of snakes from the
+// previous turn, with each tail's Value set to the number of hazards to stack there.
+const snailTailsState = "tails"
 
 // doubleTail determine if the snake has a double stacked tail currently
 func doubleTail(snake *rules.Snake) bool {
@@ -70,7 +57,7 @@ func (m SnailModeMap) PreUpdateBoard(lastBoardState *rules.BoardState, settings
 }
 
 // PostUpdateBoard does the work of placing the hazards along the 'snail tail' of snakes
-// This is responsible for saving the current tail location off the board
+// This is responsible for saving the current tail location in private state
 // and restoring the previous tail position. This also handles removing one hazards from
 // the current stacks so the hazards tails fade as the snake moves away.
 func (m SnailModeMap) PostUpdateBoard(lastBoardState *rules.BoardState, settings rules.Settings, editor Editor) error {
@@ -84,23 +71,17 @@ func (m SnailModeMap) PostUpdateBoard(lastBoardState *rules.BoardState, settings
 	editor.ClearHazards()
 
 	// This is a list of all the hazards we want to add for the previous tails
-	// These were stored off board in the previous turn as a way to save state
-	// When we add the locations to this list we have already converted the off-board
-	// points to on-board points
-	tailLocations := make([]rules.Point, 0, len(lastBoardState.Snakes))
+	// These were saved in private state in the previous turn, stacked based on
+	// the length of the snake
+	var tailLocations []rules.Point
+	if _, err := rules.GetState(lastBoardState.GameState, m.ID(), snailTailsState, &tailLocations); err != nil {
+		return err
+	}
 
 	// Count the number of hazards for a given position
-	// Add non-double tail locations to a slice
 	hazardCounts := map[rules.Point]int{}
 	for _, hazard := range lastBoardState.Hazards {
-
-		// discard out of bound
-		if outOfBounds(hazard, lastBoardState.Width, lastBoardState.Height) {
-			onBoardTail := getPrevTailLocation(hazard, lastBoardState.Height)
-			tailLocations = append(tailLocations, onBoardTail)
-		} else {
-			hazardCounts[hazard]++
-		}
+		hazardCounts[hazard]++
 	}
 
 	// Add back existing hazards, but with a stack of 1 less than before.
@@ -112,9 +93,9 @@ func (m SnailModeMap) PostUpdateBoard(lastBoardState *rules.BoardState, settings
 		}
 	}
 
-	// Store a stack of hazards for the tail of each snake.  This is stored out
-	// of bounds and then applied on the next turn.  The stack count is equal
-	// the lenght of the snake.
+	// Save the tail of each snake, to be turned into a stack of hazards on
+	// the next turn. The stack count is equal the length of the snake.
+	tails := make([]rules.Point, 0, len(lastBoardState.Snakes))
 	for _, snake := range lastBoardState.Snakes {
 		if isEliminated(&snake) {
 			continue
@@ -127,14 +108,13 @@ func (m SnailModeMap) PostUpdateBoard(lastBoardState *rules.BoardState, settings
 		}
 
 		tail := snake.Body[len(snake.Body)-1]
-		offBoardTail := storeTailLocation(tail, lastBoardState.Height)
-		for i := 0; i < len(snake.Body); i++ {
-			editor.AddHazard(offBoardTail)
-		}
+		tails = append(tails, rules.Point{X: tail.X, Y: tail.Y, Value: len(snake.Body)})
+	}
+	if err := rules.SetState(editor.GameState(), m.ID(), snailTailsState, tails); err != nil {
+		return err
 	}
 
-	// Read offboard tails and move them to the board. The offboard tails are
-	// stacked based on the length of the snake
+	// Move the previous tails to the board.
 	for _, p := range tailLocations {
 
 		// Skip position if a snakes head occupies it.
@@ -155,7 +135,9 @@ func (m SnailModeMap) PostUpdateBoard(lastBoardState *rules.BoardState, settings
 			continue
 		}
 
-		editor.AddHazard(p)
+		for i := 0; i < p.Value; i++ {
+			editor.AddHazard(rules.Point{X: p.X, Y: p.Y})
+		}
 	}
 
 	return nil
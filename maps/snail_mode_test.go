@@ -0,0 +1,35 @@
+package maps_test
+
+import (
+	"testing"
+
+	"github.com/BattlesnakeOfficial/rules"
+	"github.com/BattlesnakeOfficial/rules/maps"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSnailModeTrail(t *testing.T) {
+	m := maps.SnailModeMap{}
+	settings := rules.Settings{}
+	boardState := rules.NewBoardState(11, 11).WithSnakes([]rules.Snake{
+		{ID: "one", Health: 100, Body: []rules.Point{{X: 5, Y: 6}, {X: 5, Y: 5}, {X: 5, Y: 4}}},
+	})
+
+	// The tail is saved in private state instead of the hazards
+	boardState, err := maps.PostUpdateBoard(m, boardState, settings)
+	require.NoError(t, err)
+	require.Empty(t, boardState.Hazards)
+	require.NotEmpty(t, boardState.GameState)
+
+	// After the snake moves, the old tail becomes a stack of hazards as long as the snake
+	boardState.Snakes[0].Body = []rules.Point{{X: 5, Y: 7}, {X: 5, Y: 6}, {X: 5, Y: 5}}
+	boardState, err = maps.PostUpdateBoard(m, boardState, settings)
+	require.NoError(t, err)
+	require.Equal(t, []rules.Point{{X: 5, Y: 4}, {X: 5, Y: 4}, {X: 5, Y: 4}}, boardState.Hazards)
+
+	// Stacks shrink by one every turn
+	boardState.Snakes[0].Body = []rules.Point{{X: 5, Y: 8}, {X: 5, Y: 7}, {X: 5, Y: 6}}
+	boardState, err = maps.PostUpdateBoard(m, boardState, settings)
+	require.NoError(t, err)
+	require.ElementsMatch(t, []rules.Point{{X: 5, Y: 4}, {X: 5, Y: 4}, {X: 5, Y: 5}, {X: 5, Y: 5}, {X: 5, Y: 5}}, boardState.Hazards)
+}
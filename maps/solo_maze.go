@@ -2,7 +2,6 @@ package maps
 
 import (
 	"fmt"
-	"strconv"
 
 	"bufio"
 	"bytes"
@@ -80,7 +79,7 @@ func gameNeedsToEndSoon(maxBoardSize int, currentLevel int64) bool {
 }
 
 func (m SoloMazeMap) CreateMaze(initialBoardState *rules.BoardState, settings rules.Settings, editor Editor, currentLevel int64) error {
-	rand := settings.GetRand(initialBoardState.Turn)
+	rand := settings.GetStageRand(initialBoardState.Turn, m.ID())
 
 	// Make sure the actual maze size can always fit in the CreateBoard
 	// This means that when you get to 'max' size each level stops making
@@ -101,7 +100,9 @@ func (m SoloMazeMap) CreateMaze(initialBoardState *rules.BoardState, settings ru
 
 	editor.ClearHazards()
 
-	m.WriteBitState(initialBoardState, currentLevel, editor)
+	if err := m.WriteLevel(currentLevel, editor); err != nil {
+		return err
+	}
 
 	m.SubdivideRoom(mazeBoardState, rand, rules.Point{X: 0, Y: 0}, topRightCorner, make([]int, 0), make([]int, 0), 0)
 
@@ -111,7 +112,7 @@ func (m SoloMazeMap) CreateMaze(initialBoardState *rules.BoardState, settings ru
 		tempBoardState.Hazards = append(tempBoardState.Hazards, adjusted)
 	}
 
-	// Since we reserve the bottom row of the board for state,
+	// Since we keep the maze at least 2 rows shorter than the board,
 	// AND we center the maze within the board we know there will
 	// always be a `y: -1` that we can put the tail into
 	snake_head_position := rules.Point{X: 0, Y: 0}
@@ -138,7 +139,7 @@ func (m SoloMazeMap) CreateMaze(initialBoardState *rules.BoardState, settings ru
 	xAdjust := int((initialBoardState.Width - int(actualBoardSize)) / 2)
 	yAdjust := int((initialBoardState.Height - int(actualBoardSize)) / 2)
 	for x := 0; x < initialBoardState.Width; x++ {
-		for y := 1; y < initialBoardState.Height; y++ {
+		for y := 0; y < initialBoardState.Height; y++ {
 			if x < xAdjust || y < yAdjust || x >= xAdjust+int(actualBoardSize) || y >= yAdjust+int(actualBoardSize) {
 				editor.AddHazard(rules.Point{X: x, Y: y})
 			}
@@ -163,7 +164,7 @@ func (m SoloMazeMap) PlaceFood(boardState *rules.BoardState, settings rules.Sett
 	// trying to place a food.
 	for !foodPlaced && tries < MAX_TRIES {
 		tries++
-		rand := settings.GetRand(boardState.Turn + tries)
+		rand := settings.GetStageRand(boardState.Turn+tries, m.ID())
 
 		foodSpawnPoint := rules.Point{X: rand.Intn(int(actualBoardSize)), Y: rand.Intn(int(actualBoardSize))}
 		adjustedFood := m.AdjustPosition(foodSpawnPoint, int(actualBoardSize), boardState.Height, boardState.Width)
@@ -181,16 +182,15 @@ func (m SoloMazeMap) PreUpdateBoard(lastBoardState *rules.BoardState, settings r
 }
 
 func (m SoloMazeMap) PostUpdateBoard(lastBoardState *rules.BoardState, settings rules.Settings, editor Editor) error {
-	currentLevel, e := m.ReadBitState(lastBoardState)
+	currentLevel, e := m.ReadLevel(lastBoardState)
 	if e != nil {
 		return e
 	}
 
 	if len(lastBoardState.Food) == 0 {
 		currentLevel += 1
-		m.WriteBitState(lastBoardState, currentLevel, editor)
 
-		// This will create a new maze
+		// This will create a new maze, and store the new level
 		return m.CreateMaze(lastBoardState, settings, editor, currentLevel)
 	}
 
@@ -361,38 +361,16 @@ func (m SoloMazeMap) AdjustPosition(mazePosition rules.Point, actualBoardSize in
 	return rules.Point{X: mazePosition.X + xAdjust, Y: mazePosition.Y + yAdjust}
 }
 
-func (m SoloMazeMap) ReadBitState(boardState *rules.BoardState) (int64, error) {
-	row := 0
-	width := boardState.Width
-
-	stringBits := ""
-
-	for i := 0; i < width; i++ {
-		if containsPoint(boardState.Hazards, rules.Point{X: i, Y: row}) {
-			stringBits += "1"
-		} else {
-			stringBits += "0"
-		}
-	}
-
-	return strconv.ParseInt(stringBits, 2, 64)
+// ReadLevel returns the level the snake is on, which is the number of food it has eaten.
+func (m SoloMazeMap) ReadLevel(boardState *rules.BoardState) (int64, error) {
+	var level int64
+	_, err := rules.GetState(boardState.GameState, m.ID(), "level", &level)
+	return level, err
 }
 
-func (m SoloMazeMap) WriteBitState(boardState *rules.BoardState, state int64, editor Editor) {
-	width := boardState.Width
-
-	stringBits := strconv.FormatInt(state, 2)
-	paddingBits := fmt.Sprintf("%0*s", width, stringBits)
-
-	for i, c := range paddingBits {
-		point := rules.Point{X: i, Y: 0}
-
-		if c == '1' {
-			editor.AddHazard(point)
-		} else {
-			editor.RemoveHazard(point)
-		}
-	}
+// WriteLevel stores the level the snake is on in the game's private state.
+func (m SoloMazeMap) WriteLevel(level int64, editor Editor) error {
+	return rules.SetState(editor.GameState(), m.ID(), "level", level)
 }
 
 // Return value is first the wall that has been cut, the second is the holes we cut out
@@ -0,0 +1,39 @@
+package maps_test
+
+import (
+	"testing"
+
+	"github.com/BattlesnakeOfficial/rules"
+	"github.com/BattlesnakeOfficial/rules/maps"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSoloMazeLevelIsPrivate(t *testing.T) {
+	m := maps.SoloMazeMap{}
+	settings := rules.NewSettings(nil).WithSeed(7)
+	boardState := rules.NewBoardState(11, 11)
+	rules.InitializeSnakes(boardState, []string{"one"})
+	require.NoError(t, m.SetupBoard(boardState, settings, maps.NewBoardStateEditor(boardState)))
+
+	level, err := m.ReadLevel(boardState)
+	require.NoError(t, err)
+	require.Equal(t, int64(0), level)
+	require.Len(t, boardState.Food, 1)
+
+	// Eating the food moves on to the next level
+	boardState.Food = nil
+	boardState, err = maps.PostUpdateBoard(m, boardState, settings)
+	require.NoError(t, err)
+	level, err = m.ReadLevel(boardState)
+	require.NoError(t, err)
+	require.Equal(t, int64(1), level)
+	require.Len(t, boardState.Food, 1)
+
+	// The level isn't written into the hazards, so the whole first row is wall
+	for x := 0; x < boardState.Width; x++ {
+		require.Contains(t, boardState.Hazards, rules.Point{X: x, Y: 0})
+	}
+	for _, p := range boardState.Hazards {
+		require.True(t, p.X >= 0 && p.Y >= 0 && p.X < boardState.Width && p.Y < boardState.Height, "hazard %v is off the board", p)
+	}
+}
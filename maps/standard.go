@@ -28,7 +28,7 @@ func (m StandardMap) Meta() Metadata {
 }
 
 func (m StandardMap) SetupBoard(initialBoardState *rules.BoardState, settings rules.Settings, editor Editor) error {
-	rand := settings.GetRand(0)
+	rand := settings.GetStageRand(0, m.ID())
 
 	if len(initialBoardState.Snakes) > int(m.Meta().MaxPlayers) {
 		return rules.ErrorTooManySnakes
@@ -62,34 +62,20 @@ func (m StandardMap) PreUpdateBoard(lastBoardState *rules.BoardState, settings r
 }
 
 func (m StandardMap) PostUpdateBoard(lastBoardState *rules.BoardState, settings rules.Settings, editor Editor) error {
-	rand := settings.GetRand(lastBoardState.Turn)
-
-	foodNeeded := checkFoodNeedingPlacement(rand, settings, lastBoardState)
-	if foodNeeded > 0 {
-		placeFoodRandomly(rand, lastBoardState, editor, foodNeeded)
-	}
-
-	return nil
+	rand := settings.GetStageRand(lastBoardState.Turn, m.ID())
+	return spawnFood(rand, settings, lastBoardState, editor, rules.GetUnoccupiedPoints(lastBoardState, false, false))
 }
 
-func checkFoodNeedingPlacement(rand rules.Rand, settings rules.Settings, state *rules.BoardState) int {
-	minFood := settings.Int(rules.ParamMinimumFood, 0)
-	foodSpawnChance := settings.Int(rules.ParamFoodSpawnChance, 0)
-	numCurrentFood := len(state.Food)
-
-	if numCurrentFood < minFood {
-		return minFood - numCurrentFood
+// spawnFood adds the food chosen by the game's food spawner, out of the given positions.
+func spawnFood(rand rules.Rand, settings rules.Settings, b *rules.BoardState, editor Editor, positions []rules.Point) error {
+	spawner, err := rules.GetFoodSpawner(settings)
+	if err != nil {
+		return err
 	}
-	if foodSpawnChance > 0 && (100-rand.Intn(100)) < foodSpawnChance {
-		return 1
+	for _, p := range spawner.SpawnFood(rand, b, settings, positions) {
+		editor.AddFood(p)
 	}
-
-	return 0
-}
-
-func placeFoodRandomly(rand rules.Rand, b *rules.BoardState, editor Editor, n int) {
-	unoccupiedPoints := rules.GetUnoccupiedPoints(b, false, false)
-	placeFoodRandomlyAtPositions(rand, b, editor, n, unoccupiedPoints)
+	return nil
 }
 
 func placeFoodRandomlyAtPositions(rand rules.Rand, b *rules.BoardState, editor Editor, n int, positions []rules.Point) {
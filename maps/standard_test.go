@@ -195,6 +195,24 @@ func TestStandardMapUpdateBoard(t *testing.T) {
 	}
 }
 
+func TestStandardMapFoodSpawner(t *testing.T) {
+	m := maps.StandardMap{}
+	initialBoardState := rules.NewBoardState(3, 3).WithSnakes([]rules.Snake{
+		{ID: "1", Body: []rules.Point{{X: 0, Y: 0}}},
+		{ID: "2", Body: []rules.Point{{X: 2, Y: 2}}},
+	})
+
+	settings := rules.NewSettingsWithParams(rules.ParamFoodSpawner, rules.FoodSpawnerFair, rules.ParamMinimumFood, "1").WithRand(rules.MinRand)
+	nextBoardState := initialBoardState.Clone()
+	err := m.PostUpdateBoard(initialBoardState.Clone(), settings, maps.NewBoardStateEditor(nextBoardState))
+	require.NoError(t, err)
+	require.Equal(t, []rules.Point{{X: 0, Y: 2}}, nextBoardState.Food)
+
+	settings = rules.NewSettingsWithParams(rules.ParamFoodSpawner, "nope").WithRand(rules.MinRand)
+	err = m.PostUpdateBoard(initialBoardState.Clone(), settings, maps.NewBoardStateEditor(initialBoardState.Clone()))
+	require.EqualError(t, err, "unknown food spawner: nope")
+}
+
 func generateSnakes(n int) []rules.Snake {
 	var snakes []rules.Snake
 	for i := 0; i < n; i++ {
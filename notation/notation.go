@@ -0,0 +1,236 @@
+// Package notation implements a compact, human-readable text format for recording games.
+//
+// Instead of storing the board on every turn, a game is recorded as the inputs needed to
+// re-simulate it deterministically: a header of tags describing the ruleset, map, seed, board
+// size, settings and snakes, followed by one line per turn listing the move of every snake.
+//
+//	[Game "GAME_ID"]
+//	[Ruleset "standard"]
+//	[Map "standard"]
+//	[Seed "1656460409268690000"]
+//	[Width "11"]
+//	[Height "11"]
+//	[Setting "foodSpawnChance" "15"]
+//	[Setting "minimumFood" "1"]
+//	[Snake "snk_0" "Snake One"]
+//	[Snake "snk_1" "Snake Two"]
+//
+//	0. U L
+//	1. U D
+//	2. R -
+//
+// Tag values are Go-style quoted strings. Snakes are listed in the order they were placed on the
+// board, and each turn lists their moves in the same order: U, D, L and R for up, down, left and
+// right, and - for snakes that are no longer in the game. Blank lines and lines starting with #
+// are ignored.
+package notation
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/BattlesnakeOfficial/rules"
+	"github.com/BattlesnakeOfficial/rules/maps"
+	"github.com/BattlesnakeOfficial/rules/replay"
+)
+
+// Tag names used in the header.
+const (
+	TagGame    = "Game"
+	TagRuleset = "Ruleset"
+	TagMap     = "Map"
+	TagSeed    = "Seed"
+	TagWidth   = "Width"
+	TagHeight  = "Height"
+	TagSetting = "Setting"
+	TagSnake   = "Snake"
+)
+
+// NoMove is written for snakes that have been eliminated.
+const NoMove = "-"
+
+var moveSymbols = map[string]string{
+	rules.MoveUp:    "U",
+	rules.MoveDown:  "D",
+	rules.MoveLeft:  "L",
+	rules.MoveRight: "R",
+}
+
+// Game is a game recorded in notation form.
+type Game struct {
+	ID       string
+	Ruleset  string
+	Map      string
+	Seed     int64
+	Width    int
+	Height   int
+	Settings map[string]string
+	Snakes   []Snake
+
+	// Turns holds the moves made on each turn, in the same order as Snakes.
+	// Snakes that are no longer in the game have an empty move.
+	Turns [][]string
+}
+
+// Snake identifies a snake in a recorded game.
+type Snake struct {
+	ID   string
+	Name string
+}
+
+// AddTurn records the moves made on the next turn. Moves for snakes not in the game are ignored.
+func (g *Game) AddTurn(moves []rules.SnakeMove) error {
+	turn := make([]string, len(g.Snakes))
+	for _, move := range moves {
+		if _, ok := moveSymbols[move.Move]; !ok {
+			return fmt.Errorf("turn %d: invalid move %q for snake %q", len(g.Turns), move.Move, move.ID)
+		}
+		index := g.snakeIndex(move.ID)
+		if index < 0 {
+			return fmt.Errorf("turn %d: unknown snake %q", len(g.Turns), move.ID)
+		}
+		turn[index] = move.Move
+	}
+	g.Turns = append(g.Turns, turn)
+	return nil
+}
+
+func (g *Game) snakeIndex(id string) int {
+	for i, snake := range g.Snakes {
+		if snake.ID == id {
+			return i
+		}
+	}
+	return -1
+}
+
+// NewRuleset builds the ruleset the game is played with.
+func (g *Game) NewRuleset() rules.Ruleset {
+	return rules.NewRulesetBuilder().
+		WithSeed(g.Seed).
+		WithParams(g.Settings).
+		WithSolo(len(g.Snakes) < 2).
+		NamedRuleset(g.Ruleset)
+}
+
+// Replay re-simulates the game, returning the board state at the start of every turn
+// followed by the state after the last recorded turn.
+func (g *Game) Replay() ([]*rules.BoardState, error) {
+	gameMap, err := maps.GetMap(g.Map)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load game map %#v: %w", g.Map, err)
+	}
+	return g.ReplayWithMap(gameMap)
+}
+
+// ReplayWithMap is like Replay, but uses the given map instead of looking the game's map up by
+// its ID. This is needed for games played with maps that aren't registered, like file maps.
+func (g *Game) ReplayWithMap(gameMap maps.GameMap) ([]*rules.BoardState, error) {
+	if gameMap.ID() != g.Map {
+		return nil, fmt.Errorf("game was played on map %#v, not %#v", g.Map, gameMap.ID())
+	}
+	ruleset := g.NewRuleset()
+
+	snakeIDs := make([]string, 0, len(g.Snakes))
+	for _, snake := range g.Snakes {
+		snakeIDs = append(snakeIDs, snake.ID)
+	}
+	gameOver, boardState, err := replay.Setup(ruleset, gameMap, g.Width, g.Height, snakeIDs)
+	if err != nil {
+		return nil, err
+	}
+	if gameOver && len(g.Turns) > 0 {
+		return nil, fmt.Errorf("turn 0: game is already over")
+	}
+
+	states := []*rules.BoardState{boardState}
+	for i, turn := range g.Turns {
+		moves := []rules.SnakeMove{}
+		for _, snake := range boardState.Snakes {
+			if snake.EliminatedCause != rules.NotEliminated {
+				continue
+			}
+			move := turn[g.snakeIndex(snake.ID)]
+			if move == "" {
+				return nil, fmt.Errorf("turn %d: no move for snake %q", i, snake.ID)
+			}
+			moves = append(moves, rules.SnakeMove{ID: snake.ID, Move: move})
+		}
+
+		// Game over is detected before any moves are applied, so no moves should be
+		// recorded once the game is over
+		if gameOver, boardState, err = replay.Step(ruleset, gameMap, boardState, moves); err != nil {
+			return nil, fmt.Errorf("turn %d: %w", i, err)
+		}
+		if gameOver {
+			return nil, fmt.Errorf("turn %d: game is already over", i)
+		}
+		states = append(states, boardState)
+	}
+
+	return states, nil
+}
+
+// Write writes the game in notation form.
+func Write(w io.Writer, g *Game) error {
+	var sb strings.Builder
+
+	writeTag := func(name string, values ...string) {
+		sb.WriteString("[" + name)
+		for _, value := range values {
+			sb.WriteString(" " + strconv.Quote(value))
+		}
+		sb.WriteString("]\n")
+	}
+
+	if g.ID != "" {
+		writeTag(TagGame, g.ID)
+	}
+	writeTag(TagRuleset, g.Ruleset)
+	writeTag(TagMap, g.Map)
+	writeTag(TagSeed, fmt.Sprint(g.Seed))
+	writeTag(TagWidth, fmt.Sprint(g.Width))
+	writeTag(TagHeight, fmt.Sprint(g.Height))
+
+	settingNames := make([]string, 0, len(g.Settings))
+	for name := range g.Settings {
+		settingNames = append(settingNames, name)
+	}
+	sort.Strings(settingNames)
+	for _, name := range settingNames {
+		writeTag(TagSetting, name, g.Settings[name])
+	}
+
+	for _, snake := range g.Snakes {
+		if snake.Name != "" {
+			writeTag(TagSnake, snake.ID, snake.Name)
+		} else {
+			writeTag(TagSnake, snake.ID)
+		}
+	}
+
+	sb.WriteString("\n")
+	for i, turn := range g.Turns {
+		if len(turn) != len(g.Snakes) {
+			return fmt.Errorf("turn %d: expected %d moves, got %d", i, len(g.Snakes), len(turn))
+		}
+		sb.WriteString(fmt.Sprintf("%d.", i))
+		for _, move := range turn {
+			symbol := NoMove
+			if move != "" {
+				var ok bool
+				if symbol, ok = moveSymbols[move]; !ok {
+					return fmt.Errorf("turn %d: invalid move %q", i, move)
+				}
+			}
+			sb.WriteString(" " + symbol)
+		}
+		sb.WriteString("\n")
+	}
+
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
@@ -0,0 +1,153 @@
+package notation
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/BattlesnakeOfficial/rules"
+	"github.com/BattlesnakeOfficial/rules/maps"
+	"github.com/stretchr/testify/require"
+)
+
+const exampleNotation = `[Game "GAME_ID"]
+[Ruleset "standard"]
+[Map "standard"]
+[Seed "1234"]
+[Width "7"]
+[Height "7"]
+[Setting "foodSpawnChance" "0"]
+[Setting "minimumFood" "1"]
+[Snake "one" "Snake \"One\""]
+[Snake "two"]
+
+0. U L
+1. U -
+`
+
+func exampleGame() *Game {
+	return &Game{
+		ID:       "GAME_ID",
+		Ruleset:  rules.GameTypeStandard,
+		Map:      "standard",
+		Seed:     1234,
+		Width:    7,
+		Height:   7,
+		Settings: map[string]string{rules.ParamMinimumFood: "1", rules.ParamFoodSpawnChance: "0"},
+		Snakes:   []Snake{{ID: "one", Name: `Snake "One"`}, {ID: "two"}},
+		Turns: [][]string{
+			{rules.MoveUp, rules.MoveLeft},
+			{rules.MoveUp, ""},
+		},
+	}
+}
+
+func TestWrite(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, Write(&buf, exampleGame()))
+	require.Equal(t, exampleNotation, buf.String())
+}
+
+func TestWriteErrors(t *testing.T) {
+	game := exampleGame()
+	game.Turns = append(game.Turns, []string{rules.MoveUp})
+	require.EqualError(t, Write(&bytes.Buffer{}, game), "turn 2: expected 2 moves, got 1")
+
+	game = exampleGame()
+	game.Turns[1][0] = "north"
+	require.EqualError(t, Write(&bytes.Buffer{}, game), `turn 1: invalid move "north"`)
+}
+
+func TestAddTurn(t *testing.T) {
+	game := &Game{Snakes: []Snake{{ID: "one"}, {ID: "two"}}}
+	require.NoError(t, game.AddTurn([]rules.SnakeMove{{ID: "two", Move: rules.MoveDown}, {ID: "one", Move: rules.MoveRight}}))
+	require.NoError(t, game.AddTurn([]rules.SnakeMove{{ID: "one", Move: rules.MoveLeft}}))
+	require.Equal(t, [][]string{{rules.MoveRight, rules.MoveDown}, {rules.MoveLeft, ""}}, game.Turns)
+
+	require.EqualError(t, game.AddTurn([]rules.SnakeMove{{ID: "three", Move: rules.MoveUp}}), `turn 2: unknown snake "three"`)
+	require.EqualError(t, game.AddTurn([]rules.SnakeMove{{ID: "one", Move: "north"}}), `turn 2: invalid move "north" for snake "one"`)
+}
+
+func TestReplay(t *testing.T) {
+	game := &Game{
+		Ruleset:  rules.GameTypeStandard,
+		Map:      "standard",
+		Seed:     1234,
+		Width:    7,
+		Height:   7,
+		Settings: map[string]string{rules.ParamFoodSpawnChance: "0"},
+		Snakes:   []Snake{{ID: "one"}, {ID: "two"}},
+	}
+
+	states, err := game.Replay()
+	require.NoError(t, err)
+	require.Len(t, states, 1)
+	start := map[string]rules.Point{}
+	for _, snake := range states[0].Snakes {
+		start[snake.ID] = snake.Body[0]
+	}
+
+	// Both snakes move towards the centre of the board
+	towardsCentre := func(p rules.Point) string {
+		if p.X < 3 {
+			return rules.MoveRight
+		}
+		return rules.MoveLeft
+	}
+	game.Turns = [][]string{{towardsCentre(start["one"]), towardsCentre(start["two"])}}
+	states, err = game.Replay()
+	require.NoError(t, err)
+	require.Len(t, states, 2)
+	require.Equal(t, 1, states[1].Turn)
+	for _, snake := range states[1].Snakes {
+		require.Equal(t, 1, abs(snake.Body[0].X-start[snake.ID].X))
+		require.Equal(t, 99, snake.Health)
+	}
+
+	// Replaying is deterministic
+	again, err := game.Replay()
+	require.NoError(t, err)
+	require.Equal(t, states, again)
+
+	game.Turns = [][]string{{rules.MoveUp, ""}}
+	_, err = game.Replay()
+	require.EqualError(t, err, `turn 0: no move for snake "two"`)
+
+	game.Map = "missing"
+	_, err = game.Replay()
+	require.EqualError(t, err, `failed to load game map "missing": map not found`)
+
+	// Maps that aren't registered can be given directly, as long as they match
+	game.Turns = nil
+	_, err = game.ReplayWithMap(maps.StandardMap{})
+	require.EqualError(t, err, `game was played on map "missing", not "standard"`)
+	game.Map = "standard"
+	states, err = game.ReplayWithMap(maps.StandardMap{})
+	require.NoError(t, err)
+	require.Len(t, states, 1)
+}
+
+func TestReplayAfterGameOver(t *testing.T) {
+	game := &Game{
+		Ruleset: rules.GameTypeStandard,
+		Map:     "standard",
+		Seed:    1,
+		Width:   7,
+		Height:  7,
+		Snakes:  []Snake{{ID: "one"}, {ID: "two"}},
+	}
+
+	// Run both snakes into the walls, then keep recording turns
+	for i := 0; i < 10; i++ {
+		game.Turns = append(game.Turns, []string{rules.MoveUp, rules.MoveUp})
+	}
+	_, err := game.Replay()
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "game is already over")
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
@@ -0,0 +1,168 @@
+package notation
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// Parse reads a game written in notation form.
+func Parse(r io.Reader) (*Game, error) {
+	game := &Game{Settings: map[string]string{}}
+	seen := map[string]bool{}
+
+	scanner := bufio.NewScanner(r)
+	lineNumber := 0
+	for scanner.Scan() {
+		lineNumber++
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		var err error
+		if strings.HasPrefix(line, "[") {
+			if len(game.Turns) > 0 {
+				err = fmt.Errorf("tags must come before any turns")
+			} else {
+				err = game.parseTag(line, seen)
+			}
+		} else {
+			err = game.parseTurn(line)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", lineNumber, err)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	for _, required := range []string{TagRuleset, TagMap, TagSeed, TagWidth, TagHeight} {
+		if !seen[required] {
+			return nil, fmt.Errorf("missing %s tag", required)
+		}
+	}
+
+	return game, nil
+}
+
+func (game *Game) parseTag(line string, seen map[string]bool) error {
+	if !strings.HasSuffix(line, "]") {
+		return fmt.Errorf("tag is missing closing bracket")
+	}
+	name, values, err := splitTag(line[1 : len(line)-1])
+	if err != nil {
+		return err
+	}
+
+	switch name {
+	case TagSetting:
+		if len(values) != 2 {
+			return fmt.Errorf("%s tag requires a name and value", name)
+		}
+		game.Settings[values[0]] = values[1]
+		return nil
+	case TagSnake:
+		if len(values) != 1 && len(values) != 2 {
+			return fmt.Errorf("%s tag requires an ID and optional name", name)
+		}
+		if game.snakeIndex(values[0]) >= 0 {
+			return fmt.Errorf("duplicate snake %q", values[0])
+		}
+		snake := Snake{ID: values[0]}
+		if len(values) == 2 {
+			snake.Name = values[1]
+		}
+		game.Snakes = append(game.Snakes, snake)
+		return nil
+	}
+
+	if len(values) != 1 {
+		return fmt.Errorf("%s tag requires a single value", name)
+	}
+	if seen[name] {
+		return fmt.Errorf("duplicate %s tag", name)
+	}
+	seen[name] = true
+
+	value := values[0]
+	switch name {
+	case TagGame:
+		game.ID = value
+	case TagRuleset:
+		game.Ruleset = value
+	case TagMap:
+		game.Map = value
+	case TagSeed:
+		game.Seed, err = strconv.ParseInt(value, 10, 64)
+	case TagWidth:
+		game.Width, err = strconv.Atoi(value)
+	case TagHeight:
+		game.Height, err = strconv.Atoi(value)
+	default:
+		return fmt.Errorf("unknown tag %s", name)
+	}
+	if err != nil {
+		return fmt.Errorf("invalid %s %q", name, value)
+	}
+	return nil
+}
+
+// splitTag splits the contents of a tag into its name and quoted values.
+func splitTag(contents string) (string, []string, error) {
+	name, rest := contents, ""
+	if i := strings.IndexAny(contents, " \t"); i >= 0 {
+		name, rest = contents[:i], contents[i:]
+	}
+	if name == "" {
+		return "", nil, fmt.Errorf("tag is missing a name")
+	}
+
+	values := []string{}
+	for {
+		rest = strings.TrimLeft(rest, " \t")
+		if rest == "" {
+			return name, values, nil
+		}
+		quoted, err := strconv.QuotedPrefix(rest)
+		if err != nil {
+			return "", nil, fmt.Errorf("%s tag has an invalid value: %s", name, rest)
+		}
+		value, _ := strconv.Unquote(quoted)
+		values = append(values, value)
+		rest = rest[len(quoted):]
+	}
+}
+
+func (game *Game) parseTurn(line string) error {
+	fields := strings.Fields(line)
+	expected := fmt.Sprintf("%d.", len(game.Turns))
+	if fields[0] != expected {
+		return fmt.Errorf("expected turn %q, got %q", expected, fields[0])
+	}
+	if len(fields)-1 != len(game.Snakes) {
+		return fmt.Errorf("expected %d moves, got %d", len(game.Snakes), len(fields)-1)
+	}
+
+	turn := make([]string, 0, len(game.Snakes))
+	for _, symbol := range fields[1:] {
+		move, ok := symbolMoves[symbol]
+		if !ok {
+			return fmt.Errorf("invalid move %q", symbol)
+		}
+		turn = append(turn, move)
+	}
+	game.Turns = append(game.Turns, turn)
+	return nil
+}
+
+var symbolMoves = map[string]string{NoMove: ""}
+
+func init() {
+	for move, symbol := range moveSymbols {
+		symbolMoves[symbol] = move
+	}
+}
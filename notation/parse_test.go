@@ -0,0 +1,69 @@
+package notation
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse(t *testing.T) {
+	game, err := Parse(strings.NewReader(exampleNotation))
+	require.NoError(t, err)
+	require.Equal(t, exampleGame(), game)
+
+	// Writing the parsed game produces the same text
+	var buf bytes.Buffer
+	require.NoError(t, Write(&buf, game))
+	require.Equal(t, exampleNotation, buf.String())
+}
+
+func TestParseCommentsAndWhitespace(t *testing.T) {
+	game, err := Parse(strings.NewReader(`
+# a game with no snakes
+  [Ruleset   "solo"]
+[Map "empty"]	
+[Seed "-5"]
+[Width "3"]
+[Height "5"]
+
+`))
+	require.NoError(t, err)
+	require.Equal(t, "solo", game.Ruleset)
+	require.Equal(t, int64(-5), game.Seed)
+	require.Equal(t, 3, game.Width)
+	require.Equal(t, 5, game.Height)
+	require.Empty(t, game.Snakes)
+	require.Empty(t, game.Turns)
+}
+
+func TestParseErrors(t *testing.T) {
+	header := "[Ruleset \"standard\"]\n[Map \"standard\"]\n[Seed \"1\"]\n[Width \"7\"]\n[Height \"7\"]\n[Snake \"one\"]\n"
+	for _, test := range []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{"missing tag", `[Ruleset "standard"]`, "missing Map tag"},
+		{"unclosed tag", `[Ruleset "standard"`, "line 1: tag is missing closing bracket"},
+		{"unnamed tag", `[ "standard"]`, "line 1: tag is missing a name"},
+		{"unquoted value", `[Ruleset standard]`, "line 1: Ruleset tag has an invalid value: standard"},
+		{"unknown tag", `[Colour "red"]`, "line 1: unknown tag Colour"},
+		{"duplicate tag", "[Map \"a\"]\n[Map \"b\"]", "line 2: duplicate Map tag"},
+		{"too many values", `[Map "a" "b"]`, "line 1: Map tag requires a single value"},
+		{"invalid seed", `[Seed "abc"]`, `line 1: invalid Seed "abc"`},
+		{"invalid setting", `[Setting "a"]`, "line 1: Setting tag requires a name and value"},
+		{"invalid snake", `[Snake]`, "line 1: Snake tag requires an ID and optional name"},
+		{"duplicate snake", "[Snake \"a\"]\n[Snake \"a\"]", `line 2: duplicate snake "a"`},
+		{"wrong turn", header + "1. U", `line 7: expected turn "0.", got "1."`},
+		{"too few moves", header + "0.", "line 7: expected 1 moves, got 0"},
+		{"invalid move", header + "0. N", `line 7: invalid move "N"`},
+		{"tag after turns", header + "0. U\n[Snake \"two\"]", "line 8: tags must come before any turns"},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := Parse(strings.NewReader(test.input))
+			require.EqualError(t, err, test.expected)
+		})
+	}
+}
@@ -12,6 +12,7 @@ const (
 	StageEliminationStandard  = "elimination.standard"
 
 	StageGameOverSoloSnake           = "game_over.solo_snake"
+	StageGameOverTurnLimit           = "game_over.turn_limit"
 	StageSpawnFoodNoFood             = "spawn_food.no_food"
 	StageSpawnHazardsShrinkMap       = "spawn_hazards.shrink_map"
 	StageModifySnakesAlwaysGrow      = "modify_snakes.always_grow"
@@ -28,6 +29,7 @@ var globalRegistry = StageRegistry{
 	StageSpawnFoodStandard:      SpawnFoodStandard,
 	StageGameOverSoloSnake:      GameOverSolo,
 	StageGameOverStandard:       GameOverStandard,
+	StageGameOverTurnLimit:      GameOverTurnLimit,
 	StageHazardDamageStandard:   DamageHazardsStandard,
 	StageSpawnHazardsShrinkMap:  PopulateHazardsRoyale,
 	StageStarvationStandard:     ReduceSnakeHealthStandard,
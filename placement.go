@@ -0,0 +1,371 @@
+package rules
+
+import "fmt"
+
+// The number of different placements PlaceSnakesFairly tries before keeping the fairest, and the
+// most times it then tries nudging every snake to improve on it.
+const (
+	fairPlacementAttempts = 20
+	fairPlacementNudges   = 10
+)
+
+// Moves to the nearby even squares that PlaceSnakesFairly nudges snakes to.
+var fairPlacementOffsets = []Point{
+	{X: -1, Y: -1}, {X: -1, Y: 1}, {X: 1, Y: -1}, {X: 1, Y: 1},
+	{X: -2, Y: 0}, {X: 2, Y: 0}, {X: 0, Y: -2}, {X: 0, Y: 2},
+}
+
+// PlacementFairness measures how fair the starting positions of the snakes on a board are.
+type PlacementFairness struct {
+	// The fewest moves between the heads of any two snakes, ignoring walls.
+	MinSpacing int
+	// The fewest and most cells that a single snake can reach before any other snake.
+	MinArea int
+	MaxArea int
+	// The nearest and furthest that any snake is from its closest food,
+	// or -1 when there's no food any snake can reach.
+	MinFoodDistance int
+	MaxFoodDistance int
+}
+
+// Score combines the fairness measurements into a single number between 0 and 1, where 1 means
+// every snake can claim the same area and is the same distance from food.
+func (f PlacementFairness) Score() float64 {
+	score := 1.0
+	if f.MaxArea > 0 {
+		score *= float64(f.MinArea) / float64(f.MaxArea)
+	}
+	if f.MaxFoodDistance >= 0 {
+		score *= float64(f.MinFoodDistance+1) / float64(f.MaxFoodDistance+1)
+	}
+	return score
+}
+
+// fairerThan returns true if f is a fairer placement than other, preferring more spacing between
+// snakes when they're equally fair.
+func (f PlacementFairness) fairerThan(other PlacementFairness) bool {
+	if f.Score() != other.Score() {
+		return f.Score() > other.Score()
+	}
+	return f.MinSpacing > other.MinSpacing
+}
+
+func (f PlacementFairness) String() string {
+	if f.MaxFoodDistance < 0 {
+		return fmt.Sprintf("%.2f (spacing %d, area %d-%d)", f.Score(), f.MinSpacing, f.MinArea, f.MaxArea)
+	}
+	return fmt.Sprintf("%.2f (spacing %d, area %d-%d, food distance %d-%d)",
+		f.Score(), f.MinSpacing, f.MinArea, f.MaxArea, f.MinFoodDistance, f.MaxFoodDistance)
+}
+
+// MeasurePlacementFairness measures how fair the positions of the snakes still in the game are.
+// Snakes can't move through walls or other snakes' bodies, but can move through hazards.
+func MeasurePlacementFairness(b *BoardState) PlacementFairness {
+	snakes, distances := snakeDistances(b)
+
+	fairness := PlacementFairness{MinSpacing: -1, MinArea: -1, MinFoodDistance: -1, MaxFoodDistance: -1}
+	for i, snake := range snakes {
+		head := snake.Body[0]
+		for _, other := range snakes[:i] {
+			d := getDistanceBetweenPoints(head, other.Body[0])
+			if fairness.MinSpacing < 0 || d < fairness.MinSpacing {
+				fairness.MinSpacing = d
+			}
+		}
+
+		nearest := -1
+		for _, food := range b.Food {
+			if food.X < 0 || food.X >= b.Width || food.Y < 0 || food.Y >= b.Height {
+				continue
+			}
+			d := distances[i][food.Y*b.Width+food.X]
+			if d >= 0 && (nearest < 0 || d < nearest) {
+				nearest = d
+			}
+		}
+		if nearest >= 0 {
+			if fairness.MinFoodDistance < 0 || nearest < fairness.MinFoodDistance {
+				fairness.MinFoodDistance = nearest
+			}
+			if nearest > fairness.MaxFoodDistance {
+				fairness.MaxFoodDistance = nearest
+			}
+		}
+	}
+	if fairness.MinSpacing < 0 {
+		fairness.MinSpacing = 0
+	}
+
+	areas := make([]int, len(snakes))
+	for cell := 0; cell < b.Width*b.Height; cell++ {
+		closest, tied := -1, false
+		for i := range snakes {
+			d := distances[i][cell]
+			if d < 0 {
+				continue
+			}
+			if closest < 0 || d < distances[closest][cell] {
+				closest, tied = i, false
+			} else if d == distances[closest][cell] {
+				tied = true
+			}
+		}
+		if closest >= 0 && !tied {
+			areas[closest]++
+		}
+	}
+	for _, area := range areas {
+		if fairness.MinArea < 0 || area < fairness.MinArea {
+			fairness.MinArea = area
+		}
+		if area > fairness.MaxArea {
+			fairness.MaxArea = area
+		}
+	}
+	if fairness.MinArea < 0 {
+		fairness.MinArea = 0
+	}
+
+	return fairness
+}
+
+// snakeDistances finds the snakes still in the game and the number of moves from each of their
+// heads to every cell on the board, as used by MeasurePlacementFairness.
+func snakeDistances(b *BoardState) ([]Snake, [][]int) {
+	var snakes []Snake
+	for _, snake := range b.Snakes {
+		if snake.EliminatedCause == NotEliminated && len(snake.Body) > 0 {
+			snakes = append(snakes, snake)
+		}
+	}
+
+	blocked := make([]bool, b.Width*b.Height)
+	block := func(p Point) {
+		if p.X >= 0 && p.X < b.Width && p.Y >= 0 && p.Y < b.Height {
+			blocked[p.Y*b.Width+p.X] = true
+		}
+	}
+	for _, p := range b.Walls {
+		block(p)
+	}
+	for _, snake := range snakes {
+		for _, p := range snake.Body {
+			block(p)
+		}
+	}
+
+	distances := make([][]int, len(snakes))
+	for i, snake := range snakes {
+		distances[i] = boardDistances(b, snake.Body[0], blocked)
+	}
+	return snakes, distances
+}
+
+// PlaceFoodFairly adds up to n new food to the board in unoccupied squares, for boards where snakes
+// were placed by PlaceSnakesFairly. When there's enough food, each snake gets one food the same
+// number of moves away, as close as possible and no closer to any other snake, so every snake's
+// nearest food is the same distance away. Any other food goes in the squares that are closest to
+// being the same distance from every snake, like FairFoodSpawner.
+func PlaceFoodFairly(rand Rand, b *BoardState, n int) error {
+	unoccupied := GetUnoccupiedPoints(b, false, false)
+	snakes, distances := snakeDistances(b)
+	if len(snakes) > 0 && n >= len(snakes) {
+		if food := pickFoodPerSnake(rand, b, unoccupied, distances); food != nil {
+			b.Food = append(b.Food, food...)
+			n -= len(food)
+			unoccupied = GetUnoccupiedPoints(b, false, false)
+		}
+	}
+
+	b.Food = append(b.Food, pickFoodFairly(rand, b, n, unoccupied)...)
+	return nil
+}
+
+// pickFoodPerSnake picks one of the positions for each snake, all at the smallest distance of at
+// least two moves that leaves every snake's nearest food the same distance away. It returns nil if
+// there's no such distance.
+func pickFoodPerSnake(rand Rand, b *BoardState, positions []Point, distances [][]int) []Point {
+	for d := 2; d < b.Width+b.Height; d++ {
+		food := make([]Point, 0, len(distances))
+		for i := range distances {
+			var candidates []Point
+			for _, p := range positions {
+				if distances[i][p.Y*b.Width+p.X] == d && !containsPoint(food, p) && !closerThan(distances, p.Y*b.Width+p.X, d) {
+					candidates = append(candidates, p)
+				}
+			}
+			if len(candidates) == 0 {
+				break
+			}
+			food = append(food, candidates[rand.Intn(len(candidates))])
+		}
+		if len(food) == len(distances) {
+			return food
+		}
+	}
+	return nil
+}
+
+// closerThan reports whether any snake can reach the cell in fewer than d moves.
+func closerThan(distances [][]int, cell int, d int) bool {
+	for _, snakeDistances := range distances {
+		if snakeDistances[cell] >= 0 && snakeDistances[cell] < d {
+			return true
+		}
+	}
+	return false
+}
+
+// PlaceSnakesFairly places snakes on any size of board, on even squares away from the center,
+// like PlaceSnakesRandomly. It tries several placements that each spread the snakes as far apart
+// as possible, keeps the one where the snakes' reachable areas are most even, as measured by
+// MeasurePlacementFairness, and then nudges snakes to nearby squares while that makes it fairer.
+// Hazards are avoided when there's room. Starting food is usually placed afterwards, so it's up to
+// PlaceFoodFairly to keep distances to food even.
+func PlaceSnakesFairly(rand Rand, b *BoardState, snakeIDs []string) error {
+	b.Snakes = make([]Snake, len(snakeIDs))
+	for i := 0; i < len(snakeIDs); i++ {
+		b.Snakes[i] = Snake{
+			ID:     snakeIDs[i],
+			Health: SnakeMaxHealth,
+		}
+	}
+	if len(snakeIDs) == 0 {
+		return nil
+	}
+
+	candidates := removeCenterCoord(b, GetEvenUnoccupiedPoints(b))
+	if len(candidates) < len(snakeIDs) {
+		return ErrorNoRoomForSnake
+	}
+	hazards := make(map[Point]bool, len(b.Hazards))
+	for _, p := range b.Hazards {
+		hazards[Point{X: p.X, Y: p.Y}] = true
+	}
+	var safe []Point
+	for _, p := range candidates {
+		if !hazards[p] {
+			safe = append(safe, p)
+		}
+	}
+	if len(safe) >= len(snakeIDs) {
+		candidates = safe
+	}
+
+	var best []Point
+	var bestFairness PlacementFairness
+	for attempt := 0; attempt < fairPlacementAttempts; attempt++ {
+		heads := spreadOutPoints(rand, candidates, len(snakeIDs))
+		fairness := placeHeads(b, heads)
+		if best == nil || fairness.fairerThan(bestFairness) {
+			best, bestFairness = heads, fairness
+		}
+	}
+
+	allowed := make(map[Point]bool, len(candidates))
+	for _, p := range candidates {
+		allowed[p] = true
+	}
+	for nudge, improved := 0, true; nudge < fairPlacementNudges && improved; nudge++ {
+		improved = false
+		for i := range best {
+			for _, offset := range fairPlacementOffsets {
+				p := Point{X: best[i].X + offset.X, Y: best[i].Y + offset.Y}
+				if !allowed[p] || containsPoint(best, p) {
+					continue
+				}
+				heads := append([]Point{}, best...)
+				heads[i] = p
+				if fairness := placeHeads(b, heads); fairness.fairerThan(bestFairness) {
+					best, bestFairness, improved = heads, fairness, true
+				}
+			}
+		}
+	}
+
+	placeHeads(b, best)
+	return nil
+}
+
+// placeHeads places each snake at its head, and measures how fair that is.
+func placeHeads(b *BoardState, heads []Point) PlacementFairness {
+	for i := range b.Snakes {
+		b.Snakes[i].Body = make([]Point, 0, SnakeStartSize)
+		for j := 0; j < SnakeStartSize; j++ {
+			b.Snakes[i].Body = append(b.Snakes[i].Body, heads[i])
+		}
+	}
+	return MeasurePlacementFairness(b)
+}
+
+func containsPoint(points []Point, p Point) bool {
+	for _, q := range points {
+		if q.X == p.X && q.Y == p.Y {
+			return true
+		}
+	}
+	return false
+}
+
+// spreadOutPoints picks n of the candidates, starting from a random one and then repeatedly
+// taking the candidate furthest from all the points already picked. Ties are broken randomly.
+func spreadOutPoints(rand Rand, candidates []Point, n int) []Point {
+	picked := []Point{candidates[rand.Intn(len(candidates))]}
+	for len(picked) < n {
+		var furthest []Point
+		furthestDistance := -1
+		for _, c := range candidates {
+			nearest := -1
+			for _, p := range picked {
+				d := getDistanceBetweenPoints(c, p)
+				if nearest < 0 || d < nearest {
+					nearest = d
+				}
+			}
+			if nearest == 0 {
+				continue
+			}
+			if nearest > furthestDistance {
+				furthest, furthestDistance = []Point{c}, nearest
+			} else if nearest == furthestDistance {
+				furthest = append(furthest, c)
+			}
+		}
+		picked = append(picked, furthest[rand.Intn(len(furthest))])
+	}
+	return picked
+}
+
+// boardDistances finds the number of moves from a point to every cell on the board, indexed by
+// y*width+x, without wrapping around the edges. Cells that can't be reached are -1, and blocked
+// cells, indexed the same way, can't be moved through.
+func boardDistances(b *BoardState, from Point, blocked []bool) []int {
+	distances := make([]int, b.Width*b.Height)
+	for i := range distances {
+		distances[i] = -1
+	}
+	if from.X < 0 || from.X >= b.Width || from.Y < 0 || from.Y >= b.Height {
+		return distances
+	}
+
+	start := from.Y*b.Width + from.X
+	distances[start] = 0
+	queue := make([]int, 0, len(distances))
+	queue = append(queue, start)
+	for i := 0; i < len(queue); i++ {
+		cell := queue[i]
+		x, y := cell%b.Width, cell/b.Width
+		for _, next := range [4][2]int{{x - 1, y}, {x + 1, y}, {x, y - 1}, {x, y + 1}} {
+			if next[0] < 0 || next[0] >= b.Width || next[1] < 0 || next[1] >= b.Height {
+				continue
+			}
+			n := next[1]*b.Width + next[0]
+			if blocked[n] || distances[n] >= 0 {
+				continue
+			}
+			distances[n] = distances[cell] + 1
+			queue = append(queue, n)
+		}
+	}
+	return distances
+}
@@ -0,0 +1,121 @@
+package rules
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMeasurePlacementFairness(t *testing.T) {
+	b := NewBoardState(7, 7).
+		WithSnakes([]Snake{
+			{ID: "one", Body: []Point{{X: 1, Y: 3}, {X: 1, Y: 3}, {X: 1, Y: 3}}},
+			{ID: "two", Body: []Point{{X: 5, Y: 3}, {X: 5, Y: 3}, {X: 5, Y: 3}}},
+			{ID: "gone", Body: []Point{{X: 3, Y: 5}}, EliminatedCause: EliminatedByCollision},
+		}).
+		WithFood([]Point{{X: 3, Y: 3}})
+
+	fairness := MeasurePlacementFairness(b)
+	require.Equal(t, PlacementFairness{MinSpacing: 4, MinArea: 21, MaxArea: 21, MinFoodDistance: 2, MaxFoodDistance: 2}, fairness)
+	require.Equal(t, 1.0, fairness.Score())
+	require.Equal(t, "1.00 (spacing 4, area 21-21, food distance 2-2)", fairness.String())
+
+	// Moving food next to one snake, and walling the other snake into a corner, is less fair
+	b.Food = []Point{{X: 1, Y: 4}}
+	b.Walls = []Point{{X: 4, Y: 2}, {X: 4, Y: 3}, {X: 4, Y: 4}}
+	fairness = MeasurePlacementFairness(b)
+	require.Equal(t, 1, fairness.MinFoodDistance)
+	require.Equal(t, 7, fairness.MaxFoodDistance)
+	require.Less(t, fairness.MinArea, fairness.MaxArea)
+	require.Less(t, fairness.Score(), 0.5)
+
+	fairness = MeasurePlacementFairness(NewBoardState(7, 7))
+	require.Equal(t, PlacementFairness{MinFoodDistance: -1, MaxFoodDistance: -1}, fairness)
+	require.Equal(t, "1.00 (spacing 0, area 0-0)", fairness.String())
+}
+
+func TestPlaceSnakesFairly(t *testing.T) {
+	tests := []struct {
+		width, height, snakes int
+		minScore              float64
+		minSpacing            int
+	}{
+		{7, 15, 4, 0.8, 6},
+		{19, 7, 6, 0.8, 4},
+		{11, 11, 12, 0.5, 2},
+		{25, 25, 16, 0.3, 6},
+	}
+	for _, test := range tests {
+		for seed := int64(0); seed < 5; seed++ {
+			b := NewBoardState(test.width, test.height)
+			err := PlaceSnakesFairly(NewSeedRand(seed), b, make([]string, test.snakes))
+			require.NoError(t, err)
+			require.Len(t, b.Snakes, test.snakes)
+			for _, snake := range b.Snakes {
+				require.Len(t, snake.Body, SnakeStartSize)
+				require.Equal(t, 0, (snake.Body[0].X+snake.Body[0].Y)%2, "snakes start on even squares")
+			}
+
+			fairness := MeasurePlacementFairness(b)
+			require.GreaterOrEqual(t, fairness.Score(), test.minScore, "%dx%d with %d snakes: %v", test.width, test.height, test.snakes, fairness)
+			require.GreaterOrEqual(t, fairness.MinSpacing, test.minSpacing, "%dx%d with %d snakes: %v", test.width, test.height, test.snakes, fairness)
+		}
+	}
+}
+
+func TestPlaceSnakesFairlyAvoidsHazards(t *testing.T) {
+	var hazards []Point
+	for x := 0; x < 9; x++ {
+		for y := 0; y < 3; y++ {
+			hazards = append(hazards, Point{X: x, Y: y})
+		}
+	}
+	b := NewBoardState(9, 5).WithHazards(hazards)
+	require.NoError(t, PlaceSnakesFairly(MinRand, b, []string{"one", "two", "three"}))
+	for _, snake := range b.Snakes {
+		require.GreaterOrEqual(t, snake.Body[0].Y, 3)
+	}
+
+	// Snakes are placed in hazards when there's no room anywhere else
+	require.NoError(t, PlaceSnakesFairly(MinRand, b, make([]string, 8)))
+
+	require.Equal(t, ErrorNoRoomForSnake, PlaceSnakesFairly(MinRand, NewBoardState(3, 3), make([]string, 5)))
+}
+
+func TestPlaceSnakesAutomaticallyRectangular(t *testing.T) {
+	for seed := int64(0); seed < 5; seed++ {
+		b := NewBoardState(17, 9)
+		require.NoError(t, PlaceSnakesAutomatically(NewSeedRand(seed), b, make([]string, 4)))
+		require.GreaterOrEqual(t, MeasurePlacementFairness(b).Score(), 0.8)
+	}
+}
+
+func TestCreateDefaultBoardStateRectangularFood(t *testing.T) {
+	for _, size := range []struct{ width, height, snakes int }{{17, 9, 4}, {11, 7, 2}, {9, 15, 3}} {
+		for seed := int64(0); seed < 5; seed++ {
+			b, err := CreateDefaultBoardState(NewSeedRand(seed), size.width, size.height, []string{"1", "2", "3", "4"}[:size.snakes])
+			require.NoError(t, err)
+			require.Len(t, b.Food, size.snakes)
+			fairness := MeasurePlacementFairness(b)
+			require.Equal(t, 2, fairness.MinFoodDistance)
+			require.Equal(t, 2, fairness.MaxFoodDistance)
+		}
+	}
+}
+
+func TestPlaceFoodFairly(t *testing.T) {
+	b := NewBoardState(11, 7)
+	require.NoError(t, PlaceSnakesFairly(NewSeedRand(1), b, make([]string, 2)))
+	require.NoError(t, PlaceFoodFairly(NewSeedRand(1), b, 5))
+	require.Len(t, b.Food, 5)
+	fairness := MeasurePlacementFairness(b)
+	require.Equal(t, fairness.MinFoodDistance, fairness.MaxFoodDistance)
+
+	// not enough food for every snake, so it's spread evenly between them
+	b = NewBoardState(11, 7)
+	require.NoError(t, PlaceSnakesFairly(NewSeedRand(1), b, make([]string, 2)))
+	require.NoError(t, PlaceFoodFairly(NewSeedRand(1), b, 1))
+	require.Len(t, b.Food, 1)
+	fairness = MeasurePlacementFairness(b)
+	require.Equal(t, fairness.MinFoodDistance, fairness.MaxFoodDistance)
+}
@@ -1,6 +1,12 @@
 package rules
 
-import "math/rand"
+import (
+	"fmt"
+	"hash/fnv"
+	"math/bits"
+	"math/rand"
+	"strconv"
+)
 
 type Rand interface {
 	Intn(n int) int
@@ -52,6 +58,98 @@ func (s seedRand) Shuffle(n int, swap func(i, j int)) {
 	s.rand.Shuffle(n, swap)
 }
 
+// StreamRand is a small, fast and fully deterministic Rand implementation based on SplitMix64.
+// Independent streams are derived from a seed, turn and stream name with NewStreamRand, so
+// every stage or map can draw random numbers without affecting the values seen by any other.
+// The entire state of the generator is a single 64-bit value which can be saved with State or
+// MarshalText and restored with SetState or UnmarshalText.
+type StreamRand struct {
+	state uint64
+}
+
+// golden gamma used to advance the SplitMix64 state
+const streamRandIncrement = 0x9e3779b97f4a7c15
+
+// NewStreamRand returns a generator for the named stream at the given seed and turn.
+// The inputs are mixed together so that neighbouring seeds or turns produce unrelated values.
+func NewStreamRand(seed int64, turn int, stream string) *StreamRand {
+	hash := fnv.New64a()
+	hash.Write([]byte(stream))
+
+	state := mix64(uint64(seed) + streamRandIncrement)
+	state = mix64(state ^ uint64(turn) + streamRandIncrement)
+	state = mix64(state ^ hash.Sum64() + streamRandIncrement)
+	return &StreamRand{state: state}
+}
+
+// State returns the current internal state of the generator.
+func (r *StreamRand) State() uint64 {
+	return r.state
+}
+
+// SetState restores a state previously returned by State.
+func (r *StreamRand) SetState(state uint64) {
+	r.state = state
+}
+
+// MarshalText encodes the generator state as a hexadecimal string.
+func (r *StreamRand) MarshalText() ([]byte, error) {
+	return []byte(strconv.FormatUint(r.state, 16)), nil
+}
+
+// UnmarshalText restores a generator state encoded by MarshalText.
+func (r *StreamRand) UnmarshalText(text []byte) error {
+	state, err := strconv.ParseUint(string(text), 16, 64)
+	if err != nil {
+		return fmt.Errorf("invalid rand state %q", text)
+	}
+	r.state = state
+	return nil
+}
+
+// Uint64 returns the next pseudo-random 64-bit value in the stream.
+func (r *StreamRand) Uint64() uint64 {
+	r.state += streamRandIncrement
+	return mix64(r.state)
+}
+
+// Intn returns a uniformly distributed value in [0,n). Panics if n <= 0.
+func (r *StreamRand) Intn(n int) int {
+	if n <= 0 {
+		panic("invalid argument to Intn")
+	}
+	// Lemire's multiply-and-reject method avoids the bias of a plain modulo
+	bound := uint64(n)
+	hi, lo := bits.Mul64(r.Uint64(), bound)
+	if lo < bound {
+		threshold := -bound % bound
+		for lo < threshold {
+			hi, lo = bits.Mul64(r.Uint64(), bound)
+		}
+	}
+	return int(hi)
+}
+
+func (r *StreamRand) Range(min, max int) int {
+	return r.Intn(max-min+1) + min
+}
+
+func (r *StreamRand) Shuffle(n int, swap func(i, j int)) {
+	if n < 0 {
+		panic("invalid argument to Shuffle")
+	}
+	for i := n - 1; i > 0; i-- {
+		swap(i, r.Intn(i+1))
+	}
+}
+
+// mix64 is the SplitMix64 output function.
+func mix64(z uint64) uint64 {
+	z = (z ^ (z >> 30)) * 0xbf58476d1ce4e5b9
+	z = (z ^ (z >> 27)) * 0x94d049bb133111eb
+	return z ^ (z >> 31)
+}
+
 // For testing purposes
 
 // A Rand implementation that always returns the minimum value for any method.
@@ -0,0 +1,93 @@
+package rules_test
+
+import (
+	"testing"
+
+	"github.com/BattlesnakeOfficial/rules"
+	"github.com/stretchr/testify/require"
+)
+
+func drawN(r rules.Rand, n int) []int {
+	values := make([]int, n)
+	for i := range values {
+		values[i] = r.Intn(1000)
+	}
+	return values
+}
+
+func TestStreamRandDeterministic(t *testing.T) {
+	require.Equal(t, drawN(rules.NewStreamRand(1, 2, "a"), 10), drawN(rules.NewStreamRand(1, 2, "a"), 10))
+
+	// Changing any input should produce an unrelated stream
+	base := drawN(rules.NewStreamRand(1, 2, "a"), 10)
+	require.NotEqual(t, base, drawN(rules.NewStreamRand(2, 2, "a"), 10))
+	require.NotEqual(t, base, drawN(rules.NewStreamRand(1, 3, "a"), 10))
+	require.NotEqual(t, base, drawN(rules.NewStreamRand(1, 2, "b"), 10))
+
+	// seed + turn is no longer equivalent to (seed + 1) + (turn - 1)
+	require.NotEqual(t, drawN(rules.NewStreamRand(10, 5, ""), 10), drawN(rules.NewStreamRand(11, 4, ""), 10))
+}
+
+func TestStreamRandState(t *testing.T) {
+	r := rules.NewStreamRand(12345, 0, "state")
+	r.Intn(10)
+
+	state := r.State()
+	text, err := r.MarshalText()
+	require.NoError(t, err)
+	expected := drawN(r, 10)
+
+	restored := &rules.StreamRand{}
+	restored.SetState(state)
+	require.Equal(t, expected, drawN(restored, 10))
+
+	unmarshalled := &rules.StreamRand{}
+	require.NoError(t, unmarshalled.UnmarshalText(text))
+	require.Equal(t, expected, drawN(unmarshalled, 10))
+
+	require.EqualError(t, unmarshalled.UnmarshalText([]byte("xyz")), `invalid rand state "xyz"`)
+}
+
+func TestStreamRandValues(t *testing.T) {
+	r := rules.NewStreamRand(99, 1, "values")
+	seen := map[int]bool{}
+	for i := 0; i < 1000; i++ {
+		v := r.Intn(6)
+		require.True(t, v >= 0 && v < 6)
+		seen[v] = true
+
+		v = r.Range(-2, 2)
+		require.True(t, v >= -2 && v <= 2)
+	}
+	require.Len(t, seen, 6)
+	require.Equal(t, 0, r.Intn(1))
+	require.Panics(t, func() { r.Intn(0) })
+
+	values := []int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9}
+	r.Shuffle(len(values), func(i, j int) { values[i], values[j] = values[j], values[i] })
+	require.ElementsMatch(t, []int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9}, values)
+	require.NotEqual(t, []int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9}, values)
+}
+
+func TestSettingsGetStageRand(t *testing.T) {
+	settings := rules.NewSettings(nil).WithSeed(42)
+
+	// Each stage gets its own stream, independent of what other stages have drawn
+	food := drawN(settings.GetStageRand(3, rules.StageSpawnFoodStandard), 5)
+	drawN(settings.GetStageRand(3, rules.StageSpawnHazardsShrinkMap), 100)
+	require.Equal(t, food, drawN(settings.GetStageRand(3, rules.StageSpawnFoodStandard), 5))
+	require.NotEqual(t, food, drawN(settings.GetStageRand(3, rules.StageSpawnHazardsShrinkMap), 5))
+
+	require.Equal(t, drawN(settings.GetStageRand(3, ""), 5), drawN(settings.GetRand(3), 5))
+
+	// Games without a seed get a random one, so they can still be reproduced from it
+	unseeded := rules.NewSettings(nil)
+	require.NotZero(t, unseeded.Seed())
+	require.NotEqual(t, unseeded.Seed(), rules.NewSettings(nil).Seed())
+	require.Equal(t, drawN(rules.NewStreamRand(unseeded.Seed(), 3, rules.StageSpawnFoodStandard), 5), drawN(unseeded.GetStageRand(3, rules.StageSpawnFoodStandard), 5))
+	require.NotZero(t, rules.NewSettings(nil).WithSeed(0).Seed())
+
+	// An explicit generator overrides every stream
+	overridden := settings.WithRand(rules.MaxRand)
+	require.Equal(t, 9, overridden.GetStageRand(3, rules.StageSpawnFoodStandard).Intn(10))
+}
@@ -0,0 +1,361 @@
+// Package replay re-simulates recorded games through a Ruleset and GameMap.
+//
+// A game is fully determined by its ruleset, settings (including the seed), map, board size,
+// the order snakes were placed in and the moves made on every turn. Given those inputs, Verify
+// recomputes every turn of a recorded game and reports the first turn where the recomputed
+// board state differs from the recorded one.
+package replay
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/BattlesnakeOfficial/rules"
+	"github.com/BattlesnakeOfficial/rules/maps"
+)
+
+// Setup creates the initial board state for a game the same way the CLI does: the snakes are
+// placed by the map in the order given, and then the ruleset is run without any moves.
+func Setup(ruleset rules.Ruleset, gameMap maps.GameMap, width, height int, snakeIDs []string) (bool, *rules.BoardState, error) {
+	boardState := rules.NewBoardState(width, height)
+	rules.InitializeSnakes(boardState, snakeIDs)
+
+	if err := gameMap.SetupBoard(boardState, ruleset.Settings(), maps.NewBoardStateEditor(boardState)); err != nil {
+		return false, nil, fmt.Errorf("error initializing board with map: %w", err)
+	}
+
+	gameOver, boardState, err := ruleset.Execute(boardState, nil)
+	if err != nil {
+		return false, nil, fmt.Errorf("error initializing board with ruleset: %w", err)
+	}
+	return gameOver, boardState, nil
+}
+
+// Step advances the game by one turn: the map's PreUpdateBoard hook, the ruleset and then
+// the map's PostUpdateBoard hook are applied before the turn counter is incremented.
+// The board state passed in is not modified.
+func Step(ruleset rules.Ruleset, gameMap maps.GameMap, boardState *rules.BoardState, moves []rules.SnakeMove) (bool, *rules.BoardState, error) {
+	return StepWith(ruleset, gameMap, boardState, func(*rules.BoardState) ([]rules.SnakeMove, error) {
+		return moves, nil
+	})
+}
+
+// MoveSource returns the moves made on a turn, given the board the snakes see when choosing them.
+type MoveSource func(boardState *rules.BoardState) ([]rules.SnakeMove, error)
+
+// StepWith advances the game by one turn like Step, but asks for the moves once the map's
+// PreUpdateBoard hook has run, which is the board that the CLI sends to snakes.
+func StepWith(ruleset rules.Ruleset, gameMap maps.GameMap, boardState *rules.BoardState, getMoves MoveSource) (bool, *rules.BoardState, error) {
+	boardState, err := maps.PreUpdateBoard(gameMap, boardState, ruleset.Settings())
+	if err != nil {
+		return false, nil, fmt.Errorf("error pre-updating board with map: %w", err)
+	}
+
+	moves, err := getMoves(boardState)
+	if err != nil {
+		return false, nil, err
+	}
+
+	gameOver, boardState, err := ruleset.Execute(boardState, moves)
+	if err != nil {
+		return false, nil, fmt.Errorf("error updating board with ruleset: %w", err)
+	}
+
+	boardState, err = maps.PostUpdateBoard(gameMap, boardState, ruleset.Settings())
+	if err != nil {
+		return false, nil, fmt.Errorf("error post-updating board with map: %w", err)
+	}
+
+	boardState.Turn += 1
+	return gameOver, boardState, nil
+}
+
+// Divergence describes the first turn where a re-simulated game doesn't match the recording.
+type Divergence struct {
+	Turn   int
+	Reason string
+}
+
+func (d *Divergence) Error() string {
+	return fmt.Sprintf("turn %d: %s", d.Turn, d.Reason)
+}
+
+// Verify re-simulates a recorded game and checks that every recorded board state is reproduced.
+// See Reconstruct for how the recording and moves are interpreted.
+func Verify(ruleset rules.Ruleset, gameMap maps.GameMap, recorded []*rules.BoardState, moves []map[string]string) error {
+	_, err := Reconstruct(ruleset, gameMap, recorded, moves)
+	return err
+}
+
+// Reconstruct re-simulates a recorded game and returns the complete list of moves made on every
+// turn, which can be passed to Step to reproduce the game.
+//
+// The snakes are placed in the order they appear in the first recorded state, and moves[i] holds
+// the moves made on the turn recorded in recorded[i], keyed by snake ID. Game recordings usually
+// omit eliminated snakes, so the moves of snakes that were eliminated on a turn often can't be
+// recovered. Any snake without a move is tried with every possible move and the first
+// combination that reproduces the next recorded state is used.
+//
+// Eliminated snakes are ignored on both sides, since game recordings usually omit them. A *Divergence is returned for the first state that doesn't match.
+// Any other error means the game couldn't be simulated at all.
+func Reconstruct(ruleset rules.Ruleset, gameMap maps.GameMap, recorded []*rules.BoardState, moves []map[string]string) ([][]rules.SnakeMove, error) {
+	if len(recorded) == 0 {
+		return nil, fmt.Errorf("no recorded turns to verify")
+	}
+
+	initial := recorded[0]
+	snakeIDs := make([]string, 0, len(initial.Snakes))
+	for _, snake := range initial.Snakes {
+		snakeIDs = append(snakeIDs, snake.ID)
+	}
+	gameOver, boardState, err := Setup(ruleset, gameMap, initial.Width, initial.Height, snakeIDs)
+	if err != nil {
+		return nil, err
+	}
+	if reason := compareBoardStates(initial, boardState); reason != "" {
+		return nil, &Divergence{Turn: initial.Turn, Reason: reason}
+	}
+
+	if gameOver {
+		if len(recorded) > 1 {
+			return nil, &Divergence{Turn: recorded[1].Turn, Reason: "game was already over"}
+		}
+		return [][]rules.SnakeMove{}, nil
+	}
+
+	allMoves := make([][]rules.SnakeMove, 0, len(recorded)-1)
+	for i := 1; i < len(recorded); i++ {
+		var turnMoves map[string]string
+		if i-1 < len(moves) {
+			turnMoves = moves[i-1]
+		}
+
+		// The game over check runs before any moves are applied, so a game that is over
+		// produces no further turns.
+		var madeMoves []rules.SnakeMove
+		gameOver, boardState, madeMoves, err = stepToMatch(ruleset, gameMap, boardState, turnMoves, recorded[i])
+		if err != nil {
+			return nil, err
+		}
+		if gameOver {
+			return nil, &Divergence{Turn: recorded[i].Turn, Reason: "game was already over"}
+		}
+		if reason := compareBoardStates(recorded[i], boardState); reason != "" {
+			return nil, &Divergence{Turn: recorded[i].Turn, Reason: reason}
+		}
+		allMoves = append(allMoves, madeMoves)
+	}
+
+	// Check the game really ended where the recording stops
+	gameOver, _, err = Step(ruleset, gameMap, boardState, completeMoves(boardState, nil, nil))
+	if err != nil {
+		return nil, err
+	}
+	if !gameOver {
+		return nil, &Divergence{Turn: boardState.Turn, Reason: "recording ends but the game is not over"}
+	}
+
+	return allMoves, nil
+}
+
+// stepToMatch advances the board state using the known moves, searching for moves for any snakes
+// that are missing one. The moves used are returned along with the next state. If no combination
+// of moves reproduces the expected state, the result of the first combination tried is returned.
+func stepToMatch(ruleset rules.Ruleset, gameMap maps.GameMap, boardState *rules.BoardState, known map[string]string, expected *rules.BoardState) (bool, *rules.BoardState, []rules.SnakeMove, error) {
+	unknown := []string{}
+	for _, snake := range boardState.Snakes {
+		if snake.EliminatedCause != rules.NotEliminated {
+			continue
+		}
+		if _, ok := known[snake.ID]; !ok {
+			unknown = append(unknown, snake.ID)
+		}
+	}
+
+	// choices holds the index into possibleMoves for each unknown snake, and is incremented like an odometer
+	possibleMoves := []string{rules.MoveUp, rules.MoveDown, rules.MoveLeft, rules.MoveRight}
+	choices := make([]int, len(unknown))
+
+	var firstGameOver bool
+	var firstState *rules.BoardState
+	var firstMoves []rules.SnakeMove
+	for {
+		guessed := make(map[string]string, len(unknown))
+		for i, id := range unknown {
+			guessed[id] = possibleMoves[choices[i]]
+		}
+
+		moves := completeMoves(boardState, known, guessed)
+		gameOver, nextState, err := Step(ruleset, gameMap, boardState, moves)
+		if err != nil {
+			return false, nil, nil, err
+		}
+		if len(unknown) == 0 || compareBoardStates(expected, nextState) == "" {
+			return gameOver, nextState, moves, nil
+		}
+		if firstState == nil {
+			firstGameOver, firstState, firstMoves = gameOver, nextState, moves
+		}
+
+		i := 0
+		for ; i < len(choices); i++ {
+			choices[i]++
+			if choices[i] < len(possibleMoves) {
+				break
+			}
+			choices[i] = 0
+		}
+		if i == len(choices) {
+			return firstGameOver, firstState, firstMoves, nil
+		}
+	}
+}
+
+// completeMoves builds the list of moves for every snake still in the game,
+// defaulting to up when a snake's move is neither known nor guessed.
+func completeMoves(boardState *rules.BoardState, known, guessed map[string]string) []rules.SnakeMove {
+	moves := make([]rules.SnakeMove, 0, len(boardState.Snakes))
+	for _, snake := range boardState.Snakes {
+		if snake.EliminatedCause != rules.NotEliminated {
+			continue
+		}
+		move, ok := known[snake.ID]
+		if !ok {
+			move, ok = guessed[snake.ID]
+		}
+		if !ok {
+			move = rules.MoveUp
+		}
+		moves = append(moves, rules.SnakeMove{ID: snake.ID, Move: move})
+	}
+	return moves
+}
+
+// compareBoardStates returns a description of the first difference between the expected and
+// actual board states, or an empty string if they match. Food, hazards and walls are compared
+// without regard to order, including their TTL and Value, along with the private GameState and
+// PointState. Only snakes that haven't been eliminated are compared.
+func compareBoardStates(expected, actual *rules.BoardState) string {
+	if expected.Turn != actual.Turn {
+		return fmt.Sprintf("expected turn %d, got %d", expected.Turn, actual.Turn)
+	}
+	if expected.Width != actual.Width || expected.Height != actual.Height {
+		return fmt.Sprintf("expected a %dx%d board, got %dx%d", expected.Width, expected.Height, actual.Width, actual.Height)
+	}
+	if !samePoints(expected.Food, actual.Food) {
+		return fmt.Sprintf("expected food %v, got %v", sortedPoints(expected.Food), sortedPoints(actual.Food))
+	}
+	if !samePoints(expected.Hazards, actual.Hazards) {
+		return fmt.Sprintf("expected hazards %v, got %v", sortedPoints(expected.Hazards), sortedPoints(actual.Hazards))
+	}
+	if !samePoints(expected.Walls, actual.Walls) {
+		return fmt.Sprintf("expected walls %v, got %v", sortedPoints(expected.Walls), sortedPoints(actual.Walls))
+	}
+	if !sameGameState(expected.GameState, actual.GameState) {
+		return fmt.Sprintf("expected game state %v, got %v", expected.GameState, actual.GameState)
+	}
+	if !samePointState(expected.PointState, actual.PointState) {
+		return fmt.Sprintf("expected point state %v, got %v", expected.PointState, actual.PointState)
+	}
+
+	actualSnakes := map[string]rules.Snake{}
+	for _, snake := range actual.Snakes {
+		if snake.EliminatedCause == rules.NotEliminated {
+			actualSnakes[snake.ID] = snake
+		}
+	}
+	for _, snake := range expected.Snakes {
+		if snake.EliminatedCause != rules.NotEliminated {
+			continue
+		}
+		actualSnake, ok := actualSnakes[snake.ID]
+		if !ok {
+			return fmt.Sprintf("expected snake %s to be in the game, but it was eliminated", snake.ID)
+		}
+		delete(actualSnakes, snake.ID)
+
+		if snake.Health != actualSnake.Health {
+			return fmt.Sprintf("expected snake %s to have health %d, got %d", snake.ID, snake.Health, actualSnake.Health)
+		}
+		if !sameBody(snake.Body, actualSnake.Body) {
+			return fmt.Sprintf("expected snake %s to have body %v, got %v", snake.ID, snake.Body, actualSnake.Body)
+		}
+	}
+	for _, snake := range actual.Snakes {
+		if _, ok := actualSnakes[snake.ID]; ok {
+			return fmt.Sprintf("expected snake %s to be eliminated", snake.ID)
+		}
+	}
+
+	return ""
+}
+
+func sameBody(a, b []rules.Point) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i].X != b[i].X || a[i].Y != b[i].Y {
+			return false
+		}
+	}
+	return true
+}
+
+func samePoints(a, b []rules.Point) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	sortedA, sortedB := sortedPoints(a), sortedPoints(b)
+	for i := range sortedA {
+		if sortedA[i] != sortedB[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// sortedPoints returns a sorted copy of the given points.
+func sortedPoints(points []rules.Point) []rules.Point {
+	sorted := append([]rules.Point{}, points...)
+	sort.Slice(sorted, func(i, j int) bool {
+		a, b := sorted[i], sorted[j]
+		if a.X != b.X {
+			return a.X < b.X
+		}
+		if a.Y != b.Y {
+			return a.Y < b.Y
+		}
+		if a.TTL != b.TTL {
+			return a.TTL < b.TTL
+		}
+		return a.Value < b.Value
+	})
+	return sorted
+}
+
+// sameGameState compares two GameStates, treating a nil GameState the same as an empty one.
+func sameGameState(a, b map[string]string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for key, value := range a {
+		if other, ok := b[key]; !ok || other != value {
+			return false
+		}
+	}
+	return true
+}
+
+// samePointState compares two PointStates, treating a nil PointState the same as an empty one.
+func samePointState(a, b map[rules.Point]int) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for p, value := range a {
+		if other, ok := b[p]; !ok || other != value {
+			return false
+		}
+	}
+	return true
+}
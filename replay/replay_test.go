@@ -0,0 +1,207 @@
+package replay
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/BattlesnakeOfficial/rules"
+	"github.com/BattlesnakeOfficial/rules/maps"
+	"github.com/stretchr/testify/require"
+)
+
+// playGame plays a game where every snake follows a fixed list of moves, returning every board
+// state with eliminated snakes removed (like a game export) and the moves made on each turn.
+func playGame(t *testing.T, ruleset rules.Ruleset, gameMap maps.GameMap, snakeIDs []string, snakeMoves map[string][]string) ([]*rules.BoardState, []map[string]string) {
+	t.Helper()
+
+	gameOver, boardState, err := Setup(ruleset, gameMap, 11, 11, snakeIDs)
+	require.NoError(t, err)
+
+	recorded := []*rules.BoardState{}
+	moves := []map[string]string{}
+	for !gameOver {
+		recorded = append(recorded, withoutEliminated(boardState))
+
+		turnMoves := map[string]string{}
+		snakeMoveList := []rules.SnakeMove{}
+		for _, snake := range boardState.Snakes {
+			if snake.EliminatedCause != rules.NotEliminated {
+				continue
+			}
+			move := snakeMoves[snake.ID][boardState.Turn%len(snakeMoves[snake.ID])]
+			turnMoves[snake.ID] = move
+			snakeMoveList = append(snakeMoveList, rules.SnakeMove{ID: snake.ID, Move: move})
+		}
+
+		var nextState *rules.BoardState
+		gameOver, nextState, err = Step(ruleset, gameMap, boardState, snakeMoveList)
+		require.NoError(t, err)
+		if !gameOver {
+			moves = append(moves, turnMoves)
+			boardState = nextState
+		}
+	}
+	return recorded, moves
+}
+
+func withoutEliminated(boardState *rules.BoardState) *rules.BoardState {
+	snakes := []rules.Snake{}
+	for _, snake := range boardState.Snakes {
+		if snake.EliminatedCause == rules.NotEliminated {
+			snakes = append(snakes, rules.Snake{ID: snake.ID, Body: snake.Body, Health: snake.Health})
+		}
+	}
+	return rules.NewBoardState(boardState.Width, boardState.Height).
+		WithTurn(boardState.Turn).
+		WithFood(boardState.Food).
+		WithHazards(boardState.Hazards).
+		WithSnakes(snakes)
+}
+
+func requireDivergence(t *testing.T, err error, turn int) *Divergence {
+	t.Helper()
+	var divergence *Divergence
+	require.True(t, errors.As(err, &divergence), "expected a divergence, got %v", err)
+	require.Equal(t, turn, divergence.Turn)
+	return divergence
+}
+
+func TestVerify(t *testing.T) {
+	ruleset := rules.NewRulesetBuilder().
+		WithSeed(1234).
+		WithParams(map[string]string{rules.ParamFoodSpawnChance: "50", rules.ParamMinimumFood: "2"}).
+		NamedRuleset(rules.GameTypeStandard)
+	gameMap, err := maps.GetMap("standard")
+	require.NoError(t, err)
+
+	// one snake circles forever while the other snake runs into a wall
+	snakeMoves := map[string][]string{
+		"one": {rules.MoveUp, rules.MoveRight, rules.MoveDown, rules.MoveLeft},
+		"two": {rules.MoveUp},
+	}
+	recorded, moves := playGame(t, ruleset, gameMap, []string{"one", "two"}, snakeMoves)
+	require.Greater(t, len(recorded), 2)
+
+	require.NoError(t, Verify(ruleset, gameMap, recorded, moves))
+
+	// The move of the eliminated snake on the final turn is recovered automatically
+	lastMoves := moves[len(moves)-1]
+	require.Len(t, lastMoves, 2)
+	delete(lastMoves, "two")
+	require.NoError(t, Verify(ruleset, gameMap, recorded, moves))
+
+	// A different seed places snakes and food differently
+	otherSeed := rules.NewRulesetBuilder().
+		WithSeed(4321).
+		WithParams(map[string]string{rules.ParamFoodSpawnChance: "50", rules.ParamMinimumFood: "2"}).
+		NamedRuleset(rules.GameTypeStandard)
+	requireDivergence(t, Verify(otherSeed, gameMap, recorded, moves), 0)
+
+	// Tampering with a recorded state is reported on that turn
+	tampered := recorded[2].Clone()
+	tampered.Snakes[0].Health = 1
+	divergence := requireDivergence(t, Verify(ruleset, gameMap, append(append([]*rules.BoardState{}, recorded[:2]...), tampered), moves), 2)
+	require.Contains(t, divergence.Reason, "health")
+
+	// A recording that stops before the game is over is also reported
+	requireDivergence(t, Verify(ruleset, gameMap, recorded[:2], moves), 1)
+
+	require.EqualError(t, Verify(ruleset, gameMap, nil, nil), "no recorded turns to verify")
+}
+
+func TestCompareBoardStates(t *testing.T) {
+	base := rules.NewBoardState(5, 5).
+		WithFood([]rules.Point{{X: 1, Y: 1}, {X: 2, Y: 2}}).
+		WithHazards([]rules.Point{{X: 0, Y: 0}}).
+		WithSnakes([]rules.Snake{
+			{ID: "one", Health: 100, Body: []rules.Point{{X: 3, Y: 3}, {X: 3, Y: 2}}},
+			{ID: "gone", Health: 0, Body: []rules.Point{{X: 4, Y: 4}}, EliminatedCause: rules.EliminatedByCollision},
+		})
+
+	require.Equal(t, "", compareBoardStates(base, base.Clone()))
+
+	// food order, eliminated snakes and missing private state don't matter
+	reordered := base.Clone().WithFood([]rules.Point{{X: 2, Y: 2}, {X: 1, Y: 1}})
+	reordered.Snakes = reordered.Snakes[:1]
+	reordered.GameState, reordered.PointState = nil, nil
+	require.Equal(t, "", compareBoardStates(base, reordered))
+
+	for _, test := range []struct {
+		name     string
+		modify   func(b *rules.BoardState)
+		expected string
+	}{
+		{"turn", func(b *rules.BoardState) { b.Turn = 3 }, "expected turn 0, got 3"},
+		{"size", func(b *rules.BoardState) { b.Width = 7 }, "expected a 5x5 board, got 7x5"},
+		{"food", func(b *rules.BoardState) { b.Food = b.Food[:1] }, "expected food [{1 1 0 0} {2 2 0 0}], got [{1 1 0 0}]"},
+		{"hazards", func(b *rules.BoardState) { b.Hazards = nil }, "expected hazards [{0 0 0 0}], got []"},
+		{"food ttl", func(b *rules.BoardState) { b.Food[0].TTL = 3 }, "expected food [{1 1 0 0} {2 2 0 0}], got [{1 1 3 0} {2 2 0 0}]"},
+		{"food nutrition", func(b *rules.BoardState) { b.Food[1].Value = 20 }, "expected food [{1 1 0 0} {2 2 0 0}], got [{1 1 0 0} {2 2 0 20}]"},
+		{"hazard damage", func(b *rules.BoardState) { b.Hazards[0].Value = -10 }, "expected hazards [{0 0 0 0}], got [{0 0 0 -10}]"},
+		{"game state", func(b *rules.BoardState) { b.GameState["level"] = "2" }, "expected game state map[], got map[level:2]"},
+		{"point state", func(b *rules.BoardState) { b.PointState[rules.Point{X: 1, Y: 2}] = 4 }, "expected point state map[], got map[{1 2 0 0}:4]"},
+		{"eliminated", func(b *rules.BoardState) { b.Snakes[0].EliminatedCause = rules.EliminatedByOutOfHealth }, "expected snake one to be in the game, but it was eliminated"},
+		{"health", func(b *rules.BoardState) { b.Snakes[0].Health = 50 }, "expected snake one to have health 100, got 50"},
+		{"body", func(b *rules.BoardState) { b.Snakes[0].Body = b.Snakes[0].Body[:1] }, "expected snake one to have body [{3 3 0 0} {3 2 0 0}], got [{3 3 0 0}]"},
+		{"extra snake", func(b *rules.BoardState) { b.Snakes[1].EliminatedCause = rules.NotEliminated }, "expected snake gone to be eliminated"},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			actual := base.Clone()
+			test.modify(actual)
+			require.Equal(t, test.expected, compareBoardStates(base, actual))
+		})
+	}
+}
+
+func TestReconstruct(t *testing.T) {
+	ruleset := rules.NewRulesetBuilder().WithSeed(99).NamedRuleset(rules.GameTypeStandard)
+	gameMap, err := maps.GetMap("standard")
+	require.NoError(t, err)
+
+	snakeMoves := map[string][]string{
+		"one": {rules.MoveUp, rules.MoveRight, rules.MoveDown, rules.MoveLeft},
+		"two": {rules.MoveDown},
+	}
+	recorded, moves := playGame(t, ruleset, gameMap, []string{"one", "two"}, snakeMoves)
+
+	// forget the move of the snake that was eliminated on the last turn
+	delete(moves[len(moves)-1], "two")
+
+	allMoves, err := Reconstruct(ruleset, gameMap, recorded, moves)
+	require.NoError(t, err)
+	require.Len(t, allMoves, len(recorded)-1)
+	require.Len(t, allMoves[len(allMoves)-1], 2, "the eliminated snake's move should be recovered")
+
+	// replaying the reconstructed moves reproduces every recorded state
+	_, boardState, err := Setup(ruleset, gameMap, 11, 11, []string{"one", "two"})
+	require.NoError(t, err)
+	for i, turnMoves := range allMoves {
+		_, boardState, err = Step(ruleset, gameMap, boardState, turnMoves)
+		require.NoError(t, err)
+		require.Equal(t, "", compareBoardStates(recorded[i+1], boardState))
+	}
+}
+
+func TestStepWith(t *testing.T) {
+	ruleset := rules.NewRulesetBuilder().WithSeed(99).NamedRuleset(rules.GameTypeStandard)
+	gameMap, err := maps.GetMap("standard")
+	require.NoError(t, err)
+	_, boardState, err := Setup(ruleset, gameMap, 11, 11, []string{"one"})
+	require.NoError(t, err)
+
+	// the moves are asked for on the board before the turn is run
+	_, nextState, err := StepWith(ruleset, gameMap, boardState, func(b *rules.BoardState) ([]rules.SnakeMove, error) {
+		require.Equal(t, boardState.Turn, b.Turn)
+		require.Equal(t, boardState.Snakes, b.Snakes)
+		return []rules.SnakeMove{{ID: "one", Move: rules.MoveUp}}, nil
+	})
+	require.NoError(t, err)
+	_, expected, err := Step(ruleset, gameMap, boardState, []rules.SnakeMove{{ID: "one", Move: rules.MoveUp}})
+	require.NoError(t, err)
+	require.Equal(t, expected, nextState)
+
+	_, _, err = StepWith(ruleset, gameMap, boardState, func(*rules.BoardState) ([]rules.SnakeMove, error) {
+		return nil, errors.New("no moves")
+	})
+	require.EqualError(t, err, "no moves")
+}
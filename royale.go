@@ -6,6 +6,7 @@ import (
 
 var royaleRulesetStages = []string{
 	StageGameOverStandard,
+	StageGameOverTurnLimit,
 	StageMovementStandard,
 	StageStarvationStandard,
 	StageHazardDamageStandard,
@@ -32,7 +33,7 @@ func PopulateHazardsRoyale(b *BoardState, settings Settings, moves []SnakeMove)
 		return false, nil
 	}
 
-	randGenerator := settings.GetRand(0)
+	randGenerator := settings.GetStageRand(0, StageSpawnHazardsShrinkMap)
 
 	numShrinks := turn / shrinkEveryNTurns
 	minX, maxX := 0, b.Width-1
@@ -147,8 +147,8 @@ func TestRulesetBuilderGetRand(t *testing.T) {
 	rand1 := ruleset.Settings().GetRand(turn)
 
 	// Should produce a predictable series of numbers based on a seed
-	require.Equal(t, 83, rand1.Intn(100))
-	require.Equal(t, 15, rand1.Intn(100))
+	require.Equal(t, 64, rand1.Intn(100))
+	require.Equal(t, 5, rand1.Intn(100))
 
 	// Should produce the same number if re-initialized
 	require.Equal(
@@ -158,6 +158,6 @@ func TestRulesetBuilderGetRand(t *testing.T) {
 	)
 
 	// Should produce a different series of numbers for another turn
-	require.Equal(t, 69, rand1.Intn(100))
-	require.Equal(t, 86, rand1.Intn(100))
+	require.Equal(t, 33, rand1.Intn(100))
+	require.Equal(t, 38, rand1.Intn(100))
 }
@@ -0,0 +1,89 @@
+package search
+
+import (
+	"github.com/BattlesnakeOfficial/rules"
+	"github.com/BattlesnakeOfficial/rules/analysis"
+)
+
+// Evaluator scores a board state for a snake, from Loss to Win. It's only called for snakes that
+// haven't been eliminated in games that aren't over, and should return scores strictly between
+// Loss and Win so that they're never confused with the end of the game.
+type Evaluator func(b *rules.BoardState, snakeID string) float64
+
+// LengthEvaluator scores a snake by how much longer it is than the longest other snake. With no
+// other snakes left, every board state scores the same.
+func LengthEvaluator(b *rules.BoardState, snakeID string) float64 {
+	own, longest := 0, 0
+	for _, snake := range b.Snakes {
+		if snake.EliminatedCause != rules.NotEliminated {
+			continue
+		}
+		if snake.ID == snakeID {
+			own = len(snake.Body)
+		} else if len(snake.Body) > longest {
+			longest = len(snake.Body)
+		}
+	}
+	if longest == 0 {
+		return 0
+	}
+	return float64(own-longest) / float64(own+longest+1)
+}
+
+// SpaceEvaluator scores a snake by how much more room it has to move around than the other snake
+// with the most room, as a fraction of the board. With no other snakes left, the snake is scored
+// by its own room.
+func SpaceEvaluator(options analysis.Options) Evaluator {
+	return func(b *rules.BoardState, snakeID string) float64 {
+		own, most := 0, 0
+		for _, snake := range b.Snakes {
+			if snake.EliminatedCause != rules.NotEliminated || len(snake.Body) == 0 {
+				continue
+			}
+			area := analysis.ReachableArea(b, snake.Body[0], options)
+			if snake.ID == snakeID {
+				own = area
+			} else if area > most {
+				most = area
+			}
+		}
+		return float64(own-most) / float64(b.Width*b.Height+1)
+	}
+}
+
+// TerritoryEvaluator scores a snake by how much more of the board it can reach first than the
+// other snake with the most territory, as a fraction of the board. See analysis.Territory. This is
+// the evaluator used by searches that aren't given one.
+func TerritoryEvaluator(options analysis.Options) Evaluator {
+	return func(b *rules.BoardState, snakeID string) float64 {
+		areas := analysis.Territory(b, options).Areas()
+		own, most := areas[snakeID], 0
+		for id, area := range areas {
+			if id != snakeID && area > most {
+				most = area
+			}
+		}
+		return float64(own-most) / float64(b.Width*b.Height+1)
+	}
+}
+
+// Weighted is an evaluator and how much it counts towards a blended score.
+type Weighted struct {
+	Evaluate Evaluator
+	Weight   float64
+}
+
+// Blend combines evaluators into one, scoring board states with the weighted average of their scores.
+func Blend(evaluators ...Weighted) Evaluator {
+	return func(b *rules.BoardState, snakeID string) float64 {
+		score, total := 0.0, 0.0
+		for _, e := range evaluators {
+			score += e.Weight * e.Evaluate(b, snakeID)
+			total += e.Weight
+		}
+		if total == 0 {
+			return 0
+		}
+		return score / total
+	}
+}
@@ -0,0 +1,56 @@
+package search
+
+import (
+	"testing"
+
+	"github.com/BattlesnakeOfficial/rules"
+	"github.com/BattlesnakeOfficial/rules/analysis"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLengthEvaluator(t *testing.T) {
+	b := rules.NewBoardState(5, 5).WithSnakes([]rules.Snake{
+		{ID: "one", Body: []rules.Point{{X: 0, Y: 0}, {X: 0, Y: 1}, {X: 0, Y: 2}, {X: 0, Y: 3}}},
+		{ID: "two", Body: []rules.Point{{X: 2, Y: 0}, {X: 2, Y: 1}}},
+		{ID: "gone", Body: []rules.Point{{X: 4, Y: 0}, {X: 4, Y: 1}, {X: 4, Y: 2}, {X: 4, Y: 3}, {X: 4, Y: 4}}, EliminatedCause: rules.EliminatedByCollision},
+	})
+
+	require.InDelta(t, 2.0/7, LengthEvaluator(b, "one"), 1e-9)
+	require.InDelta(t, -2.0/7, LengthEvaluator(b, "two"), 1e-9)
+
+	b.Snakes = b.Snakes[:1]
+	require.Equal(t, 0.0, LengthEvaluator(b, "one"))
+}
+
+func TestSpaceAndTerritoryEvaluators(t *testing.T) {
+	// A wall splits the board, leaving one with more room than two
+	b := rules.NewBoardState(5, 1).
+		WithSnakes([]rules.Snake{
+			{ID: "one", Body: []rules.Point{{X: 0, Y: 0}}},
+			{ID: "two", Body: []rules.Point{{X: 4, Y: 0}}},
+		}).
+		WithWalls([]rules.Point{{X: 3, Y: 0}})
+
+	space := SpaceEvaluator(analysis.Options{})
+	require.InDelta(t, 2.0/6, space(b, "one"), 1e-9)
+	require.InDelta(t, -2.0/6, space(b, "two"), 1e-9)
+
+	territory := TerritoryEvaluator(analysis.Options{})
+	require.InDelta(t, 2.0/6, territory(b, "one"), 1e-9)
+
+	// Wrapped around, both snakes can reach the same cells, but one still gets to more of them first
+	space = SpaceEvaluator(analysis.Options{Wrapped: true})
+	require.Equal(t, 0.0, space(b, "one"))
+	territory = TerritoryEvaluator(analysis.Options{Wrapped: true})
+	require.InDelta(t, 2.0/6, territory(b, "one"), 1e-9)
+}
+
+func TestBlend(t *testing.T) {
+	half := func(*rules.BoardState, string) float64 { return 0.5 }
+	negative := func(*rules.BoardState, string) float64 { return -1 }
+	b := rules.NewBoardState(3, 3)
+
+	require.InDelta(t, 0.0, Blend(Weighted{half, 2}, Weighted{negative, 1})(b, "one"), 1e-9)
+	require.Equal(t, 0.5, Blend(Weighted{half, 1})(b, "one"))
+	require.Equal(t, 0.0, Blend()(b, "one"))
+}
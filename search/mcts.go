@@ -0,0 +1,247 @@
+package search
+
+import (
+	"math"
+
+	"github.com/BattlesnakeOfficial/rules"
+)
+
+// Defaults for MCTS.
+const (
+	// How many board states MCTS simulates when its budget has no limits.
+	DefaultNodes = 10000
+	// How many moves ahead each simulated game is played when the budget doesn't set a depth.
+	DefaultPlayoutDepth = 10
+	// How much MCTS favours trying moves it knows little about over moves that have done well.
+	DefaultExploration = math.Sqrt2
+)
+
+// MCTS is a Monte Carlo tree search for simultaneous moves. Each iteration walks down the tree
+// with every snake choosing its own move at every node, using the UCB1 formula on the results of
+// its moves from that node so far. The first new board state reached is added to the tree and
+// the game is played on from it with random safe moves, up to the budget's depth. The board
+// state it ends on is scored for every snake, and each snake's score is added to the results of
+// the moves it chose. The snake's most tried move at the top of the tree is returned.
+type MCTS struct {
+	Ruleset rules.Ruleset
+	// Scores board states at the end of each simulated game. Defaults to TerritoryEvaluator.
+	Evaluate Evaluator
+	Budget   Budget
+	// Defaults to DefaultExploration.
+	Exploration float64
+	// Used to break ties between untried moves and play random moves. Defaults to a generator
+	// seeded with 0, so the same search always picks the same move.
+	Rand rules.Rand
+}
+
+// mctsNode is a board state in the tree, with the results of every snake's moves from it.
+type mctsNode struct {
+	board    *rules.BoardState
+	gameOver bool
+	snakeIDs []string
+	moves    [][]string
+	visits   int
+	// The number of times each move of each snake has been chosen, and the total of their scores.
+	tries    [][]int
+	rewards  [][]float64
+	children map[string]*mctsNode
+}
+
+func newMCTSNode(sim *simulation, b *rules.BoardState, gameOver bool) *mctsNode {
+	n := &mctsNode{board: b, gameOver: gameOver, children: map[string]*mctsNode{}}
+	if gameOver {
+		return n
+	}
+	n.snakeIDs = aliveSnakeIDs(b)
+	for _, id := range n.snakeIDs {
+		moves := sim.moves(b, id)
+		n.moves = append(n.moves, moves)
+		n.tries = append(n.tries, make([]int, len(moves)))
+		n.rewards = append(n.rewards, make([]float64, len(moves)))
+	}
+	return n
+}
+
+// mctsSearch holds the state of a single search.
+type mctsSearch struct {
+	*simulation
+	snakeID     string
+	exploration float64
+	rand        rules.Rand
+	depth       int
+	maxDepth    int
+}
+
+func (m *MCTS) Search(b *rules.BoardState, snakeID string) (Result, error) {
+	if isEliminated(b, snakeID) {
+		return Result{}, ErrorSnakeNotFound
+	}
+
+	budget := m.Budget
+	if budget.Time == 0 && budget.Nodes == 0 {
+		budget.Nodes = DefaultNodes
+	}
+	s := &mctsSearch{
+		simulation:  newSimulation(m.Ruleset, m.Evaluate, budget),
+		snakeID:     snakeID,
+		exploration: m.Exploration,
+		rand:        m.Rand,
+		depth:       budget.Depth,
+	}
+	if s.exploration == 0 {
+		s.exploration = DefaultExploration
+	}
+	if s.rand == nil {
+		s.rand = rules.NewSeedRand(0)
+	}
+	if s.depth == 0 {
+		s.depth = DefaultPlayoutDepth
+	}
+
+	root := newMCTSNode(s.simulation, b, false)
+	for {
+		err := s.iterate(root)
+		if err == errBudgetSpent {
+			break
+		}
+		if err != nil {
+			return Result{}, err
+		}
+	}
+
+	// The most tried move is the one the search trusts most
+	self := 0
+	for i, id := range root.snakeIDs {
+		if id == snakeID {
+			self = i
+		}
+	}
+	result := Result{Move: root.moves[self][0], Score: Loss, Depth: s.maxDepth, Nodes: s.nodes}
+	best := -1
+	for i, tries := range root.tries[self] {
+		if tries > best {
+			best = tries
+			result.Move = root.moves[self][i]
+			if tries > 0 {
+				result.Score = 2*root.rewards[self][i]/float64(tries) - 1
+			}
+		}
+	}
+	return result, nil
+}
+
+// iterate walks down the tree, adds a board state to it, plays a game on from there and records
+// the result for every snake along the way.
+func (s *mctsSearch) iterate(root *mctsNode) error {
+	type visit struct {
+		node    *mctsNode
+		choices []int
+	}
+	var path []visit
+
+	var scores map[string]float64
+	node := root
+	for {
+		if node.gameOver || len(path) == s.depth {
+			// Nothing new is simulated, but it still counts towards the budget
+			if err := s.spend(); err != nil {
+				return err
+			}
+			scores = s.scores(node.board, node.gameOver)
+			break
+		}
+
+		choices := s.choose(node)
+		path = append(path, visit{node, choices})
+		key := make([]byte, len(choices))
+		moves := make([]rules.SnakeMove, len(choices))
+		for i, c := range choices {
+			key[i] = byte(c)
+			moves[i] = rules.SnakeMove{ID: node.snakeIDs[i], Move: node.moves[i][c]}
+		}
+
+		child, ok := node.children[string(key)]
+		if !ok {
+			gameOver, next, err := s.step(node.board, moves)
+			if err != nil {
+				return err
+			}
+			child = newMCTSNode(s.simulation, next, gameOver)
+			node.children[string(key)] = child
+
+			scores, err = s.playout(next, gameOver, s.depth-len(path))
+			if err != nil {
+				return err
+			}
+			break
+		}
+		node = child
+	}
+
+	if len(path) > s.maxDepth {
+		s.maxDepth = len(path)
+	}
+	for _, v := range path {
+		v.node.visits++
+		for i, c := range v.choices {
+			v.node.tries[i][c]++
+			v.node.rewards[i][c] += (scores[v.node.snakeIDs[i]] + 1) / 2
+		}
+	}
+	return nil
+}
+
+// choose picks a move for every snake at a node, trying each move once before using UCB1.
+func (s *mctsSearch) choose(node *mctsNode) []int {
+	choices := make([]int, len(node.snakeIDs))
+	for i := range node.snakeIDs {
+		var untried []int
+		for c, tries := range node.tries[i] {
+			if tries == 0 {
+				untried = append(untried, c)
+			}
+		}
+		if len(untried) > 0 {
+			choices[i] = untried[s.rand.Intn(len(untried))]
+			continue
+		}
+
+		best := math.Inf(-1)
+		for c, tries := range node.tries[i] {
+			value := node.rewards[i][c]/float64(tries) + s.exploration*math.Sqrt(math.Log(float64(node.visits))/float64(tries))
+			if value > best {
+				best, choices[i] = value, c
+			}
+		}
+	}
+	return choices
+}
+
+// playout plays a game on with random safe moves and scores where it ends for every snake.
+func (s *mctsSearch) playout(b *rules.BoardState, gameOver bool, depth int) (map[string]float64, error) {
+	for ; depth > 0 && !gameOver; depth-- {
+		var moves []rules.SnakeMove
+		for _, id := range aliveSnakeIDs(b) {
+			options := s.moves(b, id)
+			moves = append(moves, rules.SnakeMove{ID: id, Move: options[s.rand.Intn(len(options))]})
+		}
+		var err error
+		gameOver, b, err = s.step(b, moves)
+		if err != nil {
+			return nil, err
+		}
+		if _, over := outcome(b, gameOver, s.snakeID); over {
+			break
+		}
+	}
+	return s.scores(b, gameOver), nil
+}
+
+// scores scores a board state for every snake.
+func (s *mctsSearch) scores(b *rules.BoardState, gameOver bool) map[string]float64 {
+	scores := make(map[string]float64, len(b.Snakes))
+	for _, snake := range b.Snakes {
+		scores[snake.ID] = s.score(b, gameOver, snake.ID)
+	}
+	return scores
+}
@@ -0,0 +1,201 @@
+package search
+
+import (
+	"math"
+
+	"github.com/BattlesnakeOfficial/rules"
+)
+
+// DefaultDepth is how many moves ahead Minimax and BestReply search when their budget has no limits.
+const DefaultDepth = 3
+
+// Minimax is a paranoid minimax search with alpha-beta pruning. At every turn the snake chooses
+// its move first, and then all of the other snakes choose their moves together, knowing its
+// move, to make the result as bad as possible for it. The search deepens one move at a time
+// until the budget runs out, and returns the result of the deepest search that finished.
+type Minimax struct {
+	Ruleset rules.Ruleset
+	// Scores board states at the end of the search. Defaults to TerritoryEvaluator.
+	Evaluate Evaluator
+	Budget   Budget
+}
+
+func (m *Minimax) Search(b *rules.BoardState, snakeID string) (Result, error) {
+	search := &treeSearch{simulation: newSimulation(m.Ruleset, m.Evaluate, m.Budget), snakeID: snakeID, replies: paranoidReplies}
+	return search.run(b, m.Budget)
+}
+
+// BestReply is a best-reply search. It's searched like Minimax, but instead of every other snake
+// choosing a move together, only the one with the most damaging reply chooses its move, while
+// the rest make their first safe move.
+type BestReply struct {
+	Ruleset rules.Ruleset
+	// Scores board states at the end of the search. Defaults to TerritoryEvaluator.
+	Evaluate Evaluator
+	Budget   Budget
+}
+
+func (m *BestReply) Search(b *rules.BoardState, snakeID string) (Result, error) {
+	search := &treeSearch{simulation: newSimulation(m.Ruleset, m.Evaluate, m.Budget), snakeID: snakeID, replies: bestReplies}
+	return search.run(b, m.Budget)
+}
+
+// treeSearch is an alpha-beta search where the snake is the maximizing player, and the replies
+// of the other snakes to each of its moves are the minimizing player.
+type treeSearch struct {
+	*simulation
+	snakeID string
+	// Returns the moves for every snake to try after the snake makes a move.
+	replies func(sim *simulation, b *rules.BoardState, snakeID, move string) [][]rules.SnakeMove
+	// Whether the last search stopped anywhere because it was deep enough, rather than because
+	// the game was over.
+	cutoff bool
+}
+
+func (s *treeSearch) run(b *rules.BoardState, budget Budget) (Result, error) {
+	if isEliminated(b, s.snakeID) {
+		return Result{}, ErrorSnakeNotFound
+	}
+
+	maxDepth := budget.Depth
+	if budget.unlimited() {
+		maxDepth = DefaultDepth
+	}
+	moves := s.moves(b, s.snakeID)
+	result := Result{Move: moves[0], Score: Loss}
+	for depth := 1; maxDepth == 0 || depth <= maxDepth; depth++ {
+		s.cutoff = false
+		move, score, err := s.root(b, moves, depth)
+		if err == errBudgetSpent {
+			// A search that didn't finish is only better than nothing
+			if result.Depth == 0 && move != "" {
+				result.Move, result.Score = move, score
+			}
+			break
+		}
+		if err != nil {
+			return Result{}, err
+		}
+		result = Result{Move: move, Score: score, Depth: depth}
+		if !s.cutoff {
+			break
+		}
+
+		// Searching the best move first lets more of the others be pruned next time
+		for i, m := range moves {
+			if m == move {
+				copy(moves[1:i+1], moves[:i])
+				moves[0] = move
+				break
+			}
+		}
+	}
+	result.Nodes = s.nodes
+	return result, nil
+}
+
+// root returns the best move and its score, or the best so far if the budget runs out.
+func (s *treeSearch) root(b *rules.BoardState, moves []string, depth int) (string, float64, error) {
+	best, alpha := "", math.Inf(-1)
+	for _, move := range moves {
+		score, err := s.minimize(b, move, depth, 0, alpha, math.Inf(1))
+		if err != nil {
+			return best, alpha, err
+		}
+		if best == "" || score > alpha {
+			best, alpha = move, score
+		}
+	}
+	return best, alpha, nil
+}
+
+// maximize scores a board state by the snake's best move.
+func (s *treeSearch) maximize(b *rules.BoardState, gameOver bool, depth, ply int, alpha, beta float64) (float64, error) {
+	if score, ok := outcome(b, gameOver, s.snakeID); ok {
+		// Prefer winning sooner and losing later
+		return score * (1 - float64(ply)/1000), nil
+	}
+	if depth == 0 {
+		s.cutoff = true
+		return s.evaluate(b, s.snakeID), nil
+	}
+
+	best := math.Inf(-1)
+	for _, move := range s.moves(b, s.snakeID) {
+		score, err := s.minimize(b, move, depth, ply, alpha, beta)
+		if err != nil {
+			return 0, err
+		}
+		best = math.Max(best, score)
+		alpha = math.Max(alpha, best)
+		if alpha >= beta {
+			break
+		}
+	}
+	return best, nil
+}
+
+// minimize scores one of the snake's moves by the other snakes' most damaging reply.
+func (s *treeSearch) minimize(b *rules.BoardState, move string, depth, ply int, alpha, beta float64) (float64, error) {
+	best := math.Inf(1)
+	for _, moves := range s.replies(s.simulation, b, s.snakeID, move) {
+		gameOver, next, err := s.step(b, moves)
+		if err != nil {
+			return 0, err
+		}
+		score, err := s.maximize(next, gameOver, depth-1, ply+1, alpha, beta)
+		if err != nil {
+			return 0, err
+		}
+		best = math.Min(best, score)
+		beta = math.Min(beta, best)
+		if alpha >= beta {
+			break
+		}
+	}
+	return best, nil
+}
+
+// paranoidReplies returns every combination of moves the other snakes can make.
+func paranoidReplies(sim *simulation, b *rules.BoardState, snakeID, move string) [][]rules.SnakeMove {
+	replies := [][]rules.SnakeMove{{{ID: snakeID, Move: move}}}
+	for _, id := range aliveSnakeIDs(b) {
+		if id == snakeID {
+			continue
+		}
+		var next [][]rules.SnakeMove
+		for _, reply := range replies {
+			for _, m := range sim.moves(b, id) {
+				moves := append(append([]rules.SnakeMove{}, reply...), rules.SnakeMove{ID: id, Move: m})
+				next = append(next, moves)
+			}
+		}
+		replies = next
+	}
+	return replies
+}
+
+// bestReplies returns the moves where one of the other snakes makes any move it can, and the
+// rest make their first safe move.
+func bestReplies(sim *simulation, b *rules.BoardState, snakeID, move string) [][]rules.SnakeMove {
+	defaults := []rules.SnakeMove{{ID: snakeID, Move: move}}
+	var others [][]string
+	for _, id := range aliveSnakeIDs(b) {
+		if id == snakeID {
+			continue
+		}
+		moves := sim.moves(b, id)
+		defaults = append(defaults, rules.SnakeMove{ID: id, Move: moves[0]})
+		others = append(others, moves)
+	}
+
+	replies := [][]rules.SnakeMove{defaults}
+	for i, moves := range others {
+		for _, m := range moves[1:] {
+			reply := append([]rules.SnakeMove{}, defaults...)
+			reply[i+1].Move = m
+			replies = append(replies, reply)
+		}
+	}
+	return replies
+}
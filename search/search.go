@@ -0,0 +1,252 @@
+// Package search chooses moves for a snake by looking ahead through a rules.Ruleset. Every snake
+// moves at the same time, and each search models that differently:
+//
+//   - Minimax is a paranoid minimax search with alpha-beta pruning. The snake chooses a move, and
+//     then all of the other snakes choose theirs together to make things as bad as possible for it.
+//   - BestReply is a best-reply search. It's like Minimax, but only the one opponent with the most
+//     damaging reply gets to choose, while the others make a default move. With several snakes it
+//     searches far fewer board states, so it can look further ahead in the same time.
+//   - MCTS is a Monte Carlo tree search where every snake chooses its own moves from its own
+//     statistics (decoupled UCT), so the other snakes play for themselves instead of against it.
+//
+// Board states are scored for a snake by an Evaluator, from Loss to Win, and a Budget limits how
+// long a search runs. The searches only run the ruleset, so changes that a map would make to the
+// board during the game aren't taken into account.
+package search
+
+import (
+	"errors"
+	"time"
+
+	"github.com/BattlesnakeOfficial/rules"
+	"github.com/BattlesnakeOfficial/rules/analysis"
+)
+
+// Scores for snakes whose game is over. Evaluators score every other board state between Loss and Win.
+const (
+	Loss = -1.0
+	Draw = 0.0
+	Win  = 1.0
+)
+
+const ErrorSnakeNotFound = rules.RulesetError("snake is not in the game")
+
+// Searcher chooses a move for a snake.
+type Searcher interface {
+	Search(b *rules.BoardState, snakeID string) (Result, error)
+}
+
+// Result is the move chosen by a search.
+type Result struct {
+	Move string
+	// The expected score of the move for the snake, from Loss to Win.
+	Score float64
+	// The number of moves ahead that were searched. For MCTS, this is the deepest the tree grew.
+	Depth int
+	// The number of board states searched.
+	Nodes int
+}
+
+// Budget limits how long a search runs. The search stops as soon as any limit is reached and
+// returns the best move found so far. Limits left at zero aren't applied, but a search with no
+// limits at all uses a default budget.
+type Budget struct {
+	// How long to search for.
+	Time time.Duration
+	// How many moves ahead to search. For MCTS, how many moves ahead each simulated game is played
+	// before it's scored.
+	Depth int
+	// How many board states to search.
+	Nodes int
+}
+
+func (budget Budget) unlimited() bool {
+	return budget.Time == 0 && budget.Depth == 0 && budget.Nodes == 0
+}
+
+var errBudgetSpent = errors.New("search budget spent")
+
+// SafeMoves returns the moves that don't take a snake off the board or into a wall or a snake
+// body right away, in the order up, down, left, right. Tails are treated as safe unless the snake
+// has just eaten, so moves that the ruleset might still eliminate the snake for can be included.
+func SafeMoves(b *rules.BoardState, snakeID string, wrapped bool) []string {
+	var head rules.Point
+	found := false
+	blocked := map[rules.Point]bool{}
+	for _, p := range b.Walls {
+		blocked[rules.Point{X: p.X, Y: p.Y}] = true
+	}
+	for _, snake := range b.Snakes {
+		if snake.EliminatedCause != rules.NotEliminated || len(snake.Body) == 0 {
+			continue
+		}
+		if snake.ID == snakeID {
+			head, found = snake.Body[0], true
+		}
+		for i, p := range snake.Body {
+			if i == len(snake.Body)-1 && i > 0 && p != snake.Body[i-1] {
+				continue
+			}
+			blocked[rules.Point{X: p.X, Y: p.Y}] = true
+		}
+	}
+	if !found {
+		return nil
+	}
+
+	var moves []string
+	for _, move := range []string{rules.MoveUp, rules.MoveDown, rules.MoveLeft, rules.MoveRight} {
+		p := rules.Point{X: head.X, Y: head.Y}
+		switch move {
+		case rules.MoveUp:
+			p.Y++
+		case rules.MoveDown:
+			p.Y--
+		case rules.MoveLeft:
+			p.X--
+		case rules.MoveRight:
+			p.X++
+		}
+		if wrapped {
+			p.X = (p.X + b.Width) % b.Width
+			p.Y = (p.Y + b.Height) % b.Height
+		} else if p.X < 0 || p.X >= b.Width || p.Y < 0 || p.Y >= b.Height {
+			continue
+		}
+		if !blocked[p] {
+			moves = append(moves, move)
+		}
+	}
+	return moves
+}
+
+// simulation runs moves through a ruleset for a search, keeping track of its budget.
+type simulation struct {
+	ruleset  rules.Ruleset
+	wrapped  bool
+	evaluate Evaluator
+	deadline time.Time
+	maxNodes int
+	nodes    int
+	spent    bool
+}
+
+func newSimulation(ruleset rules.Ruleset, evaluate Evaluator, budget Budget) *simulation {
+	options := analysis.OptionsForGame(ruleset.Name())
+	if evaluate == nil {
+		evaluate = TerritoryEvaluator(options)
+	}
+	sim := &simulation{
+		ruleset:  ruleset,
+		wrapped:  options.Wrapped,
+		evaluate: evaluate,
+		maxNodes: budget.Nodes,
+	}
+	if budget.Time > 0 {
+		sim.deadline = time.Now().Add(budget.Time)
+	}
+	return sim
+}
+
+// spend counts a board state towards the budget, or returns errBudgetSpent once it has run out.
+func (sim *simulation) spend() error {
+	if !sim.spent && sim.maxNodes > 0 && sim.nodes >= sim.maxNodes {
+		sim.spent = true
+	}
+	// Checking the time is slow compared to a turn, so it's only checked every so often
+	if !sim.spent && !sim.deadline.IsZero() && sim.nodes%64 == 0 && time.Now().After(sim.deadline) {
+		sim.spent = true
+	}
+	if sim.spent {
+		return errBudgetSpent
+	}
+	sim.nodes++
+	return nil
+}
+
+// step plays a turn, counting the board state it simulates towards the budget.
+func (sim *simulation) step(b *rules.BoardState, moves []rules.SnakeMove) (bool, *rules.BoardState, error) {
+	if err := sim.spend(); err != nil {
+		return false, nil, err
+	}
+	gameOver, next, err := sim.ruleset.Execute(b, moves)
+	if err != nil {
+		return false, nil, err
+	}
+	next.Turn++
+	return gameOver, next, nil
+}
+
+// moves returns the moves to search for a snake. A snake without any safe moves is eliminated
+// whatever it does, so only one move is searched.
+func (sim *simulation) moves(b *rules.BoardState, snakeID string) []string {
+	moves := SafeMoves(b, snakeID, sim.wrapped)
+	if len(moves) == 0 {
+		return []string{rules.MoveUp}
+	}
+	return moves
+}
+
+// score returns the score of a board state for a snake, using the evaluator unless the game is over for it.
+func (sim *simulation) score(b *rules.BoardState, gameOver bool, snakeID string) float64 {
+	if score, ok := outcome(b, gameOver, snakeID); ok {
+		return score
+	}
+	return sim.evaluate(b, snakeID)
+}
+
+// outcome returns the score for a snake if its game is over. Snakes that are eliminated lose,
+// unless every snake left was eliminated on the same turn, which is a draw.
+func outcome(b *rules.BoardState, gameOver bool, snakeID string) (float64, bool) {
+	var self *rules.Snake
+	alive := 0
+	for i := range b.Snakes {
+		if b.Snakes[i].ID == snakeID {
+			self = &b.Snakes[i]
+		}
+		if b.Snakes[i].EliminatedCause == rules.NotEliminated {
+			alive++
+		}
+	}
+	if self == nil {
+		return Loss, true
+	}
+
+	if self.EliminatedCause != rules.NotEliminated {
+		if alive > 0 {
+			return Loss, true
+		}
+		for _, snake := range b.Snakes {
+			if snake.ID != snakeID && snake.EliminatedOnTurn == self.EliminatedOnTurn {
+				return Draw, true
+			}
+		}
+		return Loss, true
+	}
+	if gameOver {
+		if alive == 1 && len(b.Snakes) > 1 {
+			return Win, true
+		}
+		return Draw, true
+	}
+	return 0, false
+}
+
+func aliveSnakeIDs(b *rules.BoardState) []string {
+	var ids []string
+	for _, snake := range b.Snakes {
+		if snake.EliminatedCause == rules.NotEliminated {
+			ids = append(ids, snake.ID)
+		}
+	}
+	return ids
+}
+
+func isEliminated(b *rules.BoardState, snakeID string) bool {
+	for _, snake := range b.Snakes {
+		if snake.ID == snakeID {
+			return snake.EliminatedCause != rules.NotEliminated
+		}
+	}
+	return true
+}
@@ -0,0 +1,189 @@
+package search
+
+import (
+	"testing"
+	"time"
+
+	"github.com/BattlesnakeOfficial/rules"
+	"github.com/stretchr/testify/require"
+)
+
+// killBoard is a board where snake one wins by moving down, meeting snake two head on in the
+// only square it can move to.
+func killBoard() *rules.BoardState {
+	return rules.NewBoardState(7, 7).WithSnakes([]rules.Snake{
+		{ID: "one", Health: 100, Body: []rules.Point{{X: 0, Y: 2}, {X: 0, Y: 3}, {X: 0, Y: 4}, {X: 0, Y: 5}}},
+		{ID: "two", Health: 100, Body: []rules.Point{{X: 0, Y: 0}, {X: 1, Y: 0}, {X: 2, Y: 0}}},
+	})
+}
+
+// trapBoard is a board where moving up or left leads into a dead end, and only moving right survives.
+func trapBoard() *rules.BoardState {
+	return rules.NewBoardState(5, 5).
+		WithSnakes([]rules.Snake{
+			{ID: "one", Health: 100, Body: []rules.Point{{X: 1, Y: 3}, {X: 1, Y: 2}, {X: 1, Y: 1}}},
+		}).
+		WithWalls([]rules.Point{{X: 0, Y: 4}, {X: 2, Y: 4}, {X: 0, Y: 2}})
+}
+
+// openingBoard is the start of a standard game with four snakes.
+func openingBoard(t testing.TB) *rules.BoardState {
+	b, err := rules.CreateDefaultBoardState(rules.MinRand, 11, 11, []string{"one", "two", "three", "four"})
+	require.NoError(t, err)
+	return b
+}
+
+func TestSafeMoves(t *testing.T) {
+	b := rules.NewBoardState(3, 3).
+		WithSnakes([]rules.Snake{
+			{ID: "one", Body: []rules.Point{{X: 0, Y: 0}, {X: 0, Y: 1}, {X: 0, Y: 2}}},
+			{ID: "two", Body: []rules.Point{{X: 2, Y: 1}, {X: 2, Y: 2}, {X: 1, Y: 2}}},
+			{ID: "gone", Body: []rules.Point{{X: 1, Y: 1}}, EliminatedCause: rules.EliminatedByCollision},
+		})
+
+	require.Equal(t, []string{rules.MoveRight}, SafeMoves(b, "one", false))
+	require.Equal(t, []string{rules.MoveDown, rules.MoveLeft, rules.MoveRight}, SafeMoves(b, "one", true))
+	// The tail moves out of the way, unless the snake has just eaten
+	require.Equal(t, []string{rules.MoveDown, rules.MoveLeft}, SafeMoves(b, "two", false))
+	b.Snakes[1].Body = []rules.Point{{X: 2, Y: 1}, {X: 1, Y: 1}, {X: 1, Y: 1}}
+	require.Equal(t, []string{rules.MoveUp, rules.MoveDown}, SafeMoves(b, "two", false))
+
+	b.Walls = []rules.Point{{X: 1, Y: 0}}
+	require.Empty(t, SafeMoves(b, "one", false))
+	require.Nil(t, SafeMoves(b, "gone", false))
+	require.Nil(t, SafeMoves(b, "missing", false))
+}
+
+func TestOutcome(t *testing.T) {
+	b := rules.NewBoardState(3, 3).WithSnakes([]rules.Snake{
+		{ID: "one", Body: []rules.Point{{X: 0, Y: 0}}},
+		{ID: "two", Body: []rules.Point{{X: 2, Y: 2}}},
+	})
+
+	_, ok := outcome(b, false, "one")
+	require.False(t, ok)
+	score, ok := outcome(b, false, "missing")
+	require.True(t, ok)
+	require.Equal(t, Loss, score)
+
+	rules.EliminateSnake(&b.Snakes[1], rules.EliminatedByOutOfBounds, "", 3)
+	score, ok = outcome(b, true, "one")
+	require.True(t, ok)
+	require.Equal(t, Win, score)
+	score, ok = outcome(b, true, "two")
+	require.True(t, ok)
+	require.Equal(t, Loss, score)
+
+	// Snakes eliminated together draw
+	rules.EliminateSnake(&b.Snakes[0], rules.EliminatedByOutOfBounds, "", 3)
+	score, ok = outcome(b, true, "one")
+	require.True(t, ok)
+	require.Equal(t, Draw, score)
+	b.Snakes[1].EliminatedOnTurn = 2
+	score, ok = outcome(b, true, "one")
+	require.True(t, ok)
+	require.Equal(t, Loss, score)
+
+	// Solo games can only be lost
+	b.Snakes = b.Snakes[:1]
+	score, ok = outcome(b, true, "one")
+	require.True(t, ok)
+	require.Equal(t, Loss, score)
+}
+
+func TestSearchers(t *testing.T) {
+	standard := rules.NewRulesetBuilder().NamedRuleset(rules.GameTypeStandard)
+	solo := rules.NewRulesetBuilder().WithSolo(true).NamedRuleset(rules.GameTypeSolo)
+
+	for name, searcher := range map[string]func(rules.Ruleset, Budget) Searcher{
+		"minimax": func(ruleset rules.Ruleset, budget Budget) Searcher {
+			return &Minimax{Ruleset: ruleset, Budget: budget}
+		},
+		"best reply": func(ruleset rules.Ruleset, budget Budget) Searcher {
+			return &BestReply{Ruleset: ruleset, Budget: budget}
+		},
+		"mcts": func(ruleset rules.Ruleset, budget Budget) Searcher {
+			return &MCTS{Ruleset: ruleset, Budget: budget, Rand: rules.NewSeedRand(1)}
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			result, err := searcher(standard, Budget{Depth: 2, Nodes: 2000}).Search(killBoard(), "one")
+			require.NoError(t, err)
+			require.Equal(t, rules.MoveDown, result.Move)
+			require.Greater(t, result.Score, 0.9)
+
+			result, err = searcher(solo, Budget{Depth: 3, Nodes: 2000}).Search(trapBoard(), "one")
+			require.NoError(t, err)
+			require.Equal(t, rules.MoveRight, result.Move)
+			require.Greater(t, result.Score, Loss)
+
+			// Every move loses, but the search still chooses one
+			b := trapBoard()
+			b.Walls = append(b.Walls, rules.Point{X: 2, Y: 3})
+			result, err = searcher(solo, Budget{Depth: 3, Nodes: 2000}).Search(b, "one")
+			require.NoError(t, err)
+			require.Contains(t, []string{rules.MoveUp, rules.MoveLeft}, result.Move)
+			require.Less(t, result.Score, -0.9)
+
+			result, err = searcher(standard, Budget{Nodes: 50}).Search(openingBoard(t), "one")
+			require.NoError(t, err)
+			require.NotEmpty(t, result.Move)
+			require.LessOrEqual(t, result.Nodes, 50)
+
+			start := time.Now()
+			result, err = searcher(standard, Budget{Time: 50 * time.Millisecond}).Search(openingBoard(t), "one")
+			require.NoError(t, err)
+			require.NotEmpty(t, result.Move)
+			require.Greater(t, result.Depth, 0)
+			require.Less(t, time.Since(start), time.Second)
+
+			_, err = searcher(standard, Budget{}).Search(killBoard(), "missing")
+			require.ErrorIs(t, err, ErrorSnakeNotFound)
+		})
+	}
+}
+
+func TestMCTSDefaultRand(t *testing.T) {
+	ruleset := rules.NewRulesetBuilder().WithSeed(1).NamedRuleset(rules.GameTypeStandard)
+	first, err := (&MCTS{Ruleset: ruleset, Budget: Budget{Nodes: 500}}).Search(openingBoard(t), "one")
+	require.NoError(t, err)
+	second, err := (&MCTS{Ruleset: ruleset, Budget: Budget{Nodes: 500}}).Search(openingBoard(t), "one")
+	require.NoError(t, err)
+	require.Equal(t, first, second)
+}
+
+func BenchmarkMinimax(b *testing.B) {
+	benchmarkSearcher(b, func(ruleset rules.Ruleset) Searcher {
+		return &Minimax{Ruleset: ruleset, Budget: Budget{Depth: 2}}
+	})
+}
+
+func BenchmarkBestReply(b *testing.B) {
+	benchmarkSearcher(b, func(ruleset rules.Ruleset) Searcher {
+		return &BestReply{Ruleset: ruleset, Budget: Budget{Depth: 3}}
+	})
+}
+
+func BenchmarkMCTS(b *testing.B) {
+	benchmarkSearcher(b, func(ruleset rules.Ruleset) Searcher {
+		return &MCTS{Ruleset: ruleset, Budget: Budget{Nodes: 2000}, Rand: rules.NewSeedRand(1)}
+	})
+}
+
+// benchmarkSearcher searches the opening of a standard game, reporting how many board states
+// are searched per second along with the time per search.
+func benchmarkSearcher(b *testing.B, newSearcher func(rules.Ruleset) Searcher) {
+	searcher := newSearcher(rules.NewRulesetBuilder().WithSeed(1).NamedRuleset(rules.GameTypeStandard))
+	board := openingBoard(b)
+
+	nodes := 0
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		result, err := searcher.Search(board, "one")
+		if err != nil {
+			b.Fatal(err)
+		}
+		nodes += result.Nodes
+	}
+	b.ReportMetric(float64(nodes)/b.Elapsed().Seconds(), "nodes/s")
+}
@@ -1,6 +1,9 @@
 package rules
 
-import "strconv"
+import (
+	"math/rand"
+	"strconv"
+)
 
 // Settings contains all settings relevant to a game.
 // The settings are stored as raw string values, which should not be accessed
@@ -22,6 +25,7 @@ func NewSettings(params map[string]string) Settings {
 
 	return Settings{
 		rawValues: rawValues,
+		seed:      newSeed(),
 	}
 }
 
@@ -34,22 +38,36 @@ func NewSettingsWithParams(params ...string) Settings {
 
 	return Settings{
 		rawValues: rawValues,
+		seed:      newSeed(),
+	}
+}
+
+// newSeed picks the seed for settings that aren't given one, so that every unseeded game is
+// different but can still be reproduced from its Seed.
+func newSeed() int64 {
+	for {
+		if seed := rand.Int63(); seed != 0 {
+			return seed
+		}
 	}
 }
 
 // Get a random number generator initialized based on the seed and current turn.
+// This is equivalent to GetStageRand with an empty stage name.
 func (settings Settings) GetRand(turn int) Rand {
+	return settings.GetStageRand(turn, "")
+}
+
+// GetStageRand returns a random number generator for the named stage or map on the given turn.
+// Each combination of seed, turn and stage produces an independent stream, so the values drawn
+// by one stage never depend on how many values were drawn by another.
+func (settings Settings) GetStageRand(turn int, stage string) Rand {
 	// Allow overriding the random generator for testing
 	if settings.rand != nil {
 		return settings.rand
 	}
 
-	if settings.seed != 0 {
-		return NewSeedRand(settings.seed + int64(turn))
-	}
-
-	// Default to global random number generator if neither seed or rand are set.
-	return GlobalRand
+	return NewStreamRand(settings.seed, turn, stage)
 }
 
 func (settings Settings) WithRand(rand Rand) Settings {
@@ -57,11 +75,17 @@ func (settings Settings) WithRand(rand Rand) Settings {
 	return settings
 }
 
+// Seed returns the seed the settings' random number generators are derived from. Settings that
+// weren't given a seed have a random one, so their games can still be reproduced.
 func (settings Settings) Seed() int64 {
 	return settings.seed
 }
 
+// WithSeed sets the seed used for random numbers. A seed of 0 picks a random seed instead.
 func (settings Settings) WithSeed(seed int64) Settings {
+	if seed == 0 {
+		seed = newSeed()
+	}
 	settings.seed = seed
 	return settings
 }
@@ -76,6 +100,15 @@ func (settings Settings) Bool(paramName string, defaultValue bool) bool {
 	return defaultValue
 }
 
+// String returns the raw value for the specified parameter.
+// If the parameter doesn't exist, the default value will be returned.
+func (settings Settings) String(paramName string, defaultValue string) string {
+	if val, ok := settings.rawValues[paramName]; ok {
+		return val
+	}
+	return defaultValue
+}
+
 // Int returns the int value for the specified parameter.
 // If the parameter doesn't exist, the default value will be returned.
 // If the parameter does exist, but is not a valid int, the default value will be returned.
@@ -2,6 +2,7 @@ package rules
 
 var soloRulesetStages = []string{
 	StageGameOverSoloSnake,
+	StageGameOverTurnLimit,
 	StageMovementStandard,
 	StageStarvationStandard,
 	StageHazardDamageStandard,
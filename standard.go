@@ -1,12 +1,12 @@
 package rules
 
 import (
-	"math/rand"
 	"sort"
 )
 
 var standardRulesetStages = []string{
 	StageGameOverStandard,
+	StageGameOverTurnLimit,
 	StageMovementStandard,
 	StageStarvationStandard,
 	StageHazardDamageStandard,
@@ -138,32 +138,41 @@ func DamageHazardsStandard(b *BoardState, settings Settings, moves []SnakeMove)
 			continue
 		}
 		head := snake.Body[0]
-		for _, p := range b.Hazards {
-			if head == p {
-				// If there's a food in this square, don't reduce health
-				foundFood := false
-				for _, food := range b.Food {
-					if p == food {
-						foundFood = true
-					}
-				}
-				if foundFood {
-					continue
-				}
 
-				// Snake is in a hazard, reduce health
-				snake.Health = snake.Health - hazardDamage
-				if snake.Health < 0 {
-					snake.Health = 0
-				}
-				if snake.Health > SnakeMaxHealth {
-					snake.Health = SnakeMaxHealth
-				}
-				if snakeIsOutOfHealth(snake) {
-					EliminateSnake(snake, EliminatedByHazard, "", b.Turn+1)
-				}
+		// If there's a food in this square, don't change health
+		foundFood := false
+		for _, food := range b.Food {
+			if food.X == head.X && food.Y == head.Y {
+				foundFood = true
+			}
+		}
+		if foundFood {
+			continue
+		}
+
+		// Every hazard in a stack deals its own damage
+		damage, inHazard := 0, false
+		for _, p := range b.Hazards {
+			if p.X == head.X && p.Y == head.Y {
+				damage += p.HazardDamage(hazardDamage)
+				inHazard = true
 			}
 		}
+		if !inHazard {
+			continue
+		}
+
+		// Snake is in a hazard, change health
+		snake.Health = snake.Health - damage
+		if snake.Health < 0 {
+			snake.Health = 0
+		}
+		if snake.Health > SnakeMaxHealth {
+			snake.Health = SnakeMaxHealth
+		}
+		if snakeIsOutOfHealth(snake) {
+			EliminateSnake(snake, EliminatedByHazard, "", b.Turn+1)
+		}
 	}
 
 	return false, nil
@@ -186,7 +195,7 @@ func EliminateSnakesStandard(b *BoardState, settings Settings, moves []SnakeMove
 	})
 
 	// First, iterate over all non-eliminated snakes and eliminate the ones
-	// that are out of health or have moved out of bounds.
+	// that are out of health, have moved out of bounds or have moved into a wall.
 	for i := 0; i < len(b.Snakes); i++ {
 		snake := &b.Snakes[i]
 		if snake.EliminatedCause != NotEliminated {
@@ -205,6 +214,11 @@ func EliminateSnakesStandard(b *BoardState, settings Settings, moves []SnakeMove
 			EliminateSnake(snake, EliminatedByOutOfBounds, "", b.Turn+1)
 			continue
 		}
+
+		if snakeHasHitWall(snake, b.Walls) {
+			EliminateSnake(snake, EliminatedByWall, "", b.Turn+1)
+			continue
+		}
 	}
 
 	// Next, look for any collisions. Note we apply collision eliminations
@@ -307,6 +321,16 @@ func snakeIsOutOfBounds(s *Snake, boardWidth int, boardHeight int) bool {
 	return false
 }
 
+func snakeHasHitWall(s *Snake, walls []Point) bool {
+	head := s.Body[0]
+	for _, wall := range walls {
+		if head.X == wall.X && head.Y == wall.Y {
+			return true
+		}
+	}
+	return false
+}
+
 func snakeHasBodyCollided(s *Snake, other *Snake) bool {
 	head := s.Body[0]
 	for i, body := range other.Body {
@@ -326,6 +350,9 @@ func snakeHasLostHeadToHead(s *Snake, other *Snake) bool {
 	return false
 }
 
+// FeedSnakesStandard feeds snakes whose heads are on food, and ages the food that isn't eaten.
+// Food with a TTL is removed once it has been on the board for that many turns. Food with a
+// nutrition Value feeds snakes that value instead of the usual full meal, see feedSnakeNutrition.
 func FeedSnakesStandard(b *BoardState, settings Settings, moves []SnakeMove) (bool, error) {
 	newFood := []Point{}
 	for _, food := range b.Food {
@@ -339,12 +366,18 @@ func FeedSnakesStandard(b *BoardState, settings Settings, moves []SnakeMove) (bo
 			}
 
 			if snake.Body[0].X == food.X && snake.Body[0].Y == food.Y {
-				feedSnake(snake)
+				feedSnakeNutrition(snake, food.Value)
 				foodHasBeenEaten = true
 			}
 		}
-		// Persist food to next BoardState if not eaten
+		// Persist food to next BoardState if not eaten, unless it has expired
 		if !foodHasBeenEaten {
+			if food.TTL > 0 && !IsInitialization(b, settings, moves) {
+				food.TTL--
+				if food.TTL == 0 {
+					continue
+				}
+			}
 			newFood = append(newFood, food)
 		}
 	}
@@ -358,6 +391,41 @@ func feedSnake(snake *Snake) {
 	snake.Health = SnakeMaxHealth
 }
 
+// feedSnakeNutrition feeds a snake food with a nutrition value, measured in health. A value of 0
+// is a standard full meal. Positive values restore that much health, and grow the snake by one
+// segment for every SnakeMaxHealth of nutrition, and at least one. Negative values are poison,
+// taking away that much health without growing the snake.
+func feedSnakeNutrition(snake *Snake, nutrition int) {
+	switch {
+	case nutrition == 0:
+		feedSnake(snake)
+	case nutrition < 0:
+		snake.Health += nutrition
+		if snake.Health < 0 {
+			snake.Health = 0
+		}
+	default:
+		growth := nutrition / SnakeMaxHealth
+		if growth < 1 {
+			growth = 1
+		}
+		for i := 0; i < growth; i++ {
+			growSnake(snake)
+		}
+		snake.Health += nutrition
+		if snake.Health > SnakeMaxHealth {
+			snake.Health = SnakeMaxHealth
+		}
+	}
+}
+
+// NewFood returns food to spawn at p, which expires after the number of turns set by
+// ParamFoodLifetime and has the nutrition value set by ParamFoodNutrition. Both default to 0,
+// which is food that never expires and is a standard full meal.
+func NewFood(settings Settings, p Point) Point {
+	return Point{X: p.X, Y: p.Y, TTL: settings.Int(ParamFoodLifetime, 0), Value: settings.Int(ParamFoodNutrition, 0)}
+}
+
 func growSnake(snake *Snake) {
 	if len(snake.Body) > 0 {
 		snake.Body = append(snake.Body, snake.Body[len(snake.Body)-1])
@@ -371,14 +439,24 @@ func SpawnFoodStandard(b *BoardState, settings Settings, moves []SnakeMove) (boo
 	}
 	minimumFood := settings.Int(ParamMinimumFood, 0)
 	foodSpawnChance := settings.Int(ParamFoodSpawnChance, 0)
+	rand := settings.GetStageRand(b.Turn, StageSpawnFoodStandard)
 	numCurrentFood := int(len(b.Food))
-	if numCurrentFood < minimumFood {
-		return false, PlaceFoodRandomly(GlobalRand, b, minimumFood-numCurrentFood)
+	var err error
+	if settings.String(ParamFoodSpawner, "") != "" {
+		var spawner FoodSpawner
+		spawner, err = GetFoodSpawner(settings)
+		if err == nil {
+			b.Food = append(b.Food, spawner.SpawnFood(rand, b, settings, GetUnoccupiedPoints(b, false, false))...)
+		}
+	} else if numCurrentFood < minimumFood {
+		err = PlaceFoodRandomly(rand, b, minimumFood-numCurrentFood)
+	} else if foodSpawnChance > 0 && int(rand.Intn(100)) < foodSpawnChance {
+		err = PlaceFoodRandomly(rand, b, 1)
 	}
-	if foodSpawnChance > 0 && int(rand.Intn(100)) < foodSpawnChance {
-		return false, PlaceFoodRandomly(GlobalRand, b, 1)
+	for i := numCurrentFood; i < len(b.Food); i++ {
+		b.Food[i] = NewFood(settings, b.Food[i])
 	}
-	return false, nil
+	return false, err
 }
 
 func GameOverStandard(b *BoardState, settings Settings, moves []SnakeMove) (bool, error) {
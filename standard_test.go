@@ -3,7 +3,6 @@ package rules
 import (
 	"fmt"
 	"math"
-	"math/rand"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -278,7 +277,6 @@ func TestEatingOnLastMove(t *testing.T) {
 		},
 	}
 
-	rand.Seed(0) // Seed with a value that will reliably not spawn food
 	r := getStandardRuleset(Settings{})
 	for _, test := range tests {
 		_, nextState, err := r.Execute(test.prevState, test.moves)
@@ -400,7 +398,6 @@ func TestHeadToHeadOnFood(t *testing.T) {
 		},
 	}
 
-	rand.Seed(0) // Seed with a value that will reliably not spawn food
 	r := getStandardRuleset(Settings{})
 	for _, test := range tests {
 		_, nextState, err := r.Execute(test.prevState, test.moves)
@@ -477,7 +474,6 @@ func TestRegressionIssue19(t *testing.T) {
 		},
 	}
 
-	rand.Seed(0) // Seed with a value that will reliably not spawn food
 	r := getStandardRuleset(Settings{})
 	for _, test := range tests {
 		_, nextState, err := r.Execute(test.prevState, test.moves)
@@ -1266,6 +1262,28 @@ func TestMaybeEliminateSnakesPriority(t *testing.T) {
 	}
 }
 
+func TestMaybeEliminateSnakesWalls(t *testing.T) {
+	b := NewBoardState(10, 10).
+		WithWalls([]Point{{X: 3, Y: 3}, {X: 3, Y: 4}}).
+		WithSnakes([]Snake{
+			{ID: "1", Health: 50, Body: []Point{{X: 3, Y: 3}, {X: 2, Y: 3}, {X: 1, Y: 3}}},
+			{ID: "2", Health: 50, Body: []Point{{X: 3, Y: 3}, {X: 3, Y: 2}}},
+			{ID: "3", Health: 50, Body: []Point{{X: 4, Y: 4}, {X: 4, Y: 3}, {X: 4, Y: 2}}},
+			{ID: "4", Health: 0, Body: []Point{{X: 3, Y: 4}, {X: 4, Y: 4}, {X: 5, Y: 4}}},
+		})
+
+	_, err := EliminateSnakesStandard(b, Settings{}, mockSnakeMoves())
+	require.NoError(t, err)
+
+	// Snakes that run into a wall are removed before collisions, so they can't win head-to-heads
+	require.Equal(t, EliminatedByWall, b.Snakes[0].EliminatedCause)
+	require.Equal(t, EliminatedByWall, b.Snakes[1].EliminatedCause)
+	require.Equal(t, NotEliminated, b.Snakes[2].EliminatedCause)
+	require.Equal(t, EliminatedByOutOfHealth, b.Snakes[3].EliminatedCause)
+	require.Equal(t, 1, b.Snakes[0].EliminatedOnTurn)
+	require.Empty(t, b.Snakes[0].EliminatedBy)
+}
+
 func TestMaybeDamageHazards(t *testing.T) {
 	tests := []struct {
 		Snakes                    []Snake
@@ -1382,6 +1400,37 @@ func TestHazardDamagePerTurn(t *testing.T) {
 	}
 }
 
+func TestHazardDamageValues(t *testing.T) {
+	tests := []struct {
+		Name                     string
+		Health                   int
+		Hazards                  []Point
+		ExpectedHealth           int
+		ExpectedEliminationCause string
+	}{
+		{"default damage", 50, []Point{{X: 0, Y: 0}}, 36, NotEliminated},
+		{"own damage", 50, []Point{{X: 0, Y: 0, Value: 5}}, 45, NotEliminated},
+		{"healing", 50, []Point{{X: 0, Y: 0, Value: -20}}, 70, NotEliminated},
+		{"healing is capped", 95, []Point{{X: 0, Y: 0, Value: -20}}, 100, NotEliminated},
+		{"stacked", 50, []Point{{X: 0, Y: 0}, {X: 0, Y: 0, Value: 5}}, 31, NotEliminated},
+		{"stacked healing and damage", 50, []Point{{X: 0, Y: 0, Value: -20}, {X: 0, Y: 0, Value: 30}}, 40, NotEliminated},
+		{"deadly", 50, []Point{{X: 0, Y: 0, Value: 50}}, 0, EliminatedByHazard},
+		{"elsewhere", 50, []Point{{X: 1, Y: 0, Value: 50}}, 50, NotEliminated},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			b := &BoardState{Snakes: []Snake{{Health: test.Health, Body: []Point{{X: 0, Y: 0}}}}, Hazards: test.Hazards}
+			r := getStandardRuleset(NewSettingsWithParams(ParamHazardDamagePerTurn, "14"))
+
+			_, err := DamageHazardsStandard(b, r.Settings(), mockSnakeMoves())
+			require.NoError(t, err)
+			require.Equal(t, test.ExpectedHealth, b.Snakes[0].Health)
+			require.Equal(t, test.ExpectedEliminationCause, b.Snakes[0].EliminatedCause)
+		})
+	}
+}
+
 func TestMaybeFeedSnakes(t *testing.T) {
 	tests := []struct {
 		Name           string
@@ -1466,6 +1515,73 @@ func TestMaybeFeedSnakes(t *testing.T) {
 	}
 }
 
+func TestFeedSnakesNutrition(t *testing.T) {
+	tests := []struct {
+		Name           string
+		Nutrition      int
+		ExpectedHealth int
+		ExpectedLength int
+	}{
+		{"standard", 0, SnakeMaxHealth, 4},
+		{"partial", 30, 80, 4},
+		{"partial capped", 75, SnakeMaxHealth, 4},
+		{"extra growth", 250, SnakeMaxHealth, 5},
+		{"poison", -20, 30, 3},
+		{"deadly poison", -80, 0, 3},
+	}
+
+	for _, test := range tests {
+		b := NewBoardState(11, 11).
+			WithTurn(5).
+			WithFood([]Point{{X: 2, Y: 1, Value: test.Nutrition}}).
+			WithSnakes([]Snake{{ID: "one", Health: 50, Body: []Point{{X: 2, Y: 1}, {X: 1, Y: 1}, {X: 1, Y: 2}}}})
+		_, err := FeedSnakesStandard(b, Settings{}, mockSnakeMoves())
+		require.NoError(t, err, test.Name)
+		require.Empty(t, b.Food, test.Name)
+		require.Equal(t, test.ExpectedHealth, b.Snakes[0].Health, test.Name)
+		require.Len(t, b.Snakes[0].Body, test.ExpectedLength, test.Name)
+	}
+}
+
+func TestFeedSnakesExpiringFood(t *testing.T) {
+	b := NewBoardState(11, 11).
+		WithTurn(5).
+		WithFood([]Point{{X: 2, Y: 1, TTL: 1}, {X: 5, Y: 5, TTL: 1}, {X: 6, Y: 6, TTL: 3}, {X: 7, Y: 7}}).
+		WithSnakes([]Snake{{ID: "one", Health: 50, Body: []Point{{X: 2, Y: 1}, {X: 1, Y: 1}, {X: 1, Y: 2}}}})
+
+	// Food can still be eaten on its last turn, and food without a TTL never expires
+	_, err := FeedSnakesStandard(b, Settings{}, mockSnakeMoves())
+	require.NoError(t, err)
+	require.Equal(t, SnakeMaxHealth, b.Snakes[0].Health)
+	require.Equal(t, []Point{{X: 6, Y: 6, TTL: 2}, {X: 7, Y: 7}}, b.Food)
+
+	// Food doesn't age while the game is being initialized
+	b.Turn = 0
+	_, err = FeedSnakesStandard(b, Settings{}, nil)
+	require.NoError(t, err)
+	require.Equal(t, []Point{{X: 6, Y: 6, TTL: 2}, {X: 7, Y: 7}}, b.Food)
+}
+
+func TestSpawnFoodSettings(t *testing.T) {
+	settings := NewSettings(map[string]string{
+		ParamMinimumFood:   "3",
+		ParamFoodLifetime:  "10",
+		ParamFoodNutrition: "-15",
+	})
+	require.Equal(t, Point{X: 1, Y: 2, TTL: 10, Value: -15}, NewFood(settings, Point{X: 1, Y: 2}))
+	require.Equal(t, Point{X: 1, Y: 2}, NewFood(Settings{}, Point{X: 1, Y: 2, TTL: 3}))
+
+	b := NewBoardState(11, 11).WithFood([]Point{{X: 4, Y: 5}})
+	_, err := SpawnFoodStandard(b, settings, mockSnakeMoves())
+	require.NoError(t, err)
+	require.Len(t, b.Food, 3)
+	require.Equal(t, Point{X: 4, Y: 5}, b.Food[0], "existing food is unchanged")
+	for _, food := range b.Food[1:] {
+		require.Equal(t, 10, food.TTL)
+		require.Equal(t, -15, food.Value)
+	}
+}
+
 func TestMaybeSpawnFoodMinimum(t *testing.T) {
 	tests := []struct {
 		MinimumFood  int
@@ -1541,15 +1657,15 @@ func TestMaybeSpawnFoodHalfChance(t *testing.T) {
 	}{
 		// Use pre-tested seeds and results
 		{123, []Point{}, 1},
-		{12345, []Point{}, 0},
-		{456, []Point{{X: 4, Y: 4}}, 1},
-		{789, []Point{{X: 4, Y: 4}}, 2},
-		{511, []Point{{X: 4, Y: 4}}, 1},
+		{12345, []Point{}, 1},
+		{456, []Point{{X: 4, Y: 4}}, 2},
+		{789, []Point{{X: 4, Y: 4}}, 1},
+		{511, []Point{{X: 4, Y: 4}}, 2},
 		{165, []Point{{X: 4, Y: 4}}, 2},
 	}
 
-	r := getStandardRuleset(NewSettingsWithParams(ParamFoodSpawnChance, "50"))
 	for _, test := range tests {
+		r := getStandardRuleset(NewSettingsWithParams(ParamFoodSpawnChance, "50").WithSeed(test.Seed))
 		b := &BoardState{
 			Height: 4,
 			Width:  5,
@@ -1560,7 +1676,6 @@ func TestMaybeSpawnFoodHalfChance(t *testing.T) {
 			Food: test.Food,
 		}
 
-		rand.Seed(test.Seed)
 		_, err := SpawnFoodStandard(b, r.Settings(), mockSnakeMoves())
 		require.NoError(t, err)
 		require.Equal(t, test.ExpectedFood, len(b.Food), "Seed %d", test.Seed)
@@ -0,0 +1,111 @@
+package rules
+
+import (
+	"sort"
+	"strings"
+)
+
+// Tie-breakers that can be used to separate snakes that would otherwise share a placement.
+const (
+	TieBreakerLength = "length"
+	TieBreakerHealth = "health"
+)
+
+// Placement is the final position of a snake in a game. Snakes that can't be separated share
+// the same rank, and the following rank is skipped for every extra snake (e.g. 1, 2, 2, 4).
+type Placement struct {
+	SnakeID string
+	Rank    int
+}
+
+// Standings ranks every snake in a finished game.
+//
+// Snakes that haven't been eliminated rank above all eliminated snakes, and snakes eliminated on
+// a later turn rank above snakes eliminated earlier. Snakes eliminated on the same turn, or still
+// in the game when it ended, are tied unless one of the tie-breakers separates them. Tie-breakers
+// are applied in order, and a longer snake or one with more health ranks higher.
+//
+// The placements are returned from first to last. Tied snakes keep the order they appear in the
+// board state.
+func Standings(b *BoardState, tieBreakers []string) ([]Placement, error) {
+	for _, tieBreaker := range tieBreakers {
+		if err := checkTieBreaker(tieBreaker); err != nil {
+			return nil, err
+		}
+	}
+
+	snakes := make([]Snake, len(b.Snakes))
+	copy(snakes, b.Snakes)
+
+	// compare returns a positive number if a should rank above b, negative if below and 0 if tied
+	compare := func(a, b Snake) int {
+		aEliminated, bEliminated := a.EliminatedCause != NotEliminated, b.EliminatedCause != NotEliminated
+		if aEliminated != bEliminated {
+			if bEliminated {
+				return 1
+			}
+			return -1
+		}
+		if aEliminated && a.EliminatedOnTurn != b.EliminatedOnTurn {
+			return a.EliminatedOnTurn - b.EliminatedOnTurn
+		}
+		for _, tieBreaker := range tieBreakers {
+			var diff int
+			switch tieBreaker {
+			case TieBreakerLength:
+				diff = len(a.Body) - len(b.Body)
+			case TieBreakerHealth:
+				diff = a.Health - b.Health
+			}
+			if diff != 0 {
+				return diff
+			}
+		}
+		return 0
+	}
+
+	sort.SliceStable(snakes, func(i, j int) bool {
+		return compare(snakes[i], snakes[j]) > 0
+	})
+
+	placements := make([]Placement, len(snakes))
+	for i, snake := range snakes {
+		rank := i + 1
+		if i > 0 && compare(snakes[i-1], snake) == 0 {
+			rank = placements[i-1].Rank
+		}
+		placements[i] = Placement{SnakeID: snake.ID, Rank: rank}
+	}
+	return placements, nil
+}
+
+// TieBreakers returns the comma-separated tie-breakers configured in the settings, in the order
+// they should be applied. An error is returned if any of them are unknown.
+func TieBreakers(settings Settings) ([]string, error) {
+	value := strings.TrimSpace(settings.String(ParamTieBreakers, ""))
+	if value == "" {
+		return nil, nil
+	}
+	tieBreakers := strings.Split(value, ",")
+	for i := range tieBreakers {
+		tieBreakers[i] = strings.TrimSpace(tieBreakers[i])
+		if err := checkTieBreaker(tieBreakers[i]); err != nil {
+			return nil, err
+		}
+	}
+	return tieBreakers, nil
+}
+
+func checkTieBreaker(tieBreaker string) error {
+	if tieBreaker != TieBreakerLength && tieBreaker != TieBreakerHealth {
+		return RulesetError("unknown tie-breaker: " + tieBreaker)
+	}
+	return nil
+}
+
+// GameOverTurnLimit ends the game once the board reaches the turn limit in the settings.
+// A turn limit of 0 (the default) means games can continue forever.
+func GameOverTurnLimit(b *BoardState, settings Settings, moves []SnakeMove) (bool, error) {
+	turnLimit := settings.Int(ParamTurnLimit, 0)
+	return turnLimit > 0 && b.Turn >= turnLimit, nil
+}
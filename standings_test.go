@@ -0,0 +1,137 @@
+package rules
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestStandings(t *testing.T) {
+	tests := []struct {
+		name        string
+		snakes      []Snake
+		tieBreakers []string
+		expected    []Placement
+	}{
+		{
+			name: "last snake standing",
+			snakes: []Snake{
+				{ID: "one", EliminatedCause: EliminatedByCollision, EliminatedOnTurn: 5},
+				{ID: "two"},
+				{ID: "three", EliminatedCause: EliminatedByOutOfHealth, EliminatedOnTurn: 8},
+			},
+			expected: []Placement{{"two", 1}, {"three", 2}, {"one", 3}},
+		},
+		{
+			name: "simultaneous eliminations tie",
+			snakes: []Snake{
+				{ID: "one", EliminatedCause: EliminatedByCollision, EliminatedOnTurn: 3},
+				{ID: "two", EliminatedCause: EliminatedByHeadToHeadCollision, EliminatedOnTurn: 7},
+				{ID: "three", EliminatedCause: EliminatedByHeadToHeadCollision, EliminatedOnTurn: 7},
+				{ID: "four"},
+			},
+			expected: []Placement{{"four", 1}, {"two", 2}, {"three", 2}, {"one", 4}},
+		},
+		{
+			name: "turn limit reached without tie-breakers",
+			snakes: []Snake{
+				{ID: "one", Body: []Point{{X: 0, Y: 0}, {X: 0, Y: 1}}},
+				{ID: "two", Body: []Point{{X: 1, Y: 0}, {X: 1, Y: 1}, {X: 1, Y: 2}}},
+			},
+			expected: []Placement{{"one", 1}, {"two", 1}},
+		},
+		{
+			name: "length tie-breaker",
+			snakes: []Snake{
+				{ID: "one", Body: []Point{{X: 0, Y: 0}, {X: 0, Y: 1}}, Health: 90},
+				{ID: "two", Body: []Point{{X: 1, Y: 0}, {X: 1, Y: 1}, {X: 1, Y: 2}}, Health: 50},
+			},
+			tieBreakers: []string{TieBreakerLength},
+			expected:    []Placement{{"two", 1}, {"one", 2}},
+		},
+		{
+			name: "tie-breakers applied in order",
+			snakes: []Snake{
+				{ID: "one", Body: []Point{{X: 0, Y: 0}, {X: 0, Y: 1}}, Health: 90},
+				{ID: "two", Body: []Point{{X: 1, Y: 0}, {X: 1, Y: 1}}, Health: 95},
+				{ID: "three", Body: []Point{{X: 2, Y: 0}, {X: 2, Y: 1}, {X: 2, Y: 2}}, Health: 10},
+				{ID: "four", Body: []Point{{X: 3, Y: 0}, {X: 3, Y: 1}}, Health: 90},
+			},
+			tieBreakers: []string{TieBreakerLength, TieBreakerHealth},
+			expected:    []Placement{{"three", 1}, {"two", 2}, {"one", 3}, {"four", 3}},
+		},
+		{
+			name: "tie-breakers don't reorder elimination turns",
+			snakes: []Snake{
+				{ID: "one", Body: []Point{{X: 0, Y: 0}, {X: 0, Y: 1}, {X: 0, Y: 2}}, EliminatedCause: EliminatedByCollision, EliminatedOnTurn: 2},
+				{ID: "two", Body: []Point{{X: 1, Y: 0}}, EliminatedCause: EliminatedByCollision, EliminatedOnTurn: 4},
+			},
+			tieBreakers: []string{TieBreakerLength},
+			expected:    []Placement{{"two", 1}, {"one", 2}},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			b := &BoardState{Snakes: test.snakes}
+			standings, err := Standings(b, test.tieBreakers)
+			require.NoError(t, err)
+			require.Equal(t, test.expected, standings)
+		})
+	}
+}
+
+func TestStandingsUnknownTieBreaker(t *testing.T) {
+	_, err := Standings(&BoardState{}, []string{"score"})
+	require.EqualError(t, err, "unknown tie-breaker: score")
+}
+
+func TestTieBreakers(t *testing.T) {
+	tieBreakers, err := TieBreakers(Settings{})
+	require.NoError(t, err)
+	require.Empty(t, tieBreakers)
+
+	tieBreakers, err = TieBreakers(NewSettingsWithParams(ParamTieBreakers, "health, length"))
+	require.NoError(t, err)
+	require.Equal(t, []string{TieBreakerHealth, TieBreakerLength}, tieBreakers)
+
+	_, err = TieBreakers(NewSettingsWithParams(ParamTieBreakers, "length,,health"))
+	require.EqualError(t, err, "unknown tie-breaker: ")
+}
+
+func TestGameOverTurnLimit(t *testing.T) {
+	noLimit := Settings{}
+	limit := NewSettingsWithParams(ParamTurnLimit, "10")
+
+	gameOver, err := GameOverTurnLimit(&BoardState{Turn: 500}, noLimit, nil)
+	require.NoError(t, err)
+	require.False(t, gameOver)
+
+	gameOver, err = GameOverTurnLimit(&BoardState{Turn: 9}, limit, nil)
+	require.NoError(t, err)
+	require.False(t, gameOver)
+
+	gameOver, err = GameOverTurnLimit(&BoardState{Turn: 10}, limit, nil)
+	require.NoError(t, err)
+	require.True(t, gameOver)
+}
+
+func TestRulesetTurnLimit(t *testing.T) {
+	for _, gameType := range []string{GameTypeStandard, GameTypeConstrictor, GameTypeRoyale, GameTypeWrapped, GameTypeSolo} {
+		t.Run(gameType, func(t *testing.T) {
+			ruleset := NewRulesetBuilder().WithParams(map[string]string{ParamTurnLimit: "3"}).NamedRuleset(gameType)
+			b := &BoardState{
+				Turn:   3,
+				Width:  11,
+				Height: 11,
+				Snakes: []Snake{
+					{ID: "one", Body: []Point{{X: 1, Y: 1}, {X: 1, Y: 1}, {X: 1, Y: 1}}, Health: 100},
+					{ID: "two", Body: []Point{{X: 9, Y: 9}, {X: 9, Y: 9}, {X: 9, Y: 9}}, Health: 100},
+				},
+			}
+			gameOver, _, err := ruleset.Execute(b, nil)
+			require.NoError(t, err)
+			require.True(t, gameOver)
+		})
+	}
+}
@@ -0,0 +1,44 @@
+package rules
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// Maps and pipeline stages keep private state between turns in BoardState.GameState. It's copied
+// by Clone and saved in game exports so that replays can pick it up, but it's never sent to
+// snakes. Values are stored as JSON under keys that start with the ID of the map or stage that
+// owns them, so that owners don't overwrite each other's state.
+
+// StateKey returns the GameState key that a map or stage stores a named value under.
+func StateKey(owner, name string) string {
+	return owner + "/" + name
+}
+
+// SetState stores a value in a GameState as JSON.
+func SetState(gameState map[string]string, owner, name string, value interface{}) error {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Errorf("unable to store state %q: %w", StateKey(owner, name), err)
+	}
+	gameState[StateKey(owner, name)] = string(data)
+	return nil
+}
+
+// GetState reads a value stored by SetState into value, which must be a pointer. It returns
+// false, and leaves value alone, if nothing has been stored.
+func GetState(gameState map[string]string, owner, name string, value interface{}) (bool, error) {
+	data, ok := gameState[StateKey(owner, name)]
+	if !ok {
+		return false, nil
+	}
+	if err := json.Unmarshal([]byte(data), value); err != nil {
+		return false, fmt.Errorf("unable to read state %q: %w", StateKey(owner, name), err)
+	}
+	return true, nil
+}
+
+// DeleteState removes a value stored by SetState.
+func DeleteState(gameState map[string]string, owner, name string) {
+	delete(gameState, StateKey(owner, name))
+}
@@ -0,0 +1,45 @@
+package rules
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestState(t *testing.T) {
+	boardState := NewBoardState(11, 11)
+
+	var level int
+	ok, err := GetState(boardState.GameState, "maze", "level", &level)
+	require.NoError(t, err)
+	require.False(t, ok)
+
+	require.NoError(t, SetState(boardState.GameState, "maze", "level", 3))
+	require.NoError(t, SetState(boardState.GameState, "snail", "tails", []Point{{X: 1, Y: 2, Value: 3}}))
+	require.Equal(t, "3", boardState.GameState["maze/level"])
+
+	// State is copied to the next turn
+	next := boardState.Clone()
+	ok, err = GetState(next.GameState, "maze", "level", &level)
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.Equal(t, 3, level)
+
+	var tails []Point
+	ok, err = GetState(next.GameState, "snail", "tails", &tails)
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.Equal(t, []Point{{X: 1, Y: 2, Value: 3}}, tails)
+
+	DeleteState(next.GameState, "maze", "level")
+	ok, err = GetState(next.GameState, "maze", "level", &level)
+	require.NoError(t, err)
+	require.False(t, ok)
+	require.Contains(t, boardState.GameState, "maze/level", "clones don't share state")
+
+	next.GameState["maze/level"] = "not a number"
+	_, err = GetState(next.GameState, "maze", "level", &level)
+	require.Error(t, err)
+
+	require.Error(t, SetState(next.GameState, "maze", "level", func() {}))
+}
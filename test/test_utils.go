@@ -36,3 +36,22 @@ func RequireJSONMatchesFixture(t *testing.T, filename string, actual string) {
 
 	require.JSONEq(t, string(expectedData), actual)
 }
+
+// RequireTextMatchesFixture asserts that actual is exactly the same as the text read from
+// filename. Like RequireJSONMatchesFixture, the file is regenerated when the `-update-fixtures`
+// flag is passed to `go test`.
+func RequireTextMatchesFixture(t *testing.T, filename string, actual string) {
+	t.Helper()
+
+	if *updateFixtures {
+		err := ioutil.WriteFile(filename, []byte(actual), 0644)
+		require.NoError(t, err, "Failed to update fixture", filename)
+
+		log.Printf("Updating fixture file %#v", filename)
+	}
+
+	expectedData, err := ioutil.ReadFile(filename)
+	require.NoError(t, err, "Failed to read fixture", filename)
+
+	require.Equal(t, string(expectedData), actual, "Fixture %s is out of date, run the tests with -update-fixtures to regenerate it", filename)
+}
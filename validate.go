@@ -0,0 +1,113 @@
+package rules
+
+import "fmt"
+
+// Validate checks that the board state is internally consistent, returning an error that
+// describes the first problem found. A board state is consistent when:
+//   - snake IDs are unique and every snake has a body
+//   - each body segment is on or next to the one before it, either directly or by wrapping
+//     around the edge of the board
+//   - snakes still in the game are on the board
+//   - health is between 0 and SnakeMaxHealth
+//   - eliminated snakes have an elimination turn no later than the next turn, and were only
+//     eliminated by snakes on the board
+//   - food is on the board and no two food share a point
+//   - walls are on the board and have no food on them
+//
+// Hazards aren't checked, as maps are free to stack them or store state outside the board.
+func (b *BoardState) Validate() error {
+	return b.validate(true)
+}
+
+// validate checks the board state, optionally skipping the check that snakes in the game are
+// on the board. Stages run on their own can leave a snake off the board until it's eliminated.
+func (b *BoardState) validate(requireOnBoard bool) error {
+	if b.Width <= 0 || b.Height <= 0 {
+		return fmt.Errorf("invalid board size %dx%d", b.Width, b.Height)
+	}
+
+	ids := make(map[string]bool, len(b.Snakes))
+	for _, snake := range b.Snakes {
+		if ids[snake.ID] {
+			return fmt.Errorf("duplicate snake %q", snake.ID)
+		}
+		ids[snake.ID] = true
+	}
+
+	for _, snake := range b.Snakes {
+		if len(snake.Body) == 0 {
+			return fmt.Errorf("snake %q: %w", snake.ID, ErrorZeroLengthSnake)
+		}
+		for i := 1; i < len(snake.Body); i++ {
+			if !b.isContiguous(snake.Body[i-1], snake.Body[i]) {
+				return fmt.Errorf("snake %q: body is not contiguous at %#v and %#v", snake.ID, snake.Body[i-1], snake.Body[i])
+			}
+		}
+		if snake.Health < 0 || snake.Health > SnakeMaxHealth {
+			return fmt.Errorf("snake %q: health %d is outside 0..%d", snake.ID, snake.Health, SnakeMaxHealth)
+		}
+
+		if snake.EliminatedCause == NotEliminated {
+			if snake.EliminatedOnTurn != 0 || snake.EliminatedBy != "" {
+				return fmt.Errorf("snake %q: has elimination details but no elimination cause", snake.ID)
+			}
+			if requireOnBoard {
+				for _, p := range snake.Body {
+					if !b.isOnBoard(p) {
+						return fmt.Errorf("snake %q: body is off the board at %#v", snake.ID, p)
+					}
+				}
+			}
+			continue
+		}
+		if snake.EliminatedOnTurn <= 0 || snake.EliminatedOnTurn > b.Turn+1 {
+			return fmt.Errorf("snake %q: invalid elimination turn %d on turn %d", snake.ID, snake.EliminatedOnTurn, b.Turn)
+		}
+		if snake.EliminatedBy != "" && !ids[snake.EliminatedBy] {
+			return fmt.Errorf("snake %q: eliminated by unknown snake %q", snake.ID, snake.EliminatedBy)
+		}
+	}
+
+	food := make(map[Point]bool, len(b.Food))
+	for _, p := range b.Food {
+		if !b.isOnBoard(p) {
+			return fmt.Errorf("food is off the board at %#v", p)
+		}
+		key := Point{X: p.X, Y: p.Y}
+		if food[key] {
+			return fmt.Errorf("duplicate food at %#v", key)
+		}
+		food[key] = true
+	}
+
+	for _, p := range b.Walls {
+		if !b.isOnBoard(p) {
+			return fmt.Errorf("wall is off the board at %#v", p)
+		}
+		if food[Point{X: p.X, Y: p.Y}] {
+			return fmt.Errorf("food is in a wall at %#v", p)
+		}
+	}
+
+	return nil
+}
+
+func (b *BoardState) isOnBoard(p Point) bool {
+	return p.X >= 0 && p.X < b.Width && p.Y >= 0 && p.Y < b.Height
+}
+
+// isContiguous checks whether two body segments are stacked, adjacent, or adjacent by
+// wrapping around opposite edges of the board.
+func (b *BoardState) isContiguous(a, c Point) bool {
+	dx, dy := absInt(a.X-c.X), absInt(a.Y-c.Y)
+	if dx+dy <= 1 {
+		return true
+	}
+	if dy == 0 && dx == b.Width-1 {
+		return b.isOnBoard(a) && b.isOnBoard(c)
+	}
+	if dx == 0 && dy == b.Height-1 {
+		return b.isOnBoard(a) && b.isOnBoard(c)
+	}
+	return false
+}
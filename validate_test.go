@@ -0,0 +1,146 @@
+package rules
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidate(t *testing.T) {
+	validSnake := func() Snake {
+		return Snake{ID: "one", Body: []Point{{X: 1, Y: 1}, {X: 1, Y: 2}, {X: 1, Y: 2}}, Health: 100}
+	}
+
+	tests := []struct {
+		name     string
+		modify   func(b *BoardState)
+		expected string
+	}{
+		{
+			name:   "valid",
+			modify: func(b *BoardState) {},
+		},
+		{
+			name: "wrapped body",
+			modify: func(b *BoardState) {
+				b.Snakes[0].Body = []Point{{X: 0, Y: 0}, {X: 10, Y: 0}, {X: 10, Y: 10}}
+			},
+		},
+		{
+			name: "eliminated off the board",
+			modify: func(b *BoardState) {
+				b.Snakes[0].Body = []Point{{X: 1, Y: -1}, {X: 1, Y: 0}, {X: 1, Y: 1}}
+				EliminateSnake(&b.Snakes[0], EliminatedByOutOfBounds, "", 5)
+			},
+		},
+		{
+			name:     "invalid size",
+			modify:   func(b *BoardState) { b.Width = 0 },
+			expected: "invalid board size 0x11",
+		},
+		{
+			name:     "duplicate snake",
+			modify:   func(b *BoardState) { b.Snakes = append(b.Snakes, validSnake()) },
+			expected: `duplicate snake "one"`,
+		},
+		{
+			name:     "empty body",
+			modify:   func(b *BoardState) { b.Snakes[0].Body = nil },
+			expected: `snake "one": snake is length zero`,
+		},
+		{
+			name:     "gap in body",
+			modify:   func(b *BoardState) { b.Snakes[0].Body[2] = Point{X: 1, Y: 4} },
+			expected: `snake "one": body is not contiguous at {X:1, Y:2} and {X:1, Y:4}`,
+		},
+		{
+			name:     "wrapped body off the board",
+			modify:   func(b *BoardState) { b.Snakes[0].Body = []Point{{X: -1, Y: 0}, {X: 9, Y: 0}} },
+			expected: `snake "one": body is not contiguous at {X:-1, Y:0} and {X:9, Y:0}`,
+		},
+		{
+			name:     "off the board",
+			modify:   func(b *BoardState) { b.Snakes[0].Body = []Point{{X: 10, Y: 3}, {X: 11, Y: 3}} },
+			expected: `snake "one": body is off the board at {X:11, Y:3}`,
+		},
+		{
+			name:     "health too high",
+			modify:   func(b *BoardState) { b.Snakes[0].Health = SnakeMaxHealth + 1 },
+			expected: `snake "one": health 101 is outside 0..100`,
+		},
+		{
+			name:     "negative health",
+			modify:   func(b *BoardState) { b.Snakes[0].Health = -1 },
+			expected: `snake "one": health -1 is outside 0..100`,
+		},
+		{
+			name:     "elimination turn without cause",
+			modify:   func(b *BoardState) { b.Snakes[0].EliminatedOnTurn = 2 },
+			expected: `snake "one": has elimination details but no elimination cause`,
+		},
+		{
+			name:     "cause without elimination turn",
+			modify:   func(b *BoardState) { b.Snakes[0].EliminatedCause = EliminatedByCollision },
+			expected: `snake "one": invalid elimination turn 0 on turn 5`,
+		},
+		{
+			name:     "eliminated in the future",
+			modify:   func(b *BoardState) { EliminateSnake(&b.Snakes[0], EliminatedByCollision, "", 7) },
+			expected: `snake "one": invalid elimination turn 7 on turn 5`,
+		},
+		{
+			name:     "eliminated by unknown snake",
+			modify:   func(b *BoardState) { EliminateSnake(&b.Snakes[0], EliminatedByCollision, "two", 6) },
+			expected: `snake "one": eliminated by unknown snake "two"`,
+		},
+		{
+			name:     "food off the board",
+			modify:   func(b *BoardState) { b.Food = append(b.Food, Point{X: 3, Y: 11}) },
+			expected: "food is off the board at {X:3, Y:11}",
+		},
+		{
+			name:     "duplicate food",
+			modify:   func(b *BoardState) { b.Food = append(b.Food, Point{X: 5, Y: 5, TTL: 3}) },
+			expected: "duplicate food at {X:5, Y:5}",
+		},
+		{
+			name:     "wall off the board",
+			modify:   func(b *BoardState) { b.Walls = []Point{{X: -1, Y: 4}} },
+			expected: "wall is off the board at {X:-1, Y:4}",
+		},
+		{
+			name:     "food in a wall",
+			modify:   func(b *BoardState) { b.Walls = []Point{{X: 0, Y: 0}, {X: 5, Y: 5}} },
+			expected: "food is in a wall at {X:5, Y:5}",
+		},
+		{
+			name:   "hazards aren't checked",
+			modify: func(b *BoardState) { b.Hazards = []Point{{X: 0, Y: 0}, {X: 0, Y: 0}, {X: 0, Y: 20}} },
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			b := NewBoardState(11, 11).
+				WithTurn(5).
+				WithFood([]Point{{X: 5, Y: 5}}).
+				WithSnakes([]Snake{validSnake()})
+			test.modify(b)
+
+			err := b.Validate()
+			if test.expected == "" {
+				require.NoError(t, err)
+			} else {
+				require.EqualError(t, err, test.expected)
+			}
+		})
+	}
+}
+
+func TestValidateWithoutBoundsCheck(t *testing.T) {
+	b := NewBoardState(11, 11).WithSnakes([]Snake{
+		{ID: "one", Body: []Point{{X: -1, Y: 0}, {X: 0, Y: 0}}, Health: 100},
+	})
+	require.Error(t, b.Validate())
+	require.NoError(t, b.validate(false))
+}
@@ -2,6 +2,7 @@ package rules
 
 var wrappedRulesetStages = []string{
 	StageGameOverStandard,
+	StageGameOverTurnLimit,
 	StageMovementWrapBoundaries,
 	StageStarvationStandard,
 	StageHazardDamageStandard,
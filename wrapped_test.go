@@ -348,3 +348,23 @@ func TestWrappedCreateNextBoardState(t *testing.T) {
 		gc.requireValidNextState(t, NewRulesetBuilder().PipelineRuleset(GameTypeWrapped, NewPipeline(wrappedRulesetStages...)))
 	}
 }
+
+func TestWrappedWalls(t *testing.T) {
+	boardState := NewBoardState(11, 11).
+		WithWalls([]Point{{X: 10, Y: 5}}).
+		WithSnakes([]Snake{
+			{ID: "one", Health: 100, Body: []Point{{X: 0, Y: 5}, {X: 1, Y: 5}, {X: 2, Y: 5}}},
+			{ID: "two", Health: 100, Body: []Point{{X: 0, Y: 8}, {X: 1, Y: 8}, {X: 2, Y: 8}}},
+		})
+
+	// Snakes wrap across the edge of the board into the wall on the other side
+	_, next, err := getWrappedRuleset(NewSettings(nil)).Execute(boardState, []SnakeMove{
+		{ID: "one", Move: MoveLeft},
+		{ID: "two", Move: MoveLeft},
+	})
+	require.NoError(t, err)
+	require.Equal(t, Point{X: 10, Y: 5}, next.Snakes[0].Body[0])
+	require.Equal(t, EliminatedByWall, next.Snakes[0].EliminatedCause)
+	require.Equal(t, Point{X: 10, Y: 8}, next.Snakes[1].Body[0])
+	require.Equal(t, NotEliminated, next.Snakes[1].EliminatedCause)
+}